@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"frontforge/internal/generators"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+	"sort"
+	"strings"
+)
+
+// runAdd implements "frontforge add <option>=<value>..."
+func (a *App) runAdd(args []string) int {
+	var projectPath string
+	var dryRun bool
+	fs := a.newFlagSet("add", a.printAddHelp)
+	fs.StringVar(&projectPath, "path", ".", "Project directory")
+	fs.BoolVar(&dryRun, "dry-run", false, "Show what would change without writing files")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() == 0 {
+		a.printAddHelp()
+		return ExitUsage
+	}
+
+	changes := make(map[string]string)
+	for _, arg := range fs.Args() {
+		key, value, found := strings.Cut(arg, "=")
+		if !found {
+			return a.usageError(fmt.Errorf("expected <option>=<value>, got '%s'", arg))
+		}
+		g, ok := models.LookupGroup(key)
+		if !ok {
+			return a.usageError(fmt.Errorf("unknown option '%s'. Run 'frontforge list' for all options", key))
+		}
+		parsed, ok := g.Parse(value)
+		if !ok {
+			return a.usageError(fmt.Errorf("invalid %s '%s'. Valid options: %s", g.Name, value, strings.Join(g.IDs(), ", ")))
+		}
+		changes[g.Key] = parsed
+	}
+
+	plan, err := generators.PlanAdd(vfs.Disk{}, projectPath, changes)
+	if err != nil {
+		return a.fail(err)
+	}
+
+	source := "options from .frontforge/manifest.json"
+	if plan.Detected {
+		source = "detected from package.json"
+	}
+	a.printf("%s (%s), %s\n", plan.From.Framework, plan.From.Language, source)
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		if from, to := *g.Field(&plan.From), *g.Field(&plan.To); from != to {
+			a.printf("Adding %s: %s\n", g.Name, to)
+		}
+	}
+
+	a.println()
+	for _, f := range plan.Files {
+		label, ok := upgradeLabels[f.Status]
+		if !ok {
+			continue
+		}
+		a.printf("  %-9s %s", label, f.Path)
+		if f.Conflicts > 0 {
+			a.printf(" (%d conflicting region(s))", f.Conflicts)
+		}
+		a.println()
+	}
+	a.printEntries("dependencies", plan.Dependencies)
+	a.printEntries("devDependencies", plan.DevDependencies)
+	a.printEntries("scripts", plan.Scripts)
+
+	if plan.Changed() == 0 {
+		a.println("Nothing to change.")
+		return ExitOK
+	}
+	a.println()
+	if dryRun {
+		a.printf("Dry run: %d file(s) would change.\n", plan.Changed())
+		return ExitOK
+	}
+	if err := plan.Commit(); err != nil {
+		return a.fail(err)
+	}
+	a.printf("Updated %d file(s).\n", plan.Changed())
+	if len(plan.Dependencies)+len(plan.DevDependencies) > 0 {
+		a.printf("Run '%s install' to install the new dependencies.\n", plan.From.PackageManager)
+	}
+	if n := plan.Conflicts(); n > 0 {
+		a.printf("%d file(s) have conflict markers (<<<<<<< current ... >>>>>>> frontforge add). Resolve them before building.\n", n)
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// printEntries lists the entries added to a package.json section, sorted by name
+func (a *App) printEntries(section string, entries map[string]string) {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		a.printf("  %-9s %s %s (package.json %s)\n", "added", name, entries[name], section)
+	}
+}
+
+// printAddHelp displays help for the add command
+func (a *App) printAddHelp() {
+	w := a.Stderr
+	a.printCommandUsage("add")
+	fmt.Fprintln(w, "Options use the same names and values as 'frontforge new' flags.")
+	fmt.Fprintln(w, "The project's current options are read from .frontforge/manifest.json, or")
+	fmt.Fprintln(w, "detected from package.json and the file layout. Config and setup files the")
+	fmt.Fprintln(w, "option needs are created, vite.config and the main entry file are updated")
+	fmt.Fprintln(w, "(with conflict markers where you changed the same lines), and dependencies")
+	fmt.Fprintln(w, "and scripts are added to package.json. Files that already exist are kept.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "FLAGS:")
+	fmt.Fprintln(w, "  -path <dir>    Project directory (default: current directory)")
+	fmt.Fprintln(w, "  -dry-run       Show what would change without writing files")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Example: frontforge add testing=vitest styling=tailwind")
+	fmt.Fprintln(w)
+}
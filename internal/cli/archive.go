@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"frontforge/internal/generators"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+	"os"
+	"path/filepath"
+)
+
+// archiveConflicts lists the new flags that make no sense with -archive,
+// which writes nothing but the archive
+func archiveConflicts(opts newOptions) []string {
+	var flags []string
+	if opts.path != "" {
+		flags = append(flags, "-path")
+	}
+	if opts.dryRun != "" {
+		flags = append(flags, "-dry-run")
+	}
+	if opts.install {
+		flags = append(flags, "-install")
+	}
+	if opts.verify {
+		flags = append(flags, "-verify")
+	}
+	if opts.onConflict != "" {
+		flags = append(flags, "-on-conflict")
+	}
+	return flags
+}
+
+// writeArchive generates the project into the archive at path. It is
+// written to a temporary file next to path and renamed once complete, so a
+// failed run leaves no partial archive behind.
+func (a *App) writeArchive(config models.Config, path string) (*generators.Result, error) {
+	format, ok := vfs.ArchiveFormatFor(path)
+	if !ok {
+		return nil, fmt.Errorf("unsupported archive '%s': use a .zip, .tar.gz or .tgz file name", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("creating archive: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".frontforge-archive-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating archive: %w", err)
+	}
+	result, err := generators.GenerateArchive(context.Background(), tmp, format, config, a.Stdout)
+	if closeErr := tmp.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("writing archive: %w", closeErr)
+	}
+	if err == nil {
+		// CreateTemp makes the file private; an archive is meant to be shared
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	return result, nil
+}
+
+// extractCommand returns the command that unpacks an archive
+func extractCommand(path string) string {
+	if format, _ := vfs.ArchiveFormatFor(path); format == vfs.FormatZip {
+		return "unzip " + path
+	}
+	return "tar -xzf " + path
+}
@@ -0,0 +1,204 @@
+// Package cli implements the frontforge command-line interface.
+//
+// The CLI is organized into subcommands, each with its own flag set, help
+// text and exit codes:
+//
+//	frontforge new [options]        Create a project (interactive or from flags)
+//	frontforge add <option=value>   Apply options to an existing project
+//	frontforge remove <option>      Take options back out of a generated project
+//	frontforge upgrade [options]    Merge newer templates into a generated project
+//	frontforge list [category]      List available options
+//	frontforge doctor [options]     Audit a project and the environment
+//	frontforge serve [options]      Serve the web form and JSON API
+//	frontforge presets [action]     Manage saved presets
+//	frontforge schema               Print the config file JSON Schema
+//	frontforge versions             Print the package version catalog
+//
+// Running frontforge with no command, or with flags only, is an alias for
+// "frontforge new" so existing invocations keep working.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Exit codes returned by Run
+const (
+	ExitOK      = 0 // Command succeeded
+	ExitFailure = 1 // Command ran but failed (generation error, failed checks, ...)
+	ExitUsage   = 2 // Invalid command, flags or arguments
+	ExitChanges = 3 // new -dry-run=diff: files would be created or modified
+)
+
+// App runs CLI commands, writing output to the configured streams
+type App struct {
+	Stdin  io.Reader // Answers to prompts; nil answers every prompt with its default
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// New creates an App bound to the process stdin, stdout and stderr
+func New() *App {
+	return &App{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
+}
+
+// command describes a single subcommand
+type command struct {
+	name    string
+	usage   string // Argument synopsis shown after the command name
+	summary string // One-line description for the command list
+	run     func(a *App, args []string) int
+}
+
+// commands lists all subcommands in help order. It is filled in init because
+// command help reads the table back (printCommandUsage).
+var commands []command
+
+func init() {
+	commands = []command{
+		{"new", "[options]", "Create a new project (interactive when no options are given)", (*App).runNew},
+		{"add", "[options] <option>=<value>...", "Apply options to an existing project", (*App).runAdd},
+		{"remove", "[options] <option>...", "Take options back out of a generated project", (*App).runRemove},
+		{"upgrade", "[options]", "Merge this version's templates into a generated project", (*App).runUpgrade},
+		{"list", "[category]", "List available options and their aliases", (*App).runList},
+		{"doctor", "[options]", "Audit a project and the development environment", (*App).runDoctor},
+		{"serve", "[options]", "Serve a web form and JSON API that generate projects", (*App).runServe},
+		{"presets", "[list|delete|rename]", "Manage saved presets", (*App).runPresets},
+		{"schema", "", "Print the config file JSON Schema (for editor completion)", (*App).runSchema},
+		{"versions", "[-markdown | -update <file>]", "Print the package versions used in generated projects", (*App).runVersions},
+	}
+}
+
+// findCommand returns the subcommand with the given name
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// Run executes the command line (without the program name) and returns the exit code
+func (a *App) Run(args []string) int {
+	// No command, or flags only: interactive/flag-driven "new"
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		if len(args) > 0 && isHelpFlag(args[0]) {
+			a.printHelp()
+			return ExitOK
+		}
+		return a.runNew(args)
+	}
+
+	name := args[0]
+	if name == "help" {
+		if len(args) > 1 {
+			if cmd, ok := findCommand(args[1]); ok {
+				cmd.run(a, []string{"-help"})
+				return ExitOK
+			}
+			return a.usageError(fmt.Errorf("unknown command '%s'", args[1]))
+		}
+		a.printHelp()
+		return ExitOK
+	}
+
+	cmd, ok := findCommand(name)
+	if !ok {
+		return a.usageError(fmt.Errorf("unknown command '%s'. Run 'frontforge help' for a list of commands", name))
+	}
+	return cmd.run(a, args[1:])
+}
+
+// newFlagSet creates a flag set for a subcommand whose help goes to stderr
+func (a *App) newFlagSet(name string, help func()) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.Stderr)
+	fs.Usage = help
+	return fs
+}
+
+// parseFlags parses a subcommand's flags. The returned code is ExitOK when
+// help was requested and ExitUsage on a parse error; ok is true otherwise.
+func parseFlags(fs *flag.FlagSet, args []string) (code int, ok bool) {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return ExitOK, false
+		}
+		return ExitUsage, false
+	}
+	return ExitOK, true
+}
+
+// isHelpFlag reports whether arg requests help
+func isHelpFlag(arg string) bool {
+	switch arg {
+	case "-h", "-help", "--help":
+		return true
+	}
+	return false
+}
+
+// fail prints an error and returns ExitFailure
+func (a *App) fail(err error) int {
+	fmt.Fprintf(a.Stderr, "Error: %v\n", err)
+	return ExitFailure
+}
+
+// usageError prints an error and returns ExitUsage
+func (a *App) usageError(err error) int {
+	fmt.Fprintf(a.Stderr, "Error: %v\n", err)
+	return ExitUsage
+}
+
+// printf writes formatted human output
+func (a *App) printf(format string, args ...interface{}) {
+	fmt.Fprintf(a.Stdout, format, args...)
+}
+
+// println writes a line of human output
+func (a *App) println(args ...interface{}) {
+	fmt.Fprintln(a.Stdout, args...)
+}
+
+// printHelp displays the top-level help with the list of commands
+func (a *App) printHelp() {
+	w := a.Stdout
+	fmt.Fprintln(w, "FRONTFORGE - Modern Frontend Project Scaffolding")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "USAGE:")
+	fmt.Fprintln(w, "  frontforge [command] [options]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Running without a command starts the interactive TUI (same as 'frontforge new').")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "COMMANDS:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-9s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Run 'frontforge help <command>' or 'frontforge <command> -h' for command options.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "EXIT CODES:")
+	fmt.Fprintln(w, "  0  Success")
+	fmt.Fprintln(w, "  1  Command failed")
+	fmt.Fprintln(w, "  2  Invalid command, flags or arguments")
+	fmt.Fprintln(w, "  3  new -dry-run=diff found files that would be created or modified")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "EXAMPLES:")
+	fmt.Fprintln(w, "  frontforge")
+	fmt.Fprintln(w, "  frontforge new -quick -name my-app")
+	fmt.Fprintln(w, "  frontforge new -config stack.json")
+	fmt.Fprintln(w, "  frontforge list framework")
+	fmt.Fprintln(w, "  frontforge doctor -pm pnpm")
+	fmt.Fprintln(w)
+}
+
+// printCommandUsage prints the synopsis line shared by all command help screens
+func (a *App) printCommandUsage(name string) {
+	cmd, _ := findCommand(name)
+	fmt.Fprintf(a.Stderr, "Usage: frontforge %s %s\n\n%s\n\n", cmd.name, cmd.usage, cmd.summary)
+}
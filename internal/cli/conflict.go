@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bufio"
+	"frontforge/internal/generators"
+	"frontforge/internal/models"
+	"frontforge/internal/textdiff"
+	"frontforge/internal/vfs"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// conflictLabels describes each conflict action in the summary
+var conflictLabels = map[string]string{
+	models.OnConflictSkip:      "kept",
+	models.OnConflictOverwrite: "replaced",
+	models.OnConflictBackup:    "backed up",
+	models.OnConflictMerge:     "merged",
+}
+
+// isConflictPolicy reports whether policy is a valid -on-conflict value
+func isConflictPolicy(policy string) bool {
+	for _, p := range models.OnConflictPolicies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
+
+// generate runs the generation, printing progress through h. Under the
+// prompt policy each conflicting file is shown as a diff and the action is
+// read from stdin before anything is committed.
+func (a *App) generate(h *App, config models.Config) (*generators.Result, error) {
+	if config.OnConflict != models.OnConflictPrompt || config.DryRun {
+		return generators.Generate(config, h.Stdout)
+	}
+
+	staged, err := generators.Stage(config, h.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	choices := h.promptConflicts(staged.Conflicts, config.ProjectPath, a.Stdin)
+	return staged.Commit(choices)
+}
+
+// promptConflicts asks what to do with each conflicting file. An empty
+// answer or the end of input keeps the file; an upper-case answer applies to
+// the remaining files too, except that files which can't be merged are still
+// asked about after "M".
+func (a *App) promptConflicts(conflicts []vfs.Conflict, root string, in io.Reader) map[string]string {
+	choices := make(map[string]string)
+	if len(conflicts) == 0 {
+		return choices
+	}
+	a.println()
+	a.printf("%d existing file(s) differ from the generated ones.\n", len(conflicts))
+	if in == nil {
+		a.println("No input available; keeping the existing files.")
+		return choices
+	}
+
+	reader := bufio.NewReader(in)
+	all := ""
+	for _, c := range conflicts {
+		canMerge := generators.CanMerge(c.Path)
+		if all != "" && (all != models.OnConflictMerge || canMerge) {
+			choices[c.Path] = all
+			continue
+		}
+		rel, _ := filepath.Rel(root, c.Path)
+		rel = filepath.ToSlash(rel)
+		a.println()
+		a.printf("%s", textdiff.Unified(rel+" (existing)", rel+" (generated)", string(c.Existing), string(c.Generated), 3))
+
+		if all != "" {
+			a.printf("%s can't be merged; choose another action for it.\n", rel)
+		}
+		options, valid := "[s]kip, [o]verwrite, [b]ackup", "s, o or b"
+		if canMerge {
+			options, valid = options+", [m]erge", "s, o, b or m"
+		}
+		for {
+			a.printf("%s: %s? (default skip) ", rel, options)
+			line, err := reader.ReadString('\n')
+			answer := strings.TrimSpace(line)
+			if answer == "" && err != nil {
+				// End of input: keep this and every remaining file
+				a.println()
+				all = models.OnConflictSkip
+				choices[c.Path] = all
+				break
+			}
+			action, ok := parseConflictAnswer(strings.ToLower(answer), canMerge)
+			if !ok {
+				a.printf("Please answer %s (upper case applies to all remaining files; \"M\" only to those that can be merged).\n", valid)
+				continue
+			}
+			choices[c.Path] = action
+			if answer != strings.ToLower(answer) {
+				all = action
+			}
+			break
+		}
+	}
+	return choices
+}
+
+// parseConflictAnswer maps a prompt answer to a conflict action
+func parseConflictAnswer(answer string, canMerge bool) (string, bool) {
+	switch answer {
+	case "", "s", "skip":
+		return models.OnConflictSkip, true
+	case "o", "overwrite":
+		return models.OnConflictOverwrite, true
+	case "b", "backup":
+		return models.OnConflictBackup, true
+	case "m", "merge":
+		return models.OnConflictMerge, canMerge
+	}
+	return "", false
+}
+
+// printConflicts summarizes what happened to each existing file
+func (a *App) printConflicts(outcomes []generators.ConflictOutcome) {
+	if len(outcomes) == 0 {
+		return
+	}
+	a.println()
+	a.println("Existing files:")
+	for _, o := range outcomes {
+		a.printf("  %-10s %s\n", conflictLabels[o.Action], o.Path)
+	}
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"fmt"
+	"frontforge/internal/generators"
+	"frontforge/internal/models"
+	"frontforge/internal/preflight"
+	"frontforge/internal/vfs"
+	"path/filepath"
+	"strings"
+)
+
+// Check IDs of the environment checks, alongside the project checks'
+// generators.Check* IDs
+const (
+	checkNode           = "node"
+	checkPackageManager = "package-manager"
+)
+
+// doctorReport is the document printed by "frontforge doctor -output json"
+type doctorReport struct {
+	Path     string                   `json:"path"`
+	Passed   bool                     `json:"passed"`
+	Config   *models.Config           `json:"config,omitempty"` // nil without a package.json
+	Detected bool                     `json:"detected"`         // Config was detected rather than read from the manifest
+	Error    string                   `json:"error,omitempty"`  // Why the project checks didn't run
+	Checks   []generators.DoctorCheck `json:"checks"`
+}
+
+// doctorLabels are the markers printed before each check
+var doctorLabels = map[string]string{
+	generators.DoctorPass: "[OK]",
+	generators.DoctorWarn: "[WARN]",
+	generators.DoctorFail: "[FAIL]",
+	generators.DoctorSkip: "[SKIP]",
+}
+
+// runDoctor implements "frontforge doctor"
+func (a *App) runDoctor(args []string) int {
+	var projectPath, pm, output string
+	fs := a.newFlagSet("doctor", a.printDoctorHelp)
+	fs.StringVar(&projectPath, "path", ".", "Project directory to inspect")
+	fs.StringVar(&pm, "pm", "", "Package manager to check (default: detected from lock file, else npm)")
+	fs.StringVar(&output, "output", outputText, "Output format: text or json")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() > 0 {
+		return a.usageError(fmt.Errorf("unexpected argument '%s'", fs.Arg(0)))
+	}
+	if output != outputText && output != outputJSON {
+		return a.usageError(fmt.Errorf("invalid output format '%s'. Valid options: %s, %s", output, outputText, outputJSON))
+	}
+
+	if pm != "" {
+		g, _ := models.LookupGroup("packageManager")
+		parsed, ok := g.Parse(pm)
+		if !ok {
+			return a.usageError(fmt.Errorf("invalid package manager '%s'. Valid options: %s", pm, strings.Join(g.IDs(), ", ")))
+		}
+		pm = parsed
+	} else {
+		pm = generators.DetectPackageManager(vfs.Disk{}, projectPath)
+	}
+
+	report := &doctorReport{Path: projectPath, Checks: []generators.DoctorCheck{}}
+	if abs, err := filepath.Abs(projectPath); err == nil {
+		report.Path = abs
+	}
+
+	// Project checks need a package.json; without one only the environment is checked
+	if vfs.Exists(vfs.Disk{}, filepath.Join(projectPath, "package.json")) {
+		diagnosis, err := generators.Diagnose(vfs.Disk{}, projectPath)
+		if err != nil {
+			report.Error = err.Error()
+		} else {
+			report.Config, report.Detected = &diagnosis.Config, diagnosis.Detected
+			report.Checks = append(report.Checks, diagnosis.Checks...)
+		}
+	}
+	for _, check := range []struct {
+		id     string
+		result preflight.CheckResult
+	}{
+		{checkNode, preflight.CheckNodeJS()},
+		{checkPackageManager, preflight.CheckPackageManager(pm)},
+	} {
+		c := generators.DoctorCheck{ID: check.id, Name: check.result.Name, Status: generators.DoctorPass}
+		if !check.result.Passed {
+			c.Status, c.Message = generators.DoctorFail, check.result.Message
+			if check.result.Suggestion != "" {
+				c.Details = []string{check.result.Suggestion}
+			}
+		}
+		report.Checks = append(report.Checks, c)
+	}
+
+	report.Passed = report.Error == ""
+	for _, c := range report.Checks {
+		if c.Status == generators.DoctorFail {
+			report.Passed = false
+		}
+	}
+
+	if output == outputJSON {
+		if err := a.writeJSON(report); err != nil {
+			return a.fail(err)
+		}
+	} else {
+		a.printDoctorReport(report)
+	}
+	if !report.Passed {
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// printDoctorReport prints a doctor report for people
+func (a *App) printDoctorReport(r *doctorReport) {
+	switch {
+	case r.Config != nil:
+		source := "options from .frontforge/manifest.json"
+		if r.Detected {
+			source = "detected from package.json"
+		}
+		a.printf("Checking %s: %s (%s), %s\n", r.Path, r.Config.Framework, r.Config.Language, source)
+	case r.Error != "":
+		a.printf("Checking %s\n", r.Path)
+		a.printf("  [FAIL] Project: %s\n", r.Error)
+	default:
+		a.printf("No package.json in %s; checking the development environment only.\n", r.Path)
+	}
+
+	for _, c := range r.Checks {
+		a.printf("  %-6s %s (%s)", doctorLabels[c.Status], c.Name, c.ID)
+		if c.Message != "" {
+			a.printf(": %s", c.Message)
+		}
+		a.println()
+		for _, detail := range c.Details {
+			a.printf("         - %s\n", detail)
+		}
+	}
+
+	a.println()
+	if r.Passed {
+		a.println("All checks passed.")
+	} else {
+		a.println("Some checks failed. Please resolve the issues above.")
+	}
+}
+
+// printDoctorHelp displays help for the doctor command
+func (a *App) printDoctorHelp() {
+	w := a.Stderr
+	a.printCommandUsage("doctor")
+	fmt.Fprintln(w, "Audits a project against what frontforge would generate for it, then checks")
+	fmt.Fprintln(w, "the development environment. The project's options are read from")
+	fmt.Fprintln(w, ".frontforge/manifest.json, or detected from package.json.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "CHECKS (IDs are stable; use them to match -output json):")
+	fmt.Fprintln(w, "  core-files       Core files exist and aren't empty")
+	fmt.Fprintln(w, "  package-json     package.json parses and has the required fields")
+	fmt.Fprintln(w, "  scripts          The generated scripts (dev, build, ...) are there")
+	fmt.Fprintln(w, "  index-html       index.html loads the main entry file, which exists")
+	fmt.Fprintln(w, "  tsconfig         tsconfig files parse, references exist, types are installed")
+	fmt.Fprintln(w, "  eslint-plugins   Packages the ESLint config uses are installed")
+	fmt.Fprintln(w, "  imports          Imports resolve to a declared dependency or an existing file")
+	fmt.Fprintln(w, "  manifest-drift   Files and dependencies changed since generation (warning)")
+	fmt.Fprintln(w, "  node             Node.js meets the minimum version")
+	fmt.Fprintln(w, "  package-manager  The package manager is installed")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "FLAGS:")
+	fmt.Fprintln(w, "  -path <dir>     Project directory to inspect (default: current directory)")
+	fmt.Fprintln(w, "  -pm <name>      Package manager to check: npm, yarn, pnpm, bun")
+	fmt.Fprintln(w, "                  (default: detected from the lock file, else npm)")
+	fmt.Fprintln(w, "  -output <fmt>   Output format: text (default) or json")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Without a package.json only the environment is checked. Exits with code 1")
+	fmt.Fprintln(w, "if any check fails; warnings don't count.")
+	fmt.Fprintln(w)
+}
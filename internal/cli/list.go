@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+	"frontforge/internal/models"
+	"strings"
+)
+
+// runList implements "frontforge list [category]"
+func (a *App) runList(args []string) int {
+	fs := a.newFlagSet("list", a.printListHelp)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	switch fs.NArg() {
+	case 0:
+		// Summary of every option flag
+		for _, g := range optionFlags() {
+			a.printf("%-18s -%-10s %s\n", g.Name, g.Flag, strings.Join(g.IDs(), ", "))
+		}
+		return ExitOK
+	case 1:
+		g, ok := models.LookupGroup(fs.Arg(0))
+		if !ok {
+			return a.usageError(fmt.Errorf("unknown category '%s'. Run 'frontforge list' for all categories", fs.Arg(0)))
+		}
+		// Each accepted value with the option it selects and the frameworks supporting it
+		for _, o := range g.Options {
+			a.printf("%-18s %-28s %s\n", o.ID, o.Value, supportedFrameworks(g, o))
+		}
+		return ExitOK
+	default:
+		return a.usageError(fmt.Errorf("list takes at most one category"))
+	}
+}
+
+// supportedFrameworks describes which frameworks accept an option
+func supportedFrameworks(g *models.OptionGroup, o models.Option) string {
+	frameworks := o.Frameworks
+	if frameworks == nil {
+		frameworks = g.Frameworks
+	}
+	if frameworks == nil {
+		return "all frameworks"
+	}
+	return strings.Join(frameworks, ", ")
+}
+
+// printListHelp displays help for the list command
+func (a *App) printListHelp() {
+	w := a.Stderr
+	a.printCommandUsage("list")
+	fmt.Fprintln(w, "Without a category, lists every option flag accepted by 'frontforge new'.")
+	fmt.Fprintln(w, "With a category (e.g. framework, pm, styling), lists its values.")
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,705 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"frontforge/internal/configfile"
+	"frontforge/internal/generators"
+	"frontforge/internal/models"
+	"frontforge/internal/preflight"
+	"frontforge/internal/presets"
+	"frontforge/internal/tui"
+	"frontforge/internal/vfs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newOptions holds the flags accepted by "frontforge new"
+type newOptions struct {
+	path          string
+	name          string
+	quick         bool
+	dryRun        string // -dry-run format; empty when not a dry run
+	dryRunContent bool   // -dry-run-content
+	archive       string // -archive file; empty to write the project to disk
+	install       bool
+	verify        bool
+	noScaffold    bool
+	latest        bool          // -resolve-latest
+	onConflict    string        // -on-conflict policy; empty means overwrite (prompt in the TUI)
+	verifyTimeout time.Duration // -verify-timeout; zero keeps each step's default
+	configPath    string
+	presetName    string
+	output        string             // outputText or outputJSON
+	options       map[string]*string // Option flag values keyed by flag name
+}
+
+// dryRunFlag is -dry-run: a boolean flag that also takes a format, as in
+// -dry-run=diff. Plain -dry-run selects the tree.
+type dryRunFlag struct{ format *string }
+
+func (f dryRunFlag) String() string {
+	if f.format == nil {
+		return ""
+	}
+	return *f.format
+}
+
+func (f dryRunFlag) Set(value string) error {
+	switch value {
+	case "true":
+		*f.format = models.DryRunTree
+	case "false":
+		*f.format = ""
+	default:
+		for _, format := range models.DryRunFormats {
+			if value == format {
+				*f.format = value
+				return nil
+			}
+		}
+		return fmt.Errorf("valid formats: %s", strings.Join(models.DryRunFormats, ", "))
+	}
+	return nil
+}
+
+func (f dryRunFlag) IsBoolFlag() bool { return true }
+
+// machineDryRun reports whether a -dry-run format is meant for programs,
+// which read it from stdout
+func machineDryRun(format string) bool {
+	return format == models.DryRunJSON || format == models.DryRunFiles
+}
+
+// runNew implements "frontforge new": the interactive TUI by default, or
+// non-interactive generation from flags, a config file or a preset
+func (a *App) runNew(args []string) int {
+	var opts newOptions
+	fs := a.newFlagSet("new", a.printNewHelp)
+
+	fs.StringVar(&opts.path, "path", "", "Project path (use '.' for current directory, or specify a folder name)")
+
+	// Non-interactive flags
+	fs.BoolVar(&opts.quick, "quick", false, "Use quick preset (React + TypeScript + Tailwind) and skip interactive mode")
+	fs.Var(dryRunFlag{&opts.dryRun}, "dry-run", "Preview mode: show what files would be generated without writing them (-dry-run=diff|json|files for other formats)")
+	fs.BoolVar(&opts.dryRunContent, "dry-run-content", false, "Include file contents in -dry-run=json")
+	fs.StringVar(&opts.archive, "archive", "", "Write the project to a .zip or .tar.gz archive instead of a directory")
+	fs.BoolVar(&opts.install, "install", false, "Automatically run package manager install after generation")
+	fs.BoolVar(&opts.verify, "verify", false, "After install, run the project's typecheck, lint, test and build scripts")
+	fs.DurationVar(&opts.verifyTimeout, "verify-timeout", 0, "Time limit for each -verify step (default: per step)")
+	fs.BoolVar(&opts.latest, "resolve-latest", false, "Use the latest published package versions from the npm registry")
+	fs.StringVar(&opts.name, "name", "", "Project name (required for non-interactive mode)")
+	fs.StringVar(&opts.output, "output", outputText, "Output format: text or json (non-interactive mode only)")
+	fs.StringVar(&opts.onConflict, "on-conflict", "", "Existing files: "+strings.Join(models.OnConflictPolicies, ", ")+" (default overwrite, prompt in interactive mode)")
+
+	// Option flags (-framework, -lang, -pm, ...)
+	opts.options = make(map[string]*string)
+	for _, g := range optionFlags() {
+		opts.options[g.Flag] = fs.String(g.Flag, "", fmt.Sprintf("%s: %s", g.Name, strings.Join(g.IDs(), ", ")))
+	}
+
+	// Meta-framework debugging
+	fs.BoolVar(&opts.noScaffold, "no-scaffold", false, "Skip upstream CLI scaffold (meta-frameworks only, for debugging)")
+
+	// Declarative config file and saved presets
+	fs.StringVar(&opts.configPath, "config", "", "Load project configuration from a JSON or YAML file")
+	fs.StringVar(&opts.presetName, "preset", "", "Load a saved preset by name (see 'frontforge presets')")
+
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() > 0 {
+		return a.usageError(fmt.Errorf("unexpected argument '%s'", fs.Arg(0)))
+	}
+
+	if opts.output != outputText && opts.output != outputJSON {
+		return a.usageError(fmt.Errorf("invalid output format '%s'. Valid options: %s, %s", opts.output, outputText, outputJSON))
+	}
+
+	// From here on -output json is known, so errors are reported as documents
+	invalid := func(err error) int {
+		return a.reportError(opts, models.Config{}, ExitUsage, err)
+	}
+	if opts.configPath != "" && opts.presetName != "" {
+		return invalid(fmt.Errorf("-config and -preset cannot be used together"))
+	}
+	if opts.dryRunContent && opts.dryRun != models.DryRunJSON {
+		return invalid(fmt.Errorf("-dry-run-content requires -dry-run=json"))
+	}
+	if machineDryRun(opts.dryRun) && opts.output == outputJSON {
+		return invalid(fmt.Errorf("-dry-run=%s and -output json both write to stdout; use one", opts.dryRun))
+	}
+	if opts.archive != "" {
+		if _, ok := vfs.ArchiveFormatFor(opts.archive); !ok {
+			return invalid(fmt.Errorf("invalid -archive '%s'. Use a .zip, .tar.gz or .tgz file name", opts.archive))
+		}
+		if flags := archiveConflicts(opts); len(flags) > 0 {
+			return invalid(fmt.Errorf("-archive cannot be used with %s", strings.Join(flags, ", ")))
+		}
+	}
+	if opts.onConflict != "" && !isConflictPolicy(opts.onConflict) {
+		return invalid(fmt.Errorf("invalid -on-conflict '%s'. Valid options: %s", opts.onConflict, strings.Join(models.OnConflictPolicies, ", ")))
+	}
+
+	// Config file mode: flags given alongside -config override file values
+	if opts.configPath != "" {
+		file, err := configfile.Load(opts.configPath)
+		if err != nil {
+			return a.reportError(opts, models.Config{}, ExitFailure, err)
+		}
+		return a.runConfigFile(file, opts)
+	}
+
+	// Preset mode: same as config file mode, loaded from the preset store
+	if opts.presetName != "" {
+		if opts.name == "" {
+			code := invalid(fmt.Errorf("-name flag is required when using -preset"))
+			fmt.Fprintln(a.Stderr, "Usage: frontforge new -preset <name> -name my-project")
+			return code
+		}
+		store, err := presets.Default()
+		if err != nil {
+			return a.reportError(opts, models.Config{}, ExitFailure, err)
+		}
+		file, err := store.Load(opts.presetName)
+		if err != nil {
+			return a.reportError(opts, models.Config{}, ExitFailure, err)
+		}
+		return a.runConfigFile(file, opts)
+	}
+
+	// Check if running in non-interactive mode
+	if opts.quick || opts.name != "" {
+		return a.runFlags(opts)
+	}
+
+	if opts.output == outputJSON {
+		return invalid(fmt.Errorf("-output json requires non-interactive mode (-name, -quick, -config or -preset)"))
+	}
+	if opts.archive != "" {
+		return invalid(fmt.Errorf("-archive requires non-interactive mode (-name, -quick, -config or -preset)"))
+	}
+	return a.runInteractive(opts)
+}
+
+// runInteractive starts the TUI
+func (a *App) runInteractive(opts newOptions) int {
+	projectPath := opts.path
+	// Resolve the absolute project path
+	var absPath string
+	var userPath string
+
+	if projectPath != "" {
+		// Either the current directory or a folder to create.
+		// With no path, resolution is deferred until the project name is entered.
+		resolved, err := resolveProjectPath(projectPath, "")
+		if err != nil {
+			return a.fail(err)
+		}
+		absPath = resolved
+		userPath = projectPath
+	}
+
+	// Create the Bubbletea program with project path
+	model := tui.NewModelWithPath(absPath, userPath)
+	if opts.onConflict != "" {
+		model.SetConflictPolicy(opts.onConflict)
+	}
+	if opts.verify {
+		model.SetVerify(opts.verifyTimeout)
+	}
+	p := tea.NewProgram(model)
+
+	// Run the program
+	if _, err := p.Run(); err != nil {
+		return a.fail(fmt.Errorf("running program: %w", err))
+	}
+	return ExitOK
+}
+
+// runFlags generates a project from command-line flags without the TUI
+func (a *App) runFlags(opts newOptions) int {
+	// Validate project name is provided
+	if opts.name == "" {
+		code := a.reportError(opts, models.Config{}, ExitUsage, fmt.Errorf("-name flag is required for non-interactive mode"))
+		fmt.Fprintln(a.Stderr, "Usage: frontforge new -quick -name my-project")
+		return code
+	}
+
+	// Validate project name format
+	if !models.ValidProjectName(opts.name) {
+		return a.reportError(opts, models.Config{}, ExitUsage, fmt.Errorf("invalid project name. Use only letters, numbers, hyphens, and underscores"))
+	}
+
+	// Start with quick preset as base
+	config := models.QuickPreset()
+	config.ProjectName = opts.name
+	config.DryRun = opts.dryRun != ""
+	config.DryRunFormat = opts.dryRun
+	config.AutoInstall = opts.install
+	config.Verify = opts.verify
+	config.NoScaffold = opts.noScaffold
+	config.ResolveLatest = opts.latest
+	config.OnConflict = opts.onConflict
+
+	// Apply overrides if provided
+	if err := applyFlagOverrides(&config, opts); err != nil {
+		return a.reportError(opts, config, ExitUsage, err)
+	}
+
+	return a.runGeneration(config, opts.path, opts)
+}
+
+// applyFlagOverrides parses the option flags into config. The framework is
+// applied first so its defaults can be overridden by the remaining flags.
+func applyFlagOverrides(config *models.Config, opts newOptions) error {
+	for _, g := range optionFlags() {
+		value := *opts.options[g.Flag]
+		if value == "" {
+			continue
+		}
+		parsed, ok := g.Parse(value)
+		if !ok {
+			return fmt.Errorf("invalid %s '%s'. Valid options: %s", g.Name, value, strings.Join(g.IDs(), ", "))
+		}
+		previous := *g.Field(config)
+		*g.Field(config) = parsed
+
+		// Adjust framework-specific defaults before the remaining flags apply
+		if g.Key == "framework" {
+			if parsed != previous {
+				configfile.AdjustFrameworkDefaults(config)
+			}
+			continue
+		}
+
+		// Reject options the framework never asks about instead of silently ignoring them
+		if !g.AppliesTo(config.Framework) {
+			return fmt.Errorf("-%s is not available for %s", g.Flag, config.Framework)
+		}
+	}
+
+	// Replace defaults that clash with explicit flags (e.g. Shadcn/ui after
+	// -styling sass); clashes between explicit flags are reported later
+	models.FixCompatibility(config, func(key string) bool {
+		g, _ := models.LookupGroup(key)
+		return *opts.options[g.Flag] == ""
+	})
+
+	return nil
+}
+
+// runConfigFile generates a project from a loaded config file or preset.
+// Non-empty CLI values take precedence over the file.
+func (a *App) runConfigFile(file *configfile.File, opts newOptions) int {
+	// Start from the quick preset, switch framework defaults, then layer the
+	// file on top. A -framework flag replaces the file's framework first so
+	// the file's other values still apply.
+	f := *file
+	if g, _ := models.LookupGroup("framework"); *opts.options[g.Flag] != "" {
+		if parsed, ok := g.Parse(*opts.options[g.Flag]); ok {
+			f.Framework = parsed
+		}
+	}
+	config := f.Resolve()
+
+	// Option flags override the file; file values that clash with them give way
+	if err := applyFlagOverrides(&config, opts); err != nil {
+		return a.reportError(opts, config, ExitUsage, err)
+	}
+
+	if opts.name != "" {
+		config.ProjectName = opts.name
+	}
+	projectPath := opts.path
+	if projectPath == "" {
+		projectPath = file.Path
+	}
+	config.DryRun = opts.dryRun != ""
+	config.DryRunFormat = opts.dryRun
+	config.AutoInstall = config.AutoInstall || opts.install
+	config.Verify = opts.verify
+	config.NoScaffold = config.NoScaffold || opts.noScaffold
+	config.ResolveLatest = opts.latest
+	config.OnConflict = opts.onConflict
+
+	if config.ProjectName == "" {
+		return a.reportError(opts, config, ExitUsage, fmt.Errorf("project name is required (set \"name\" in the config file or pass -name)"))
+	}
+	if !models.ValidProjectName(config.ProjectName) {
+		return a.reportError(opts, config, ExitUsage, fmt.Errorf("invalid project name. Use only letters, numbers, hyphens, and underscores"))
+	}
+
+	return a.runGeneration(config, projectPath, opts)
+}
+
+// reportError ends a non-interactive run that failed before generation,
+// with a report under -output json like runGeneration's
+func (a *App) reportError(opts newOptions, config models.Config, code int, err error) int {
+	return a.finishReport(newReport(config), opts.output, code, err)
+}
+
+// resolveProjectPath turns the -path value into an absolute path.
+// An empty path means a new folder named after the project.
+func resolveProjectPath(projectPath, projectName string) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("getting current directory: %w", err)
+	}
+
+	switch {
+	case projectPath == "":
+		return filepath.Join(cwd, projectName), nil
+	case projectPath == ".":
+		return cwd, nil
+	case filepath.IsAbs(projectPath):
+		return filepath.Clean(projectPath), nil
+	default:
+		return filepath.Join(cwd, projectPath), nil
+	}
+}
+
+// runGeneration resolves the project path, validates the config, runs preflight
+// checks and generates the project. Shared by flag and config-file modes.
+func (a *App) runGeneration(config models.Config, projectPath string, opts newOptions) int {
+	output := opts.output
+	report := newReport(config)
+
+	// In JSON mode stdout carries only the report (or the dry-run output);
+	// human text goes to stderr
+	h := a
+	if output == outputJSON || machineDryRun(config.DryRunFormat) {
+		h = &App{Stdout: a.Stderr, Stderr: a.Stderr}
+	}
+	finish := func(code int, err error) int {
+		report.Config = config
+		return a.finishReport(report, output, code, err)
+	}
+
+	absPath, err := resolveProjectPath(projectPath, config.ProjectName)
+	if err != nil {
+		return finish(ExitFailure, err)
+	}
+	config.ProjectPath = absPath
+
+	// Report every incompatible option at once, each with a suggested fix
+	if violations := models.CheckCompatibility(config); len(violations) > 0 {
+		report.Violations = violations
+		return finish(ExitUsage, compatibilityError(violations))
+	}
+
+	// Print configuration summary
+	h.println()
+	h.println("FrontForge - Non-Interactive Mode")
+	h.println()
+	h.printf("  Project:   %s\n", config.ProjectName)
+	if opts.archive != "" {
+		h.printf("  Archive:   %s\n", opts.archive)
+	} else {
+		h.printf("  Path:      %s\n", config.ProjectPath)
+	}
+	h.printf("  Framework: %s\n", config.Framework)
+	h.printf("  Language:  %s\n", config.Language)
+	h.printf("  Styling:   %s\n", config.Styling)
+	h.printf("  Package:   %s\n", config.PackageManager)
+	h.println()
+
+	// Run preflight checks. An archive leaves the disk alone and only needs
+	// the tools when an upstream CLI scaffolds the project.
+	results := preflight.PreflightResults{AllPassed: true}
+	if opts.archive == "" || models.IsMetaFramework(config.Framework) {
+		h.println("Running pre-flight checks...")
+		if opts.archive == "" {
+			results = preflight.RunAllChecks(config)
+		} else {
+			results = preflight.RunToolChecks(config)
+		}
+		report.Preflight = results.Checks
+		h.printChecks(results.Checks)
+		h.println()
+	}
+
+	if results.FatalError {
+		h.println("Pre-flight checks failed. Please resolve the issues above.")
+		return finish(ExitFailure, nil)
+	}
+
+	h.println("Generating project...")
+
+	if opts.archive != "" {
+		result, err := h.writeArchive(config, opts.archive)
+		if err != nil {
+			return finish(ExitFailure, err)
+		}
+		report.Archive = opts.archive
+		report.Files = result.Files
+		if result.Validation != nil {
+			report.Validation = result.Validation
+		}
+		report.NextSteps = []string{
+			extractCommand(opts.archive),
+			"cd " + config.ProjectName,
+			config.PackageManager + " install",
+			getRunCommand(config.PackageManager) + " run dev",
+		}
+		h.println()
+		h.printf("Archive written to %s\n", opts.archive)
+		h.printNextSteps(report.NextSteps)
+		return finish(ExitOK, nil)
+	}
+
+	// Generate the project
+	result, err := a.generate(h, config)
+	if err != nil {
+		return finish(ExitFailure, err)
+	}
+
+	// A diff answers whether the directory matches; the exit code tells CI
+	if config.DryRunFormat == models.DryRunDiff {
+		report.Files = result.Files
+		if result.DryRun.Changed() {
+			return finish(ExitChanges, nil)
+		}
+		return finish(ExitOK, nil)
+	}
+	switch config.DryRunFormat {
+	case models.DryRunJSON:
+		if err := result.DryRun.FprintJSON(a.Stdout, opts.dryRunContent); err != nil {
+			return finish(ExitFailure, err)
+		}
+		return finish(ExitOK, nil)
+	case models.DryRunFiles:
+		result.DryRun.FprintFiles(a.Stdout)
+		return finish(ExitOK, nil)
+	}
+	if result.Files != nil {
+		report.Files = result.Files
+	}
+	if result.Validation != nil {
+		report.Validation = result.Validation
+	}
+	if result.Conflicts != nil {
+		report.Conflicts = result.Conflicts
+		h.printConflicts(result.Conflicts)
+	}
+
+	// Run install if requested (only for actual generation, not dry-run);
+	// verification needs the dependencies, so it implies install
+	installed := false
+	if (config.AutoInstall || config.Verify) && !config.DryRun {
+		h.println()
+		h.printf("Running %s install...\n", config.PackageManager)
+		h.println()
+
+		report.Install = &installReport{Command: config.PackageManager + " install"}
+		if err := generators.RunInstallTo(config.ProjectPath, config, h.Stdout); err != nil {
+			report.Install.Error = err.Error()
+			h.println()
+			h.printf("Warning: Install failed: %v\n", err)
+			h.println("You can run the install manually with:")
+			h.printf("  cd %s\n", config.ProjectName)
+			h.printf("  %s install\n", config.PackageManager)
+		} else {
+			report.Install.Success = true
+			installed = true
+			h.println()
+			h.println("Dependencies installed successfully!")
+		}
+	}
+
+	// Run the project's own scripts now that dependencies are in place
+	var verifyErr error
+	if config.Verify && !config.DryRun {
+		verifyErr = h.verify(config, opts.verifyTimeout, installed, report)
+	}
+
+	// Only suggest cd if project was created in a subdirectory, and skip the
+	// install step if auto-install succeeded
+	cwd, _ := filepath.Abs(".")
+	if config.ProjectPath != cwd {
+		report.NextSteps = append(report.NextSteps, "cd "+config.ProjectName)
+	}
+	if !installed {
+		report.NextSteps = append(report.NextSteps, config.PackageManager+" install")
+	}
+	report.NextSteps = append(report.NextSteps, getRunCommand(config.PackageManager)+" run dev")
+
+	// Success message
+	h.println()
+	if verifyErr != nil {
+		h.println("Project created, but verification failed.")
+	} else {
+		h.println("Project created successfully!")
+	}
+	h.printNextSteps(report.NextSteps)
+	if verifyErr != nil {
+		return finish(ExitFailure, verifyErr)
+	}
+	return finish(ExitOK, nil)
+}
+
+// printNextSteps prints the commands to run after generation
+func (a *App) printNextSteps(steps []string) {
+	a.println()
+	a.println("Next steps:")
+	for _, step := range steps {
+		a.printf("  %s\n", step)
+	}
+	a.println()
+}
+
+// verify runs the -verify steps and prints their results. It returns an
+// error when verification couldn't run or a step failed.
+func (a *App) verify(config models.Config, timeout time.Duration, installed bool, report *generationReport) error {
+	a.println()
+	if !installed {
+		a.println("Skipping verification: dependencies are not installed.")
+		return fmt.Errorf("cannot verify the project without installed dependencies")
+	}
+
+	a.println("Verifying the project...")
+	report.Verification = generators.Verify(context.Background(), config.ProjectPath, config, timeout, func(step generators.VerifyStep) {
+		a.printf("  Running %s...\n", step.Name)
+	})
+	a.println()
+	failed := 0
+	for _, r := range report.Verification {
+		if r.Passed {
+			a.printf("  [OK] %s\n", r.Check)
+			continue
+		}
+		failed++
+		a.printf("  [FAIL] %s: %s\n", r.Check, r.Message)
+		for _, line := range strings.Split(r.Output, "\n") {
+			a.printf("    %s\n", line)
+		}
+	}
+	if len(report.Verification) == 0 {
+		a.println("  No typecheck, lint, test or build scripts to run.")
+	}
+	if failed > 0 {
+		return fmt.Errorf("verification failed: %d of %d step(s)", failed, len(report.Verification))
+	}
+	return nil
+}
+
+// printChecks prints preflight results as [OK]/[FAIL] lines
+func (a *App) printChecks(checks []preflight.CheckResult) {
+	for _, check := range checks {
+		if check.Passed {
+			a.printf("  [OK] %s\n", check.Name)
+		} else {
+			a.printf("  [FAIL] %s: %s\n", check.Name, check.Message)
+			if check.Suggestion != "" {
+				a.printf("    → %s\n", check.Suggestion)
+			}
+		}
+	}
+}
+
+// printNewHelp displays help for the new command
+func (a *App) printNewHelp() {
+	w := a.Stderr
+	a.printCommandUsage("new")
+	fmt.Fprintln(w, "INTERACTIVE MODE (default):")
+	fmt.Fprintln(w, "  Run without flags to use the interactive TUI for full configuration.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "NON-INTERACTIVE MODE:")
+	fmt.Fprintln(w, "  Use -quick and -name flags for instant project generation.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "FLAGS:")
+	fmt.Fprintln(w, "  -h, -help        Show this help information")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Path:")
+	fmt.Fprintln(w, "    -path <path>   Project path (optional)")
+	fmt.Fprintln(w, "                   If not specified, creates folder with project name")
+	fmt.Fprintln(w, "                   Use '.' for current directory")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Non-Interactive:")
+	fmt.Fprintln(w, "    -quick         Use quick preset and skip interactive mode")
+	fmt.Fprintln(w, "    -name <name>   Project name (required for non-interactive)")
+	for _, g := range optionFlags() {
+		fmt.Fprintf(w, "    -%-13s %s: %s\n", g.Flag, capitalize(g.Name), strings.Join(g.IDs(), ", "))
+	}
+	fmt.Fprintln(w, "    -dry-run       Show what files would be generated without writing them")
+	fmt.Fprintln(w, "    -dry-run=diff  Compare them with the target directory instead: each file is")
+	fmt.Fprintln(w, "                   new, modified, identical or untouched, with unified diffs.")
+	fmt.Fprintln(w, "                   Exits with 3 if anything would change")
+	fmt.Fprintln(w, "    -dry-run=json  Print every file and directory as JSON, with its size and")
+	fmt.Fprintln(w, "                   SHA-256; add -dry-run-content to include file contents")
+	fmt.Fprintln(w, "    -dry-run=files Print the content of every file under a '==> path <==' header")
+	fmt.Fprintln(w, "                   (json and files print to stdout; progress goes to stderr)")
+	fmt.Fprintln(w, "    -archive <file>")
+	fmt.Fprintln(w, "                   Write the project to a .zip or .tar.gz (.tgz) archive instead")
+	fmt.Fprintln(w, "                   of a directory, under a folder named after the project.")
+	fmt.Fprintln(w, "                   Meta-frameworks are scaffolded in a temporary directory and")
+	fmt.Fprintln(w, "                   packed without node_modules or .git")
+	fmt.Fprintln(w, "    -install       Run the package manager install after generation")
+	fmt.Fprintln(w, "    -verify        After install (implied), run the project's typecheck, lint,")
+	fmt.Fprintln(w, "                   test and build scripts; exits with 1 if one fails")
+	fmt.Fprintln(w, "    -verify-timeout <duration>")
+	fmt.Fprintln(w, "                   Time limit for each -verify step, e.g. 90s or 10m")
+	fmt.Fprintln(w, "                   (default: 2m for typecheck and lint, 5m for test and build)")
+	fmt.Fprintln(w, "    -resolve-latest")
+	fmt.Fprintln(w, "                   Use the latest versions from the npm registry (honors .npmrc);")
+	fmt.Fprintln(w, "                   falls back to the built-in versions when offline")
+	fmt.Fprintln(w, "    -on-conflict <policy>")
+	fmt.Fprintln(w, "                   What to do with existing files that differ from the generated ones:")
+	fmt.Fprintln(w, "                   skip, overwrite (default), backup (keep <name>.bak), merge")
+	fmt.Fprintln(w, "                   (package.json, tsconfig and .gitignore; other files are kept)")
+	fmt.Fprintln(w, "                   or prompt (ask for each file, showing a diff)")
+	fmt.Fprintln(w, "    -no-scaffold   Skip upstream CLI (meta-frameworks only, for debugging)")
+	fmt.Fprintln(w, "    -output <fmt>  Output format: text (default) or json")
+	fmt.Fprintln(w, "                   json prints one report to stdout; progress goes to stderr")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Config File:")
+	fmt.Fprintln(w, "    -config <file> Load the full configuration from a .json, .yaml or .yml file")
+	fmt.Fprintln(w, "                   -name, -path, -dry-run and -install override file values")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Presets:")
+	fmt.Fprintln(w, "    -preset <name> Generate from a saved preset (requires -name)")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "EXAMPLES:")
+	fmt.Fprintln(w, "  Interactive mode:")
+	fmt.Fprintln(w, "    frontforge")
+	fmt.Fprintln(w, "    frontforge new -path .")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Quick generation (React + TypeScript + Tailwind):")
+	fmt.Fprintln(w, "    frontforge new -quick -name my-app")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Vue project with pnpm:")
+	fmt.Fprintln(w, "    frontforge new -quick -name my-vue-app -framework vue -pm pnpm")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Svelte project with JavaScript:")
+	fmt.Fprintln(w, "    frontforge new -quick -name my-svelte-app -framework svelte -lang js")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Next.js project:")
+	fmt.Fprintln(w, "    frontforge new -quick -name my-next-app -framework nextjs")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Project in current directory:")
+	fmt.Fprintln(w, "    frontforge new -quick -name my-app -path .")
+	fmt.Fprintln(w, "    frontforge new -quick -name my-app -path . -on-conflict merge")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Reproducible project from a config file or preset:")
+	fmt.Fprintln(w, "    frontforge new -config stack.json")
+	fmt.Fprintln(w, "    frontforge new -preset team-stack -name my-app")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "QUICK PRESET INCLUDES:")
+	fmt.Fprintln(w, "  Framework:        React (with Vite)")
+	fmt.Fprintln(w, "  Language:         TypeScript")
+	fmt.Fprintln(w, "  Styling:          Tailwind CSS")
+	fmt.Fprintln(w, "  UI Library:       Shadcn/ui")
+	fmt.Fprintln(w, "  Routing:          React Router")
+	fmt.Fprintln(w, "  State:            Zustand")
+	fmt.Fprintln(w, "  Data Fetching:    TanStack Query")
+	fmt.Fprintln(w, "  Forms:            React Hook Form")
+	fmt.Fprintln(w, "  Testing:          Vitest")
+	fmt.Fprintln(w, "  Animation:        Framer Motion")
+	fmt.Fprintln(w, "  Icons:            Heroicons")
+	fmt.Fprintln(w, "  Utilities:        date-fns")
+	fmt.Fprintln(w)
+}
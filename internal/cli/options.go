@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"frontforge/internal/models"
+	"strings"
+)
+
+// optionFlags returns the registry groups exposed as flags, in the order they
+// are applied. Framework comes first because it resets the framework-specific defaults.
+func optionFlags() []*models.OptionGroup {
+	framework, _ := models.LookupGroup("framework")
+	flags := []*models.OptionGroup{framework}
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		if g.Flag != "" && g != framework {
+			flags = append(flags, g)
+		}
+	}
+	return flags
+}
+
+// compatibilityError formats every violation with the flag that fixes it
+func compatibilityError(violations []models.Violation) error {
+	lines := make([]string, len(violations))
+	for i, v := range violations {
+		lines[i] = v.Message
+		g, _ := models.LookupGroup(v.Key)
+		if o, ok := g.Find(v.Suggestion); ok {
+			lines[i] += fmt.Sprintf(" (try -%s %s)", g.Flag, o.ID)
+		}
+	}
+	if len(lines) == 1 {
+		return fmt.Errorf("%s", lines[0])
+	}
+	return fmt.Errorf("%d incompatible options:\n  %s", len(lines), strings.Join(lines, "\n  "))
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// getRunCommand returns the appropriate run command for the package manager
+func getRunCommand(pm string) string {
+	if pm == models.PackageManagerNpm {
+		return "npm"
+	}
+	return pm
+}
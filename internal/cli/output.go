@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"frontforge/internal/generators"
+	"frontforge/internal/models"
+	"frontforge/internal/preflight"
+)
+
+// Output formats accepted by -output
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// generationReport is the document printed by "frontforge new -output json"
+type generationReport struct {
+	Success      bool                          `json:"success"`
+	Error        string                        `json:"error,omitempty"`
+	Config       models.Config                 `json:"config"`
+	Violations   []models.Violation            `json:"violations,omitempty"` // Incompatible options, if any
+	Preflight    []preflight.CheckResult       `json:"preflight"`
+	Archive      string                        `json:"archive,omitempty"` // The -archive file, if any
+	Files        []string                      `json:"files"`
+	Validation   []generators.ValidationResult `json:"validation"`
+	Conflicts    []generators.ConflictOutcome  `json:"conflicts"`              // Existing files that differed, and what was done
+	Install      *installReport                `json:"install,omitempty"`      // nil unless -install was requested
+	Verification []generators.ValidationResult `json:"verification,omitempty"` // nil unless -verify was requested
+	NextSteps    []string                      `json:"nextSteps"`
+}
+
+// installReport describes the outcome of the package manager install
+type installReport struct {
+	Command string `json:"command"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// newReport creates an empty report whose lists encode as [] rather than null
+func newReport(config models.Config) *generationReport {
+	return &generationReport{
+		Config:     config,
+		Preflight:  []preflight.CheckResult{},
+		Files:      []string{},
+		Validation: []generators.ValidationResult{},
+		Conflicts:  []generators.ConflictOutcome{},
+		NextSteps:  []string{},
+	}
+}
+
+// finishReport ends a non-interactive run: the error, if any, goes to
+// stderr and, under -output json, the report to stdout. It returns code.
+func (a *App) finishReport(report *generationReport, output string, code int, err error) int {
+	if err != nil {
+		report.Error = err.Error()
+		fmt.Fprintf(a.Stderr, "Error: %v\n", err)
+	}
+	report.Success = code == ExitOK
+	if output == outputJSON {
+		if err := a.writeJSON(report); err != nil {
+			fmt.Fprintf(a.Stderr, "Error: %v\n", err)
+			return ExitFailure
+		}
+	}
+	return code
+}
+
+// writeJSON prints v to stdout as indented JSON
+func (a *App) writeJSON(v interface{}) error {
+	enc := json.NewEncoder(a.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"frontforge/internal/presets"
+)
+
+// runPresets implements "frontforge presets [list|delete|rename]"
+func (a *App) runPresets(args []string) int {
+	fs := a.newFlagSet("presets", a.printPresetsHelp)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	args = fs.Args()
+
+	store, err := presets.Default()
+	if err != nil {
+		return a.fail(err)
+	}
+
+	action := "list"
+	if len(args) > 0 {
+		action = args[0]
+		args = args[1:]
+	}
+
+	switch action {
+	case "list", "ls":
+		names, err := store.List()
+		if err != nil {
+			return a.fail(err)
+		}
+		if len(names) == 0 {
+			a.printf("No presets saved in %s\n", store.Dir)
+			a.println("Save one from the review screen of the interactive mode (press S).")
+			return ExitOK
+		}
+		for _, name := range names {
+			a.println(name)
+		}
+	case "delete", "rm":
+		if len(args) != 1 {
+			fmt.Fprintln(a.Stderr, "Usage: frontforge presets delete <name>")
+			return ExitUsage
+		}
+		if err := store.Delete(args[0]); err != nil {
+			return a.fail(err)
+		}
+		a.printf("Deleted preset %s\n", args[0])
+	case "rename", "mv":
+		if len(args) != 2 {
+			fmt.Fprintln(a.Stderr, "Usage: frontforge presets rename <old> <new>")
+			return ExitUsage
+		}
+		if err := store.Rename(args[0], args[1]); err != nil {
+			return a.fail(err)
+		}
+		a.printf("Renamed preset %s to %s\n", args[0], args[1])
+	default:
+		return a.usageError(fmt.Errorf("unknown presets action '%s'. Valid actions: list, delete, rename", action))
+	}
+	return ExitOK
+}
+
+// printPresetsHelp displays help for the presets command
+func (a *App) printPresetsHelp() {
+	w := a.Stderr
+	a.printCommandUsage("presets")
+	fmt.Fprintln(w, "ACTIONS:")
+	fmt.Fprintln(w, "  list                 List saved presets (default)")
+	fmt.Fprintln(w, "  delete <name>        Delete a saved preset")
+	fmt.Fprintln(w, "  rename <old> <new>   Rename a saved preset")
+	fmt.Fprintln(w)
+	if dir, err := presets.DefaultDir(); err == nil {
+		fmt.Fprintf(w, "Presets are stored in %s\n", dir)
+	}
+	fmt.Fprintln(w, "Save a preset by pressing S on the review screen of 'frontforge new'.")
+	fmt.Fprintln(w)
+}
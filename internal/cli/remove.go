@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"frontforge/internal/generators"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+)
+
+// runRemove implements "frontforge remove <option>..."
+func (a *App) runRemove(args []string) int {
+	var projectPath string
+	var dryRun bool
+	fs := a.newFlagSet("remove", a.printRemoveHelp)
+	fs.StringVar(&projectPath, "path", ".", "Project directory")
+	fs.BoolVar(&dryRun, "dry-run", false, "Show what would change without writing files")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() == 0 {
+		a.printRemoveHelp()
+		return ExitUsage
+	}
+
+	targets := make(map[string]string)
+	for _, arg := range fs.Args() {
+		key, value, err := removeTarget(arg)
+		if err != nil {
+			return a.usageError(err)
+		}
+		targets[key] = value
+	}
+
+	plan, err := generators.PlanRemove(vfs.Disk{}, projectPath, targets)
+	if errors.Is(err, manifest.ErrNotFound) {
+		return a.fail(fmt.Errorf("%s has %w", projectPath, err))
+	}
+	if err != nil {
+		return a.fail(err)
+	}
+
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		if from, to := *g.Field(&plan.From), *g.Field(&plan.To); from != to {
+			a.printf("Removing %s: %s\n", g.Name, from)
+		}
+	}
+
+	a.println()
+	for _, f := range plan.Files {
+		label, ok := upgradeLabels[f.Status]
+		if !ok {
+			continue
+		}
+		a.printf("  %-9s %s", label, f.Path)
+		if f.Conflicts > 0 {
+			a.printf(" (%d conflicting region(s))", f.Conflicts)
+		}
+		a.println()
+	}
+	a.printRemovedEntries("dependencies", plan.Dependencies)
+	a.printRemovedEntries("devDependencies", plan.DevDependencies)
+	a.printRemovedEntries("scripts", plan.Scripts)
+
+	if plan.Changed() == 0 {
+		a.println("Nothing to change.")
+		return ExitOK
+	}
+	a.println()
+	if dryRun {
+		a.printf("Dry run: %d file(s) would change.\n", plan.Changed())
+		return ExitOK
+	}
+	if err := plan.Commit(); err != nil {
+		return a.fail(err)
+	}
+	a.printf("Updated %d file(s).\n", plan.Changed())
+	if len(plan.Dependencies)+len(plan.DevDependencies) > 0 {
+		a.printf("Run '%s install' to update node_modules and the lock file.\n", plan.From.PackageManager)
+	}
+	if edited := plan.Edited(); len(edited) > 0 {
+		a.printf("%d file(s) were edited since generation and were left in place; remove what you no longer need.\n", len(edited))
+	}
+	if n := plan.Conflicts(); n > 0 {
+		a.printf("%d file(s) have conflict markers (<<<<<<< current ... >>>>>>> frontforge remove). Resolve them before building.\n", n)
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// removeTarget resolves an argument to remove: an option name such as
+// "testing", or a value such as "jest" that belongs to exactly one option
+func removeTarget(arg string) (string, string, error) {
+	if g, ok := models.LookupGroup(arg); ok {
+		return g.Key, "", nil
+	}
+	var key, value string
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		parsed, ok := g.Parse(arg)
+		if !ok || parsed == "none" {
+			continue
+		}
+		if key != "" {
+			return "", "", fmt.Errorf("'%s' is ambiguous; use the option name (%s or %s)", arg, key, g.Key)
+		}
+		key, value = g.Key, parsed
+	}
+	if key == "" {
+		return "", "", fmt.Errorf("unknown option '%s'. Run 'frontforge list' for all options", arg)
+	}
+	return key, value, nil
+}
+
+// printRemovedEntries lists the entries removed from a package.json section
+func (a *App) printRemovedEntries(section string, names []string) {
+	for _, name := range names {
+		a.printf("  %-9s %s (package.json %s)\n", "removed", name, section)
+	}
+}
+
+// printRemoveHelp displays help for the remove command
+func (a *App) printRemoveHelp() {
+	w := a.Stderr
+	a.printCommandUsage("remove")
+	fmt.Fprintln(w, "Takes options back out of a project generated by frontforge. Name an option")
+	fmt.Fprintln(w, "(testing, styling, ...) or the value in use (jest, styled-components, ...).")
+	fmt.Fprintln(w, "Files the option brought in are deleted if they're still as generated;")
+	fmt.Fprintln(w, "edited files are listed and left in place. vite.config and the main entry")
+	fmt.Fprintln(w, "file are updated (with conflict markers where you changed the same lines),")
+	fmt.Fprintln(w, "and the option's dependencies and scripts are removed from package.json.")
+	fmt.Fprintln(w, "Needs the ownership recorded in .frontforge/manifest.json.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "FLAGS:")
+	fmt.Fprintln(w, "  -path <dir>    Project directory (default: current directory)")
+	fmt.Fprintln(w, "  -dry-run       Show what would change without writing files")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Example: frontforge remove jest styled-components")
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"fmt"
+	"frontforge/internal/configfile"
+)
+
+// runSchema implements "frontforge schema"
+func (a *App) runSchema(args []string) int {
+	fs := a.newFlagSet("schema", func() {
+		a.printCommandUsage("schema")
+		fmt.Fprintln(a.Stderr, "Example: frontforge schema > frontforge.schema.json")
+		fmt.Fprintln(a.Stderr)
+	})
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	schema, err := configfile.Schema()
+	if err != nil {
+		return a.fail(err)
+	}
+	a.println(string(schema))
+	return ExitOK
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"frontforge/internal/logger"
+	"frontforge/internal/server"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// runServe implements "frontforge serve"
+func (a *App) runServe(args []string) int {
+	var addr string
+	var timeout time.Duration
+	var maxBody int64
+	fs := a.newFlagSet("serve", a.printServeHelp)
+	fs.StringVar(&addr, "addr", "localhost:8080", "Address to listen on")
+	fs.DurationVar(&timeout, "timeout", server.DefaultTimeout, "Time limit for each request")
+	fs.Int64Var(&maxBody, "max-body", server.DefaultMaxBodyBytes, "Largest accepted request body, in bytes")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() > 0 {
+		return a.usageError(fmt.Errorf("unexpected argument '%s'", fs.Arg(0)))
+	}
+	if timeout <= 0 || maxBody <= 0 {
+		return a.usageError(fmt.Errorf("-timeout and -max-body must be positive"))
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return a.fail(err)
+	}
+	srv := &http.Server{
+		Handler: server.New(server.Options{
+			MaxBodyBytes: maxBody,
+			Timeout:      timeout,
+			Log:          logger.New(logger.LevelInfo, a.Stderr),
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      timeout + 30*time.Second, // Room for the handler's own timeout response
+		IdleTimeout:       2 * time.Minute,
+	}
+
+	// Stop on Ctrl+C, letting requests in flight finish
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	a.printf("Serving frontforge on http://%s (Ctrl+C to stop)\n", listener.Addr())
+	if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return a.fail(err)
+	}
+	return ExitOK
+}
+
+// printServeHelp displays help for the serve command
+func (a *App) printServeHelp() {
+	w := a.Stderr
+	a.printCommandUsage("serve")
+	fmt.Fprintln(w, "Serves a web form and a JSON API that generate projects, for teams that")
+	fmt.Fprintln(w, "don't use the CLI. Request bodies are config file documents (see")
+	fmt.Fprintln(w, "'frontforge schema'). Vite-based projects are generated in memory; only")
+	fmt.Fprintln(w, "meta-frameworks run a command, their upstream CLI.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "ENDPOINTS:")
+	fmt.Fprintln(w, "  GET  /               Web form")
+	fmt.Fprintln(w, "  GET  /api/options    Option groups, values and each framework's defaults")
+	fmt.Fprintln(w, "  POST /api/validate   Resolve a config and report incompatible options")
+	fmt.Fprintln(w, "  POST /api/dry-run    Files the config generates (?content=true for contents)")
+	fmt.Fprintln(w, "  POST /api/generate   The project as a zip (?format=tar.gz for a tarball)")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "FLAGS:")
+	fmt.Fprintln(w, "  -addr <host:port>   Address to listen on (default: localhost:8080)")
+	fmt.Fprintln(w, "  -timeout <duration> Time limit for each request (default: 2m)")
+	fmt.Fprintln(w, "  -max-body <bytes>   Largest accepted request body (default: 65536)")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Requests are logged to stderr.")
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"frontforge/internal/generators"
+	"frontforge/internal/manifest"
+	"frontforge/internal/vfs"
+)
+
+// upgradeLabels describes each upgrade, add and remove status; unchanged
+// files aren't listed
+var upgradeLabels = map[string]string{
+	generators.UpgradeAdded:    "added",
+	generators.UpgradeUpdated:  "updated",
+	generators.UpgradeMerged:   "merged",
+	generators.UpgradeConflict: "CONFLICT",
+	generators.UpgradeDeleted:  "deleted",
+	generators.UpgradeObsolete: "obsolete",
+	generators.UpgradeKept:     "kept",
+	generators.UpgradeRemoved:  "removed",
+	generators.UpgradeEdited:   "edited",
+}
+
+// runUpgrade implements "frontforge upgrade"
+func (a *App) runUpgrade(args []string) int {
+	var projectPath string
+	var dryRun bool
+	fs := a.newFlagSet("upgrade", a.printUpgradeHelp)
+	fs.StringVar(&projectPath, "path", ".", "Project directory to upgrade")
+	fs.BoolVar(&dryRun, "dry-run", false, "Show what would change without writing files")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() > 0 {
+		return a.usageError(fmt.Errorf("unexpected argument '%s'", fs.Arg(0)))
+	}
+
+	plan, err := generators.PlanUpgrade(vfs.Disk{}, projectPath)
+	if errors.Is(err, manifest.ErrNotFound) {
+		return a.fail(fmt.Errorf("%s has %w", projectPath, err))
+	}
+	if err != nil {
+		return a.fail(err)
+	}
+
+	a.printf("Upgrading from frontforge %s to %s\n", plan.From, plan.To)
+	if plan.Changed() == 0 {
+		a.println("Already up to date.")
+		return ExitOK
+	}
+
+	a.println()
+	packageJSON := false
+	for _, f := range plan.Files {
+		label, ok := upgradeLabels[f.Status]
+		if !ok {
+			continue
+		}
+		a.printf("  %-9s %s", label, f.Path)
+		if f.Conflicts > 0 {
+			a.printf(" (%d conflicting region(s))", f.Conflicts)
+		}
+		a.println()
+		if f.Path == "package.json" && f.Status != generators.UpgradeDeleted && f.Status != generators.UpgradeObsolete {
+			packageJSON = true
+		}
+	}
+
+	a.println()
+	if dryRun {
+		a.printf("Dry run: %d file(s) would change.\n", plan.Changed())
+		return ExitOK
+	}
+	if err := plan.Commit(); err != nil {
+		return a.fail(err)
+	}
+	a.printf("Upgraded %d file(s).\n", plan.Changed())
+	if packageJSON {
+		a.println("package.json changed; run your package manager's install.")
+	}
+	if n := plan.Conflicts(); n > 0 {
+		a.printf("%d file(s) have conflict markers (<<<<<<< current ... >>>>>>> frontforge %s). Resolve them before building.\n", n, plan.To)
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// printUpgradeHelp displays help for the upgrade command
+func (a *App) printUpgradeHelp() {
+	w := a.Stderr
+	a.printCommandUsage("upgrade")
+	fmt.Fprintln(w, "Regenerates the project from the config in .frontforge/manifest.json with")
+	fmt.Fprintln(w, "this version's templates. Files you haven't edited are replaced; edited")
+	fmt.Fprintln(w, "files are merged with the template changes, with conflict markers where")
+	fmt.Fprintln(w, "both changed the same lines. Dependency ranges you haven't changed are")
+	fmt.Fprintln(w, "bumped to the versions in 'frontforge versions'.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "FLAGS:")
+	fmt.Fprintln(w, "  -path <dir>    Project directory to upgrade (default: current directory)")
+	fmt.Fprintln(w, "  -dry-run       Show what would change without writing files")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exits with code 1 if any file was left with conflict markers.")
+	fmt.Fprintln(w)
+}
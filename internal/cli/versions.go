@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"frontforge/internal/versions"
+)
+
+// runVersions implements "frontforge versions [-markdown | -update <file>]"
+func (a *App) runVersions(args []string) int {
+	fs := a.newFlagSet("versions", a.printVersionsHelp)
+	markdown := fs.Bool("markdown", false, "Print the catalog as markdown tables")
+	update := fs.String("update", "", "Regenerate the tables in a markdown file")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() > 0 {
+		return a.usageError(fmt.Errorf("unexpected argument '%s'", fs.Arg(0)))
+	}
+	if *markdown && *update != "" {
+		return a.usageError(fmt.Errorf("-markdown and -update cannot be used together"))
+	}
+
+	catalog, err := versions.Load()
+	if err != nil {
+		return a.fail(err)
+	}
+
+	switch {
+	case *update != "":
+		if err := catalog.UpdateFile(*update); err != nil {
+			return a.fail(err)
+		}
+		a.printf("Updated %s\n", *update)
+	case *markdown:
+		a.printf("%s", catalog.Markdown())
+	default:
+		for i, s := range catalog.Sections {
+			if i > 0 {
+				a.println()
+			}
+			a.println(s.Name)
+			for _, e := range s.Packages {
+				a.printf("  %-36s %s\n", e.Name, e.Version)
+			}
+		}
+	}
+	return ExitOK
+}
+
+// printVersionsHelp displays help for the versions command
+func (a *App) printVersionsHelp() {
+	w := a.Stderr
+	a.printCommandUsage("versions")
+	fmt.Fprintln(w, "FLAGS:")
+	fmt.Fprintln(w, "  -markdown        Print the catalog as markdown tables")
+	fmt.Fprintln(w, "  -update <file>   Regenerate the tables between the")
+	fmt.Fprintf(w, "                   %s and %s markers in <file>\n", versions.BeginMarker, versions.EndMarker)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Example: frontforge versions -update PACKAGE_VERSIONS.md")
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,554 @@
+package cli_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"frontforge/internal/cli"
+	"frontforge/internal/testutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// run executes the CLI with captured output
+func run(args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	app := &cli.App{Stdout: &out, Stderr: &errOut}
+	code = app.Run(args)
+	return code, out.String(), errOut.String()
+}
+
+func TestCommandDispatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantOut  string // substring of stdout
+		wantErr  string // substring of stderr
+	}{
+		{"help flag", []string{"-h"}, cli.ExitOK, "COMMANDS:", ""},
+		{"help command", []string{"help"}, cli.ExitOK, "doctor", ""},
+		{"help exit codes", []string{"help"}, cli.ExitOK, "3  new -dry-run=diff", ""},
+		{"help for command", []string{"help", "new"}, cli.ExitOK, "", "Usage: frontforge new"},
+		{"command help flag", []string{"presets", "-h"}, cli.ExitOK, "", "rename <old> <new>"},
+		{"unknown command", []string{"frobnicate"}, cli.ExitUsage, "", "unknown command 'frobnicate'"},
+		{"unknown flag", []string{"new", "-bogus"}, cli.ExitUsage, "", "flag provided but not defined"},
+		{"missing name", []string{"new", "-quick"}, cli.ExitUsage, "", "-name flag is required"},
+		{"legacy flags alias new", []string{"-quick"}, cli.ExitUsage, "", "-name flag is required"},
+		{"invalid framework", []string{"new", "-name", "app", "-framework", "ember"}, cli.ExitUsage, "", "invalid framework 'ember'"},
+		{"config and preset", []string{"new", "-config", "a.json", "-preset", "b"}, cli.ExitUsage, "", "cannot be used together"},
+		{"stray argument", []string{"new", "extra"}, cli.ExitUsage, "", "unexpected argument"},
+		{"invalid dry-run format", []string{"new", "-name", "app", "-dry-run=patch"}, cli.ExitUsage, "", "valid formats: tree, diff, json, files"},
+		{"dry-run content without json", []string{"new", "-name", "app", "-dry-run", "-dry-run-content"}, cli.ExitUsage, "", "requires -dry-run=json"},
+		{"dry-run json with json output", []string{"new", "-name", "app", "-dry-run=json", "-output", "json"}, cli.ExitUsage, "", "both write to stdout"},
+		{"invalid archive name", []string{"new", "-name", "app", "-archive", "app.rar"}, cli.ExitUsage, "", "Use a .zip, .tar.gz or .tgz"},
+		{"archive with install", []string{"new", "-name", "app", "-archive", "app.zip", "-install"}, cli.ExitUsage, "", "-archive cannot be used with -install"},
+		{"interactive archive", []string{"new", "-archive", "app.zip"}, cli.ExitUsage, "", "-archive requires non-interactive mode"},
+		{"serve stray argument", []string{"serve", "now"}, cli.ExitUsage, "", "unexpected argument"},
+		{"serve invalid timeout", []string{"serve", "-timeout", "0s"}, cli.ExitUsage, "", "must be positive"},
+		{"invalid verify timeout", []string{"new", "-name", "app", "-verify", "-verify-timeout", "soon"}, cli.ExitUsage, "", "invalid value \"soon\""},
+		{"versions", []string{"versions"}, cli.ExitOK, "eslint", ""},
+		{"versions markdown", []string{"versions", "-markdown"}, cli.ExitOK, "| `vite` |", ""},
+		{"upgrade without manifest", []string{"upgrade", "-path", filepath.Join("testdata", "missing")}, cli.ExitFailure, "", "no frontforge manifest"},
+		{"doctor invalid output", []string{"doctor", "-output", "xml"}, cli.ExitUsage, "", "invalid output format 'xml'"},
+		{"remove unknown option", []string{"remove", "bogus"}, cli.ExitUsage, "", "unknown option 'bogus'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := run(tt.args...)
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d (stderr: %s)", code, tt.wantCode, stderr)
+			}
+			if !strings.Contains(stdout, tt.wantOut) {
+				t.Errorf("stdout %q should contain %q", stdout, tt.wantOut)
+			}
+			if !strings.Contains(stderr, tt.wantErr) {
+				t.Errorf("stderr %q should contain %q", stderr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestListCommand(t *testing.T) {
+	code, stdout, _ := run("list")
+	if code != cli.ExitOK {
+		t.Fatalf("list exit code = %d", code)
+	}
+	for _, flag := range []string{"-framework", "-lang", "-pm", "-styling"} {
+		if !strings.Contains(stdout, flag) {
+			t.Errorf("list output should mention %s", flag)
+		}
+	}
+
+	code, stdout, _ = run("list", "framework")
+	if code != cli.ExitOK {
+		t.Fatalf("list framework exit code = %d", code)
+	}
+	if !strings.Contains(stdout, "Next.js") {
+		t.Errorf("list framework should resolve nextjs to Next.js, got:\n%s", stdout)
+	}
+
+	code, _, _ = run("list", "nonsense")
+	if code != cli.ExitUsage {
+		t.Errorf("unknown category exit code = %d, want %d", code, cli.ExitUsage)
+	}
+}
+
+func TestNewOptionFlags(t *testing.T) {
+	// Every Config option has a flag
+	_, _, stderr := run("new", "-h")
+	for _, flag := range []string{"-ui", "-routing", "-forms", "-animation", "-icons", "-dataviz", "-utils", "-i18n", "-structure"} {
+		if !strings.Contains(stderr, flag+" ") {
+			t.Errorf("new help should document %s", flag)
+		}
+	}
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"-ui", "bulma"}, "invalid UI library 'bulma'"},
+		{[]string{"-framework", "vue", "-ui", "shadcn"}, "UI library 'Shadcn/ui' is not compatible with Vue"},
+		{[]string{"-framework", "svelte", "-forms", "formik"}, "form management 'Formik' is not compatible with Svelte"},
+		{[]string{"-framework", "nextjs", "-state", "pinia"}, "state management 'Pinia' is not compatible with Next.js"},
+		{[]string{"-framework", "astro", "-icons", "lucide"}, "-icons is not available for Astro"},
+	}
+
+	for _, tt := range tests {
+		args := append([]string{"new", "-name", "app", "-dry-run"}, tt.args...)
+		code, _, stderr := run(args...)
+		if code != cli.ExitUsage {
+			t.Errorf("%v: exit code = %d, want %d", tt.args, code, cli.ExitUsage)
+		}
+		if !strings.Contains(stderr, tt.wantErr) {
+			t.Errorf("%v: stderr %q should contain %q", tt.args, stderr, tt.wantErr)
+		}
+	}
+}
+
+func TestNewCompatibility(t *testing.T) {
+	// Every incompatible flag is reported with the flag that fixes it
+	code, _, stderr := run("new", "-name", "app", "-dry-run", "-framework", "vue", "-icons", "react-icons", "-i18n", "react-i18next")
+	testutil.AssertEqual(t, code, cli.ExitUsage)
+	for _, want := range []string{"2 incompatible options", "(try -icons heroicons)", "(try -i18n vue-i18n)"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("stderr %q should contain %q", stderr, want)
+		}
+	}
+
+	// Defaults that clash with an explicit flag are replaced silently
+	dir := testutil.TempDir(t)
+	_, stdout, _ := run("new", "-name", "app", "-path", dir, "-dry-run", "-output", "json", "-styling", "sass")
+	var report struct {
+		Config struct {
+			UILibrary string `json:"uiLibrary"`
+		} `json:"config"`
+		Violations []struct{} `json:"violations"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("stdout is not a JSON report: %v\n%s", err, stdout)
+	}
+	testutil.AssertEqual(t, report.Config.UILibrary, "None")
+	testutil.AssertEqual(t, len(report.Violations), 0)
+}
+
+func TestNewConfigFileFlags(t *testing.T) {
+	dir := testutil.TempDir(t)
+	config := testutil.CreateTempFile(t, dir, "stack.json", `{"name": "app", "framework": "react", "styling": "tailwind", "uiLibrary": "shadcn", "testing": "vitest"}`)
+
+	tests := []struct {
+		name  string
+		flags []string
+		want  map[string]string
+	}{
+		// Shadcn/ui needs Tailwind, so the file's UI library gives way to the flag
+		{"styling", []string{"-styling", "sass"}, map[string]string{"styling": "Sass/SCSS", "uiLibrary": "None", "testing": "Vitest"}},
+		{"framework", []string{"-framework", "vue", "-ui", "none"}, map[string]string{"framework": "Vue", "stateManagement": "Pinia", "testing": "Vitest"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"new", "-config", config, "-path", filepath.Join(dir, "out"), "-dry-run", "-output", "json"}, tt.flags...)
+			_, stdout, _ := run(args...)
+			var report struct {
+				Config map[string]interface{} `json:"config"`
+			}
+			if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+				t.Fatalf("stdout is not a JSON report: %v\n%s", err, stdout)
+			}
+			for key, want := range tt.want {
+				if report.Config[key] != want {
+					t.Errorf("%s = %v, want %s", key, report.Config[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewJSONOutput(t *testing.T) {
+	dir := testutil.TempDir(t)
+
+	// Stdout must hold exactly one JSON document, whatever the preflight outcome
+	_, stdout, _ := run("new", "-name", "app", "-path", dir, "-dry-run", "-output", "json", "-framework", "vue", "-ui", "none")
+	var report struct {
+		Success bool `json:"success"`
+		Config  struct {
+			Framework string `json:"framework"`
+			UILibrary string `json:"uiLibrary"`
+		} `json:"config"`
+		Preflight []struct {
+			Name   string `json:"name"`
+			Passed bool   `json:"passed"`
+		} `json:"preflight"`
+		Files     []string `json:"files"`
+		NextSteps []string `json:"nextSteps"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("stdout is not a JSON report: %v\n%s", err, stdout)
+	}
+	testutil.AssertEqual(t, report.Config.Framework, "Vue")
+	testutil.AssertEqual(t, report.Config.UILibrary, "None")
+	if len(report.Preflight) == 0 {
+		t.Error("report should include preflight checks")
+	}
+	if report.Success && len(report.Files) == 0 {
+		t.Error("successful dry run should list the files it would write")
+	}
+
+	// Failures are reported in the document too
+	code, stdout, _ := run("new", "-name", "app", "-path", dir, "-output", "json", "-framework", "vue", "-ui", "shadcn")
+	testutil.AssertEqual(t, code, cli.ExitUsage)
+	var failed struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &failed); err != nil {
+		t.Fatalf("stdout is not a JSON report: %v\n%s", err, stdout)
+	}
+	if failed.Success || !strings.Contains(failed.Error, "not compatible") {
+		t.Errorf("expected compatibility error in report, got %+v", failed)
+	}
+
+	// JSON output needs a non-interactive run
+	code, _, stderr := run("new", "-output", "json")
+	testutil.AssertEqual(t, code, cli.ExitUsage)
+	if !strings.Contains(stderr, "requires non-interactive mode") {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+}
+
+func TestNewJSONOutputErrors(t *testing.T) {
+	dir := testutil.TempDir(t)
+	unnamed := testutil.CreateTempFile(t, dir, "unnamed.json", `{"framework": "vue"}`)
+
+	// CI parses stdout, so errors before generation still print a report
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantErr  string
+	}{
+		{"invalid flag value", []string{"-name", "app", "-framework", "ember"}, cli.ExitUsage, "invalid framework 'ember'"},
+		{"invalid project name", []string{"-name", "my app"}, cli.ExitUsage, "invalid project name"},
+		{"missing name", []string{"-quick"}, cli.ExitUsage, "-name flag is required"},
+		{"missing config file", []string{"-config", filepath.Join(dir, "missing.json")}, cli.ExitFailure, "missing.json"},
+		{"config file without name", []string{"-config", unnamed}, cli.ExitUsage, "project name is required"},
+		{"config and preset", []string{"-config", unnamed, "-preset", "team"}, cli.ExitUsage, "cannot be used together"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"new", "-output", "json"}, tt.args...)
+			code, stdout, stderr := run(args...)
+			testutil.AssertEqual(t, code, tt.wantCode)
+			var report struct {
+				Success bool   `json:"success"`
+				Error   string `json:"error"`
+			}
+			if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+				t.Fatalf("stdout is not a JSON report: %v\n%s", err, stdout)
+			}
+			if report.Success || !strings.Contains(report.Error, tt.wantErr) {
+				t.Errorf("report error %q should contain %q", report.Error, tt.wantErr)
+			}
+			if !strings.Contains(stderr, tt.wantErr) {
+				t.Errorf("stderr %q should contain %q", stderr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddValidatesArguments(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"add", "vitest"}, "expected <option>=<value>"},
+		{[]string{"add", "linter=eslint"}, "unknown option 'linter'"},
+		{[]string{"add", "testing=mocha"}, "invalid testing 'mocha'"},
+	}
+
+	for _, tt := range tests {
+		code, _, stderr := run(tt.args...)
+		if code != cli.ExitUsage {
+			t.Errorf("%v: exit code = %d, want %d", tt.args, code, cli.ExitUsage)
+		}
+		if !strings.Contains(stderr, tt.wantErr) {
+			t.Errorf("%v: stderr %q should contain %q", tt.args, stderr, tt.wantErr)
+		}
+	}
+}
+
+func TestNewOnConflict(t *testing.T) {
+	code, _, stderr := run("new", "-name", "app", "-on-conflict", "ignore")
+	testutil.AssertEqual(t, code, cli.ExitUsage)
+	if !strings.Contains(stderr, "invalid -on-conflict 'ignore'") {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+
+	// Prompt: an invalid answer asks again, an empty one keeps README.md
+	// and "O" overwrites the remaining files
+	dir := testutil.TempDir(t)
+	testutil.CreateTempFile(t, dir, "README.md", "mine")
+	testutil.CreateTempFile(t, dir, "index.html", "<html>")
+	var out, errOut bytes.Buffer
+	app := &cli.App{Stdin: strings.NewReader("x\n\nO\n"), Stdout: &out, Stderr: &errOut}
+	code = app.Run([]string{"new", "-name", "app", "-path", dir, "-on-conflict", "prompt"})
+	if code != cli.ExitOK && strings.Contains(out.String(), "Pre-flight checks failed") {
+		t.Skip("pre-flight checks fail in this environment")
+	}
+	testutil.AssertEqual(t, code, cli.ExitOK)
+
+	stdout := out.String()
+	for _, want := range []string{
+		"2 existing file(s) differ",
+		"--- README.md (existing)",
+		"-mine",
+		"Please answer s, o or b",
+		"kept       README.md",
+		"replaced   index.html",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("stdout should contain %q:\n%s", want, stdout)
+		}
+	}
+	testutil.AssertFileContains(t, filepath.Join(dir, "README.md"), "mine")
+	testutil.AssertFileContains(t, filepath.Join(dir, "index.html"), "<!doctype html>")
+
+	// "M" merges the remaining files that can be merged and still asks
+	// about the others
+	dir = testutil.TempDir(t)
+	testutil.CreateTempFile(t, dir, ".gitignore", "secrets/\n")
+	testutil.CreateTempFile(t, dir, "README.md", "mine")
+	testutil.CreateTempFile(t, dir, "index.html", "<html>")
+	out.Reset()
+	app = &cli.App{Stdin: strings.NewReader("M\n\no\n"), Stdout: &out, Stderr: &errOut}
+	code = app.Run([]string{"new", "-name", "app", "-path", dir, "-on-conflict", "prompt"})
+	testutil.AssertEqual(t, code, cli.ExitOK)
+
+	stdout = out.String()
+	for _, want := range []string{
+		"README.md can't be merged",
+		"merged     .gitignore",
+		"kept       README.md",
+		"replaced   index.html",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("stdout should contain %q:\n%s", want, stdout)
+		}
+	}
+	testutil.AssertFileContains(t, filepath.Join(dir, ".gitignore"), "secrets/")
+	testutil.AssertFileContains(t, filepath.Join(dir, "README.md"), "mine")
+}
+
+func TestNewDryRunDiff(t *testing.T) {
+	dir := filepath.Join(testutil.TempDir(t), "app")
+	code, stdout, _ := run("new", "-quick", "-name", "app", "-path", dir)
+	if code != cli.ExitOK && strings.Contains(stdout, "Pre-flight checks failed") {
+		t.Skip("pre-flight checks fail in this environment")
+	}
+	testutil.AssertEqual(t, code, cli.ExitOK)
+
+	code, stdout, _ = run("new", "-quick", "-name", "app", "-path", dir, "-dry-run=diff")
+	testutil.AssertEqual(t, code, cli.ExitOK)
+	if !strings.Contains(stdout, "0 new, 0 modified") {
+		t.Errorf("stdout should report no changes:\n%s", stdout)
+	}
+
+	code, stdout, _ = run("new", "-quick", "-name", "app", "-path", dir, "-dry-run=diff", "-styling", "sass")
+	testutil.AssertEqual(t, code, cli.ExitChanges)
+	if !strings.Contains(stdout, "modified   package.json") {
+		t.Errorf("stdout should list package.json as modified:\n%s", stdout)
+	}
+}
+
+func TestNewDryRunJSON(t *testing.T) {
+	dir := filepath.Join(testutil.TempDir(t), "app")
+	code, stdout, stderr := run("new", "-quick", "-name", "app", "-path", dir, "-dry-run=json", "-dry-run-content")
+	if code != cli.ExitOK && strings.Contains(stderr, "Pre-flight checks failed") {
+		t.Skip("pre-flight checks fail in this environment")
+	}
+	testutil.AssertEqual(t, code, cli.ExitOK)
+
+	// stdout is only the document; progress goes to stderr
+	var doc struct {
+		ProjectName string `json:"projectName"`
+		Entries     []struct {
+			Path    string  `json:"path"`
+			Type    string  `json:"type"`
+			SHA256  string  `json:"sha256"`
+			Content *string `json:"content"`
+		} `json:"entries"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &doc); err != nil {
+		t.Fatalf("stdout should be JSON: %v\n%s", err, stdout)
+	}
+	testutil.AssertEqual(t, doc.ProjectName, "app")
+	found := false
+	for _, e := range doc.Entries {
+		if e.Path == "package.json" {
+			found = e.Type == "file" && e.SHA256 != "" && e.Content != nil && strings.Contains(*e.Content, `"name": "app"`)
+		}
+	}
+	if !found {
+		t.Errorf("package.json should be listed with its digest and content:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "Generating project...") {
+		t.Errorf("progress should go to stderr:\n%s", stderr)
+	}
+	testutil.AssertFileNotExists(t, dir)
+
+	code, stdout, _ = run("new", "-quick", "-name", "app", "-path", dir, "-dry-run=files")
+	testutil.AssertEqual(t, code, cli.ExitOK)
+	if !strings.HasPrefix(stdout, "==> ") || !strings.Contains(stdout, "==> package.json <==\n{") {
+		t.Errorf("stdout should hold each file under a header:\n%s", stdout)
+	}
+}
+
+func TestNewArchive(t *testing.T) {
+	dir := testutil.TempDir(t)
+	archive := filepath.Join(dir, "out", "app.zip")
+	code, stdout, stderr := run("new", "-quick", "-name", "app", "-archive", archive, "-output", "json")
+	testutil.AssertEqual(t, code, cli.ExitOK)
+
+	var report struct {
+		Archive   string   `json:"archive"`
+		Files     []string `json:"files"`
+		NextSteps []string `json:"nextSteps"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("stdout should be JSON: %v\n%s%s", err, stdout, stderr)
+	}
+	testutil.AssertEqual(t, report.Archive, archive)
+	testutil.AssertEqual(t, report.NextSteps[0], "unzip "+archive)
+
+	r, err := zip.OpenReader(archive)
+	testutil.AssertNoError(t, err)
+	defer r.Close()
+	names := make(map[string]bool)
+	for _, f := range r.File {
+		names[f.Name] = true
+	}
+	for _, name := range report.Files {
+		if !names["app/"+name] {
+			t.Errorf("app/%s is missing from the archive", name)
+		}
+	}
+
+	// Only the archive is written
+	entries, _ := os.ReadDir(filepath.Dir(archive))
+	testutil.AssertEqual(t, len(entries), 1)
+	testutil.AssertFileNotExists(t, filepath.Join(dir, "app"))
+}
+
+func TestAddCommand(t *testing.T) {
+	dir := filepath.Join(testutil.TempDir(t), "app")
+	code, stdout, _ := run("new", "-quick", "-name", "app", "-path", dir)
+	if code != cli.ExitOK && strings.Contains(stdout, "Pre-flight checks failed") {
+		t.Skip("pre-flight checks fail in this environment")
+	}
+	testutil.AssertEqual(t, code, cli.ExitOK)
+
+	code, stdout, stderr := run("add", "-path", dir, "-dry-run", "dataviz=recharts")
+	testutil.AssertEqual(t, code, cli.ExitOK)
+	for _, want := range []string{"options from .frontforge/manifest.json", "Adding data visualization: Recharts", "recharts", "Dry run"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("stdout should contain %q:\n%s%s", want, stdout, stderr)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "package.json")); strings.Contains(string(data), "recharts") {
+		t.Error("a dry run should not change package.json")
+	}
+
+	code, _, _ = run("add", "-path", dir, "dataviz=recharts")
+	testutil.AssertEqual(t, code, cli.ExitOK)
+	testutil.AssertFileContains(t, filepath.Join(dir, "package.json"), `"recharts"`)
+
+	code, _, stderr = run("add", "-path", dir, "dataviz=recharts")
+	testutil.AssertEqual(t, code, cli.ExitFailure)
+	if !strings.Contains(stderr, "already uses") {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+}
+
+func TestRemoveCommand(t *testing.T) {
+	dir := filepath.Join(testutil.TempDir(t), "app")
+	code, stdout, _ := run("new", "-quick", "-name", "app", "-path", dir, "-testing", "vitest")
+	if code != cli.ExitOK && strings.Contains(stdout, "Pre-flight checks failed") {
+		t.Skip("pre-flight checks fail in this environment")
+	}
+	testutil.AssertEqual(t, code, cli.ExitOK)
+
+	code, _, stderr := run("remove", "-path", dir, "jest")
+	testutil.AssertEqual(t, code, cli.ExitFailure)
+	if !strings.Contains(stderr, "not Jest") {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+
+	code, stdout, stderr = run("remove", "-path", dir, "vitest")
+	testutil.AssertEqual(t, code, cli.ExitOK)
+	for _, want := range []string{"Removing testing: Vitest", "removed   vitest.config.ts", "removed   test (package.json scripts)"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("stdout should contain %q:\n%s%s", want, stdout, stderr)
+		}
+	}
+	testutil.AssertFileNotExists(t, filepath.Join(dir, "vitest.config.ts"))
+}
+
+func TestDoctorCommand(t *testing.T) {
+	dir := filepath.Join(testutil.TempDir(t), "app")
+	code, stdout, _ := run("new", "-quick", "-name", "app", "-path", dir)
+	if code != cli.ExitOK && strings.Contains(stdout, "Pre-flight checks failed") {
+		t.Skip("pre-flight checks fail in this environment")
+	}
+	testutil.AssertEqual(t, code, cli.ExitOK)
+	testutil.AssertNoError(t, os.Remove(filepath.Join(dir, "index.html")))
+
+	code, stdout, _ = run("doctor", "-path", dir, "-output", "json")
+	testutil.AssertEqual(t, code, cli.ExitFailure)
+	var report struct {
+		Passed bool `json:"passed"`
+		Checks []struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		} `json:"checks"`
+	}
+	testutil.AssertNoError(t, json.Unmarshal([]byte(stdout), &report))
+	statuses := make(map[string]string)
+	for _, c := range report.Checks {
+		statuses[c.ID] = c.Status
+	}
+	testutil.AssertEqual(t, report.Passed, false)
+	testutil.AssertEqual(t, statuses["index-html"], "fail")
+	testutil.AssertEqual(t, statuses["package-json"], "pass")
+	if _, ok := statuses["node"]; !ok {
+		t.Error("environment checks should be included")
+	}
+
+	code, stdout, _ = run("doctor", "-path", dir)
+	testutil.AssertEqual(t, code, cli.ExitFailure)
+	if !strings.Contains(stdout, "[FAIL] index.html entry (index-html)") {
+		t.Errorf("unexpected report:\n%s", stdout)
+	}
+}
@@ -0,0 +1,318 @@
+// Package configfile loads declarative project configuration files.
+//
+// A config file describes every models.Config field so the same stack can be
+// regenerated without retyping flags or walking through the TUI:
+//
+//	{
+//	  "version": 1,
+//	  "name": "my-app",
+//	  "framework": "React",
+//	  "styling": "Tailwind CSS"
+//	}
+//
+// JSON (.json) and a flat subset of YAML (.yaml, .yml) are supported.
+// Fields that are omitted fall back to the caller's defaults. Every file
+// carries a schema version so older files keep loading after the format
+// evolves; Schema() returns a JSON Schema document for editor completion.
+package configfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"frontforge/internal/models"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CurrentVersion is the schema version written by this release
+const CurrentVersion = 1
+
+// File is the on-disk representation of a project configuration.
+// Empty fields mean "use the default".
+type File struct {
+	Schema          string `json:"$schema,omitempty"`
+	Version         int    `json:"version"`
+	Name            string `json:"name,omitempty"`
+	Path            string `json:"path,omitempty"`
+	Language        string `json:"language,omitempty"`
+	Framework       string `json:"framework,omitempty"`
+	PackageManager  string `json:"packageManager,omitempty"`
+	Styling         string `json:"styling,omitempty"`
+	UILibrary       string `json:"uiLibrary,omitempty"`
+	Routing         string `json:"routing,omitempty"`
+	Testing         string `json:"testing,omitempty"`
+	StateManagement string `json:"stateManagement,omitempty"`
+	FormManagement  string `json:"formManagement,omitempty"`
+	DataFetching    string `json:"dataFetching,omitempty"`
+	Animation       string `json:"animation,omitempty"`
+	Icons           string `json:"icons,omitempty"`
+	DataViz         string `json:"dataViz,omitempty"`
+	Utilities       string `json:"utilities,omitempty"`
+	I18n            string `json:"i18n,omitempty"`
+	Structure       string `json:"structure,omitempty"`
+	Install         bool   `json:"install,omitempty"`
+	NoScaffold      bool   `json:"noScaffold,omitempty"`
+}
+
+// fileField returns the File field for a registry key (models.OptionGroup.Key)
+func fileField(f *File, key string) *string {
+	return models.KeyedField(f, key)
+}
+
+// migrations upgrade a raw document from version N to N+1.
+// Add an entry here whenever CurrentVersion is bumped.
+var migrations = map[int]func(map[string]interface{}){}
+
+// Load reads, migrates and validates a config file.
+// The format is chosen by extension: .json, .yaml or .yml.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		raw, err = parseJSON(data)
+	case ".yaml", ".yml":
+		raw, err = parseYAML(data)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q (use .json, .yaml or .yml)", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+
+	return decode(raw)
+}
+
+// Parse decodes a JSON config document. Used by callers that don't read from disk.
+func Parse(data []byte) (*File, error) {
+	raw, err := parseJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return decode(raw)
+}
+
+// decode migrates a raw document to CurrentVersion and validates it
+func decode(raw map[string]interface{}) (*File, error) {
+	version := CurrentVersion
+	if v, ok := raw["version"]; ok {
+		n, ok := v.(float64)
+		if !ok || n != float64(int(n)) || n < 1 {
+			return nil, fmt.Errorf("version must be a positive integer")
+		}
+		version = int(n)
+	}
+	if version > CurrentVersion {
+		return nil, fmt.Errorf("config version %d is newer than supported version %d; upgrade frontforge", version, CurrentVersion)
+	}
+	for ; version < CurrentVersion; version++ {
+		if migrate, ok := migrations[version]; ok {
+			migrate(raw)
+		}
+	}
+	raw["version"] = CurrentVersion
+
+	// Round-trip through JSON with unknown fields rejected so typos surface
+	normalized, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(normalized))
+	dec.DisallowUnknownFields()
+
+	var f File
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Validate checks the project name and normalizes every enumerated field to
+// its canonical value. Matching is case-insensitive and also accepts the
+// short CLI values (e.g. "tailwind" for "Tailwind CSS").
+func (f *File) Validate() error {
+	if f.Name != "" && !models.ValidProjectName(f.Name) {
+		return fmt.Errorf("invalid name %q: use only letters, numbers, hyphens, and underscores", f.Name)
+	}
+
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		value := fileField(f, g.Key)
+		if *value == "" {
+			continue
+		}
+		canonical, ok := g.Parse(*value)
+		if !ok {
+			return fmt.Errorf("invalid %s %q. Valid options: %s", g.Key, *value, strings.Join(g.Values(), ", "))
+		}
+		*value = canonical
+	}
+
+	// Options missing from the file are filled in and checked by the caller
+	var partial models.Config
+	f.Apply(&partial)
+	if violations := models.CheckCompatibility(partial); len(violations) > 0 {
+		return &models.CompatibilityError{Violations: violations}
+	}
+
+	return nil
+}
+
+// Has reports whether the file sets the option with the given registry key
+func (f *File) Has(key string) bool {
+	return *fileField(f, key) != ""
+}
+
+// Apply copies every non-empty field onto config. Framework is applied by the
+// caller first so framework-specific defaults can be filled in beforehand.
+func (f *File) Apply(config *models.Config) {
+	if f.Name != "" {
+		config.ProjectName = f.Name
+	}
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		if value := *fileField(f, g.Key); value != "" {
+			*g.Field(config) = value
+		}
+	}
+	if f.Install {
+		config.AutoInstall = true
+	}
+	if f.NoScaffold {
+		config.NoScaffold = true
+	}
+}
+
+// Resolve builds the config the file describes: the quick preset, switched
+// to the file's framework defaults, with the file's values on top. Defaults
+// that conflict with values the file sets are replaced.
+func (f *File) Resolve() models.Config {
+	config := models.QuickPreset()
+	if f.Framework != "" {
+		config.Framework = f.Framework
+		AdjustFrameworkDefaults(&config)
+	}
+	f.Apply(&config)
+	models.FixCompatibility(&config, func(key string) bool { return !f.Has(key) })
+	return config
+}
+
+// AdjustFrameworkDefaults replaces the React-oriented options of the quick
+// preset with sensible defaults for config.Framework
+func AdjustFrameworkDefaults(config *models.Config) {
+	switch config.Framework {
+	case models.FrameworkVue:
+		config.Routing = models.RoutingVueRouter
+		config.StateManagement = models.StatePinia
+		config.UILibrary = models.UILibraryVuetify
+		config.FormManagement = models.FormVeeValidate
+		config.DataFetching = models.DataAxios
+		config.Icons = models.IconsVueIcons
+		config.I18n = models.I18nVueI18n
+		config.Animation = models.AnimationAutoAnimate
+	case models.FrameworkAngular:
+		config.Routing = models.RoutingAngularRouter
+		config.StateManagement = models.StateNgRx
+		config.UILibrary = models.UILibraryAngularMaterial
+		config.FormManagement = models.FormNone
+		config.DataFetching = models.DataFetchAPI
+		config.Animation = models.AnimationNone
+		config.Icons = models.IconsNone
+		config.I18n = models.I18nNone
+	case models.FrameworkSvelte:
+		config.Routing = models.RoutingSvelteKit
+		config.StateManagement = models.StateSvelteStores
+		config.UILibrary = models.UILibraryNone
+		config.FormManagement = models.FormNone
+		config.DataFetching = models.DataFetchAPI
+		config.Animation = models.AnimationAutoAnimate
+		config.Icons = models.IconsLucide
+		config.I18n = models.I18nNone
+	case models.FrameworkSolid:
+		config.Routing = models.RoutingSolidRouter
+		config.StateManagement = models.StateSolidStores
+		config.UILibrary = models.UILibraryNone
+		config.FormManagement = models.FormNone
+		config.DataFetching = models.DataFetchAPI
+		config.Animation = models.AnimationAutoAnimate
+		config.Icons = models.IconsLucide
+		config.I18n = models.I18nNone
+	case models.FrameworkVanilla:
+		config.Routing = models.RoutingNone
+		config.StateManagement = models.StateNone
+		config.UILibrary = models.UILibraryNone
+		config.FormManagement = models.FormNone
+		config.DataFetching = models.DataFetchAPI
+		config.Animation = models.AnimationNone
+		config.Icons = models.IconsNone
+		config.I18n = models.I18nNone
+	case models.FrameworkNextJS:
+		config.Routing = models.RoutingNextJSAppRouter
+		config.StateManagement = models.StateNone
+		config.UILibrary = models.UILibraryNone
+		config.FormManagement = models.FormNone
+		config.DataFetching = models.DataFetchAPI
+		config.Animation = models.AnimationNone
+		config.Icons = models.IconsLucide
+		config.I18n = models.I18nNone
+	case models.FrameworkAstro:
+		config.Routing = models.RoutingAstroPages
+		config.StateManagement = models.StateNone
+		config.UILibrary = models.UILibraryNone
+		config.FormManagement = models.FormNone
+		config.DataFetching = models.DataFetchAPI
+		config.Animation = models.AnimationNone
+		config.Icons = models.IconsNone
+		config.I18n = models.I18nNone
+	case models.FrameworkSvelteKit:
+		config.Routing = models.RoutingSvelteKit
+		config.StateManagement = models.StateSvelteStores
+		config.UILibrary = models.UILibraryNone
+		config.FormManagement = models.FormNone
+		config.DataFetching = models.DataFetchAPI
+		config.Animation = models.AnimationNone
+		config.Icons = models.IconsLucide
+		config.I18n = models.I18nNone
+	}
+}
+
+// FromConfig builds a File describing config, suitable for saving
+func FromConfig(config models.Config) *File {
+	f := &File{
+		Version:    CurrentVersion,
+		Name:       config.ProjectName,
+		Install:    config.AutoInstall,
+		NoScaffold: config.NoScaffold,
+	}
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		*fileField(f, g.Key) = *g.Field(&config)
+	}
+	return f
+}
+
+// Marshal encodes the file as indented JSON
+func (f *File) Marshal() ([]byte, error) {
+	return json.MarshalIndent(f, "", "  ")
+}
+
+func parseJSON(data []byte) (map[string]interface{}, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("config must be a JSON object")
+	}
+	return raw, nil
+}
@@ -0,0 +1,105 @@
+package configfile
+
+import (
+	"encoding/json"
+	"frontforge/internal/models"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// SchemaID is the $id advertised in the generated JSON Schema
+const SchemaID = "https://github.com/hugompham/frontforge/schema/config-v1.json"
+
+// Schema returns a JSON Schema (draft 2020-12) describing config files,
+// so editors can validate and autocomplete them
+func Schema() ([]byte, error) {
+	properties := map[string]interface{}{
+		"$schema": map[string]interface{}{
+			"type": "string",
+		},
+		"version": map[string]interface{}{
+			"type":        "integer",
+			"description": "Config schema version",
+			"minimum":     1,
+			"maximum":     CurrentVersion,
+			"default":     CurrentVersion,
+		},
+		"name": map[string]interface{}{
+			"type":        "string",
+			"description": "Project name (letters, numbers, hyphens, and underscores)",
+			"pattern":     "^[A-Za-z0-9_-]+$",
+		},
+		"path": map[string]interface{}{
+			"type":        "string",
+			"description": "Project path; '.' for the current directory. Defaults to a folder named after the project",
+		},
+		"install": map[string]interface{}{
+			"type":        "boolean",
+			"description": "Run the package manager install after generation",
+		},
+		"noScaffold": map[string]interface{}{
+			"type":        "boolean",
+			"description": "Skip the upstream CLI scaffold (meta-frameworks only)",
+		},
+	}
+
+	// Values are matched like Validate does: the value, ID or an alias, in
+	// any case. The enum drives completion; the pattern accepts other casings.
+	for _, g := range models.Registry {
+		spellings := optionSpellings(&g)
+		properties[g.Key] = map[string]interface{}{
+			"type":        "string",
+			"description": g.Description + " (case-insensitive; short IDs and aliases are accepted)",
+			"anyOf": []interface{}{
+				map[string]interface{}{"enum": spellings},
+				map[string]interface{}{"pattern": caseInsensitivePattern(spellings)},
+			},
+		}
+	}
+
+	schema := map[string]interface{}{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"$id":                  SchemaID,
+		"title":                "FrontForge project configuration",
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties":           properties,
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// optionSpellings returns every accepted spelling of a group's options: the
+// config value, the ID and the aliases of each, without duplicates
+func optionSpellings(g *models.OptionGroup) []string {
+	var spellings []string
+	seen := make(map[string]bool)
+	for _, o := range g.Options {
+		for _, s := range append([]string{o.Value, o.ID}, o.Aliases...) {
+			if !seen[s] {
+				seen[s] = true
+				spellings = append(spellings, s)
+			}
+		}
+	}
+	return spellings
+}
+
+// caseInsensitivePattern matches any of words ignoring case. JSON Schema
+// patterns have no case-insensitive flag, so each letter becomes a class.
+func caseInsensitivePattern(words []string) string {
+	alternatives := make([]string, len(words))
+	for i, word := range words {
+		var b strings.Builder
+		for _, r := range word {
+			if lower, upper := unicode.ToLower(r), unicode.ToUpper(r); lower != upper {
+				b.WriteString("[" + string(lower) + string(upper) + "]")
+			} else {
+				b.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		alternatives[i] = b.String()
+	}
+	return "^(?:" + strings.Join(alternatives, "|") + ")$"
+}
@@ -0,0 +1,92 @@
+package configfile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseYAML decodes the flat YAML subset used by config files:
+// one "key: value" pair per line, # comments, quoted or bare scalars,
+// booleans and integers. Nested maps and lists are rejected.
+func parseYAML(data []byte) (map[string]interface{}, error) {
+	raw := make(map[string]interface{})
+
+	for i, line := range strings.Split(string(data), "\n") {
+		lineNum := i + 1
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' || strings.HasPrefix(trimmed, "- ") {
+			return nil, fmt.Errorf("line %d: nested values are not supported", lineNum)
+		}
+
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", lineNum)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("line %d: missing key", lineNum)
+		}
+		if _, dup := raw[key]; dup {
+			return nil, fmt.Errorf("line %d: duplicate key %q", lineNum, key)
+		}
+
+		scalar, err := parseYAMLScalar(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		raw[key] = scalar
+	}
+
+	return raw, nil
+}
+
+// parseYAMLScalar converts a single YAML value into the type encoding/json would produce
+func parseYAMLScalar(value string) (interface{}, error) {
+	if value == "" {
+		return nil, fmt.Errorf("nested values are not supported")
+	}
+
+	// Quoted strings keep everything inside the quotes, including '#'
+	if value[0] == '"' || value[0] == '\'' {
+		quote := value[0]
+		end := strings.IndexByte(value[1:], quote)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		rest := strings.TrimSpace(value[end+2:])
+		if rest != "" && !strings.HasPrefix(rest, "#") {
+			return nil, fmt.Errorf("unexpected text after string: %s", rest)
+		}
+		return value[1 : end+1], nil
+	}
+
+	// Strip trailing comment from bare scalars
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = strings.TrimSpace(value[:idx])
+	}
+
+	if value[0] == '[' || value[0] == '{' || value[0] == '|' || value[0] == '>' {
+		return nil, fmt.Errorf("nested values are not supported")
+	}
+
+	switch strings.ToLower(value) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null", "~":
+		return "", nil
+	}
+
+	if n, err := strconv.Atoi(value); err == nil {
+		return float64(n), nil
+	}
+
+	return value, nil
+}
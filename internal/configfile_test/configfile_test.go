@@ -0,0 +1,148 @@
+package configfile_test
+
+import (
+	"encoding/json"
+	"frontforge/internal/configfile"
+	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLoadJSON(t *testing.T) {
+	dir := testutil.TempDir(t)
+	path := testutil.CreateTempFile(t, dir, "stack.json", `{
+  "version": 1,
+  "name": "billing-ui",
+  "framework": "vue",
+  "styling": "Tailwind CSS",
+  "uiLibrary": "None",
+  "install": true
+}`)
+
+	file, err := configfile.Load(path)
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertEqual(t, file.Name, "billing-ui")
+	testutil.AssertEqual(t, file.Framework, models.FrameworkVue) // normalized from "vue"
+	testutil.AssertEqual(t, file.Styling, models.StylingTailwind)
+	testutil.AssertEqual(t, file.Install, true)
+}
+
+func TestLoadYAML(t *testing.T) {
+	dir := testutil.TempDir(t)
+	path := testutil.CreateTempFile(t, dir, "stack.yaml", `# team default stack
+version: 1
+name: billing-ui
+framework: React
+styling: "Tailwind CSS"   # quoted values keep spaces
+testing: Vitest
+install: false
+`)
+
+	file, err := configfile.Load(path)
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertEqual(t, file.Framework, models.FrameworkReact)
+	testutil.AssertEqual(t, file.Styling, models.StylingTailwind)
+	testutil.AssertEqual(t, file.Testing, models.TestingVitest)
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		wantText string
+	}{
+		{"unknown field", "c.json", `{"name": "a", "framwork": "React"}`, "framwork"},
+		{"invalid value", "c.json", `{"name": "a", "styling": "less"}`, "invalid styling"},
+		{"invalid name", "c.json", `{"name": "my app"}`, "invalid name"},
+		{"future version", "c.json", `{"version": 99}`, "newer than supported"},
+		{"unsupported extension", "c.toml", `name = "a"`, "unsupported config file extension"},
+		{"nested yaml", "c.yaml", "name: a\nextra:\n  key: value\n", "nested values"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testutil.TempDir(t)
+			path := testutil.CreateTempFile(t, dir, tt.file, tt.content)
+
+			_, err := configfile.Load(path)
+			testutil.AssertError(t, err)
+			if !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("error %q should mention %q", err.Error(), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestApplyAndRoundTrip(t *testing.T) {
+	original := models.QuickPreset()
+	original.ProjectName = "round-trip"
+
+	data, err := configfile.FromConfig(original).Marshal()
+	testutil.AssertNoError(t, err)
+
+	file, err := configfile.Parse(data)
+	testutil.AssertNoError(t, err)
+
+	var restored models.Config
+	file.Apply(&restored)
+
+	if restored != original {
+		t.Errorf("round trip mismatch:\n got  %+v\n want %+v", restored, original)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	data, err := configfile.Schema()
+	testutil.AssertNoError(t, err)
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	props, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("schema has no properties")
+	}
+	for _, key := range []string{"version", "name", "framework", "styling", "i18n", "structure"} {
+		if _, ok := props[key]; !ok {
+			t.Errorf("schema missing property %q", key)
+		}
+	}
+
+	// Every spelling the loader accepts validates, in any case
+	for _, g := range models.Registry {
+		anyOf := props[g.Key].(map[string]interface{})["anyOf"].([]interface{})
+		enum := anyOf[0].(map[string]interface{})["enum"].([]interface{})
+		pattern := regexp.MustCompile(anyOf[1].(map[string]interface{})["pattern"].(string))
+		for _, o := range g.Options {
+			for _, spelling := range append([]string{o.Value, o.ID}, o.Aliases...) {
+				if !containsValue(enum, spelling) {
+					t.Errorf("%s: enum should offer %q", g.Key, spelling)
+				}
+				for _, s := range []string{spelling, strings.ToUpper(spelling), strings.ToLower(spelling)} {
+					if !pattern.MatchString(s) {
+						t.Errorf("%s: pattern should accept %q", g.Key, s)
+					}
+				}
+			}
+		}
+		if pattern.MatchString("crayons") {
+			t.Errorf("%s: pattern should reject unknown values", g.Key)
+		}
+	}
+}
+
+func containsValue(values []interface{}, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
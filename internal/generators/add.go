@@ -0,0 +1,313 @@
+package generators
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"frontforge/internal/generators/shared"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// AddPlan applies options to an existing project: the files the options
+// generate, the wiring they need in the build config and entry point, and
+// their dependencies. Nothing is written until Commit.
+type AddPlan struct {
+	From     models.Config // Options the project has
+	To       models.Config // Options after the change
+	Detected bool          // From was detected from package.json rather than read from the manifest
+
+	Files           []UpgradeFile
+	Dependencies    map[string]string // Added to package.json
+	DevDependencies map[string]string
+	Scripts         map[string]string
+
+	projectPath string
+	manifest    *manifest.Manifest // nil when the options were detected
+	rendered    *vfs.Memory        // Generation with the new options, for the manifest update
+}
+
+// PlanAdd works out how to apply changes (registry keys to Config values)
+// to the project in projectPath. The project's options come from its
+// manifest, or are detected from package.json and the file layout (see
+// DetectConfig). The project is rendered in memory with the old and the new
+// options: files only the new options produce are added, the build config,
+// entry point and TypeScript configs are three-way merged, and dependencies
+// and scripts go through shared.MergePackageJSON.
+func PlanAdd(fsys vfs.FS, projectPath string, changes map[string]string) (*AddPlan, error) {
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &AddPlan{projectPath: projectPath}
+	m, err := manifest.Load(fsys, projectPath)
+	switch {
+	case err == nil:
+		p.From = m.Config
+		p.From.ProjectPath = projectPath
+	case errors.Is(err, manifest.ErrNotFound):
+		if p.From, err = DetectConfig(fsys, projectPath); err != nil {
+			return nil, err
+		}
+		p.Detected = true
+	default:
+		return nil, err
+	}
+	if models.IsMetaFramework(p.From.Framework) {
+		return nil, fmt.Errorf("adding options to %s projects is not supported: their files come from the upstream CLI", p.From.Framework)
+	}
+
+	p.To = p.From
+	for key, value := range changes {
+		g, ok := models.LookupGroup(key)
+		if !ok {
+			return nil, fmt.Errorf("unknown option '%s'", key)
+		}
+		if fixedGroups[g.Key] {
+			return nil, fmt.Errorf("the %s is chosen when a project is created and can't be added", g.Name)
+		}
+		if !g.AppliesTo(p.From.Framework) || !g.Supports(value, p.From.Framework) {
+			return nil, fmt.Errorf("%s '%s' is not available for %s", g.Name, value, p.From.Framework)
+		}
+		current := *g.Field(&p.From)
+		if current == value {
+			return nil, fmt.Errorf("the project already uses %s for %s", value, g.Name)
+		}
+		if o, ok := g.Find(current); ok && len(o.Packages) > 0 {
+			return nil, fmt.Errorf("the project already uses %s for %s; it can't be replaced with %s", current, g.Name, value)
+		}
+		*g.Field(&p.To) = value
+	}
+	if violations := models.CheckCompatibility(p.To); len(violations) > 0 {
+		return nil, &models.CompatibilityError{Violations: violations}
+	}
+
+	// Versions come from the catalog
+	p.From.ResolveLatest, p.To.ResolveLatest = false, false
+	before, after := vfs.NewMemory(), vfs.NewMemory()
+	if _, err := GenerateFS(before, p.From, io.Discard); err != nil {
+		return nil, fmt.Errorf("failed to render the project's current options: %w", err)
+	}
+	result, err := GenerateFS(after, p.To, io.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the new options: %w", err)
+	}
+	if m != nil {
+		p.manifest, p.rendered = m, after
+	}
+
+	generated := make(map[string]bool)
+	for _, rel := range result.Files {
+		if strings.HasPrefix(rel, vfs.StateDir+"/") || rel == "package.json" {
+			continue
+		}
+		generated[rel] = true
+		path := filepath.Join(projectPath, filepath.FromSlash(rel))
+		data, err := after.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		old, err := before.ReadFile(path)
+		if err == nil && (bytes.Equal(old, data) || !isWiringFile(rel)) {
+			continue // Not affected, or example code left to the user
+		}
+		f, err := planAddFile(fsys, path, rel, old, data)
+		if err != nil {
+			return nil, err
+		}
+		p.Files = append(p.Files, f)
+	}
+
+	// Files only the old options produced (e.g. App.module.css when CSS
+	// Modules make way for Tailwind) stay; they may be in use
+	var obsolete []string
+	for _, path := range before.Files() {
+		rel, _ := filepath.Rel(projectPath, path)
+		rel = filepath.ToSlash(rel)
+		if !generated[rel] && !strings.HasPrefix(rel, vfs.StateDir+"/") && rel != "package.json" && vfs.Exists(fsys, path) {
+			obsolete = append(obsolete, rel)
+		}
+	}
+	sort.Strings(obsolete)
+	for _, rel := range obsolete {
+		p.Files = append(p.Files, UpgradeFile{Path: rel, Status: UpgradeObsolete})
+	}
+
+	if err := p.planPackageJSON(fsys); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// planAddFile decides what to do with a file the new options generate
+// differently. old is nil for files new to the options.
+func planAddFile(fsys vfs.FS, path, rel string, old, generated []byte) (UpgradeFile, error) {
+	f := UpgradeFile{Path: rel}
+	current, err := fsys.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		if old != nil {
+			f.Status = UpgradeDeleted
+		} else {
+			f.Status, f.data = UpgradeAdded, generated
+		}
+		return f, nil
+	case err != nil:
+		return f, err
+	case old == nil && bytes.Equal(current, generated):
+		f.Status = UpgradeUnchanged
+		return f, nil
+	case old == nil:
+		f.Status = UpgradeKept
+		return f, nil
+	}
+	return planMerge(f, old, current, generated, "frontforge add"), nil
+}
+
+// isWiringFile reports whether add updates an existing file: the Vite and
+// TypeScript configs and the main entry file, which wire options in.
+// Example components and the README are left to the user.
+func isWiringFile(rel string) bool {
+	name := filepath.Base(rel)
+	switch {
+	case rel == "vite.config.ts", rel == "vite.config.js":
+		return true
+	case strings.HasPrefix(rel, "src/main."):
+		return true
+	case strings.HasPrefix(name, "tsconfig") && strings.HasSuffix(name, ".json"):
+		return rel == name
+	}
+	return false
+}
+
+// planPackageJSON collects the dependencies and scripts the new options add
+// that package.json doesn't have yet
+func (p *AddPlan) planPackageJSON(fsys vfs.FS) error {
+	var current PackageJSON
+	data, err := fsys.ReadFile(filepath.Join(p.projectPath, "package.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read package.json: %w", err)
+	}
+	if err := json.Unmarshal(data, &current); err != nil {
+		return fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	old, updated := GeneratePackageJSON(p.From), GeneratePackageJSON(p.To)
+	p.Dependencies = missingEntries(updated.Dependencies, old.Dependencies, current.Dependencies)
+	p.DevDependencies = missingEntries(updated.DevDependencies, old.DevDependencies, current.DevDependencies)
+	p.Scripts = missingEntries(updated.Scripts, old.Scripts, current.Scripts)
+	return nil
+}
+
+// missingEntries returns the entries of updated that neither old nor current has
+func missingEntries(updated, old, current map[string]string) map[string]string {
+	missing := make(map[string]string)
+	for name, value := range updated {
+		if _, ok := old[name]; ok {
+			continue
+		}
+		if _, ok := current[name]; ok {
+			continue
+		}
+		missing[name] = value
+	}
+	return missing
+}
+
+// Changed returns the number of files written, counting package.json
+func (p *AddPlan) Changed() int {
+	n := countWrites(p.Files)
+	if len(p.Dependencies)+len(p.DevDependencies)+len(p.Scripts) > 0 {
+		n++
+	}
+	return n
+}
+
+// Conflicts returns the number of files merged with conflict markers
+func (p *AddPlan) Conflicts() int {
+	return countConflicts(p.Files)
+}
+
+// Apply writes the planned files and package.json changes through fsys, and
+// updates the manifest when the project has one
+func (p *AddPlan) Apply(fsys vfs.FS) error {
+	if err := writePlanned(fsys, p.projectPath, p.Files); err != nil {
+		return err
+	}
+	pkgPath := filepath.Join(p.projectPath, "package.json")
+	var pkgBefore, pkgAfter []byte
+	if p.Changed() > countWrites(p.Files) {
+		var err error
+		if pkgBefore, err = fsys.ReadFile(pkgPath); err != nil {
+			return fmt.Errorf("failed to read package.json: %w", err)
+		}
+		if err := shared.MergePackageJSON(fsys, p.projectPath, p.Dependencies, p.DevDependencies, p.Scripts); err != nil {
+			return err
+		}
+		if pkgAfter, err = fsys.ReadFile(pkgPath); err != nil {
+			return fmt.Errorf("failed to read package.json: %w", err)
+		}
+	}
+	if p.manifest == nil {
+		return nil
+	}
+	return p.writeState(fsys, pkgBefore, pkgAfter)
+}
+
+// writeState updates the manifest for the new options. Only files add wrote
+// get a new hash and base copy, from the render: files it left alone keep
+// theirs, so they aren't reported as edited. package.json is recorded as
+// merged on disk if it was still as generated, and only the dependencies
+// add inserted join the recorded versions.
+func (p *AddPlan) writeState(fsys vfs.FS, pkgBefore, pkgAfter []byte) error {
+	next, err := manifest.Load(p.rendered, p.projectPath)
+	if err != nil {
+		return err
+	}
+	next.Files = p.manifest.Files
+	next.Dependencies, next.DevDependencies = p.manifest.Dependencies, p.manifest.DevDependencies
+	bases := make(map[string][]byte)
+	for _, f := range p.Files {
+		switch f.Status {
+		case UpgradeAdded, UpgradeUpdated, UpgradeMerged, UpgradeConflict, UpgradeUnchanged:
+		default:
+			continue
+		}
+		generated, err := p.rendered.ReadFile(filepath.Join(p.projectPath, filepath.FromSlash(f.Path)))
+		if err != nil {
+			return err
+		}
+		next.AddFile(f.Path, generated)
+		bases[f.Path] = generated
+	}
+	if pkgAfter != nil && p.manifest.Owned("package.json", pkgBefore) {
+		next.AddFile("package.json", pkgAfter)
+		bases["package.json"] = pkgAfter
+	}
+	if err := manifest.WriteBases(fsys, p.projectPath, bases); err != nil {
+		return fmt.Errorf("failed to keep copies of the added files: %w", err)
+	}
+	for name, version := range p.Dependencies {
+		next.Dependencies[name] = version
+	}
+	for name, version := range p.DevDependencies {
+		next.DevDependencies[name] = version
+	}
+	if err := next.Write(fsys, p.projectPath); err != nil {
+		return fmt.Errorf("failed to update the manifest: %w", err)
+	}
+	return nil
+}
+
+// Commit applies the plan to the project on disk in one transaction
+func (p *AddPlan) Commit() error {
+	return commitPlan(p.projectPath, p.Apply)
+}
@@ -0,0 +1,77 @@
+package generators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// GenerateArchive generates the project into a zip or tar.gz written to w,
+// with every entry under a top-level directory named after the project.
+// Vite-based projects are streamed straight into the archive; meta-frameworks
+// are scaffolded into a temporary directory, which is packed and removed.
+// config.ProjectPath only anchors paths, nothing is written there.
+// Generation stops when ctx is done.
+func GenerateArchive(ctx context.Context, w io.Writer, format vfs.ArchiveFormat, config models.Config, out io.Writer) (*Result, error) {
+	if config.ProjectName == "" {
+		return nil, fmt.Errorf("an archive needs a project name")
+	}
+	config.DryRun = false
+
+	projectPath, err := resolveProjectPath(config)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := vfs.NewArchive(w, format, projectPath, config.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	result, err := GenerateInto(ctx, archive, config, out)
+	if err != nil {
+		return nil, err
+	}
+	return result, archive.Close()
+}
+
+// copyDir copies the directories and regular files under src into fsys at
+// dst, keeping file modes. node_modules and .git are left out; symlinks and
+// other special files are skipped.
+func copyDir(fsys vfs.FS, src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			if path != src && (d.Name() == "node_modules" || d.Name() == ".git") {
+				return filepath.SkipDir
+			}
+			return fsys.MkdirAll(target)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := fsys.WriteFile(target, data, info.Mode().Perm()); !errors.Is(err, vfs.ErrKept) {
+			return err
+		}
+		return nil
+	})
+}
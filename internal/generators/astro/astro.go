@@ -1,11 +1,13 @@
 package astro
 
 import (
+	"context"
 	"fmt"
 	"frontforge/internal/generators/meta"
 	"frontforge/internal/generators/shared"
 	"frontforge/internal/models"
-	"os"
+	"frontforge/internal/versions"
+	"frontforge/internal/vfs"
 	"path/filepath"
 )
 
@@ -16,12 +18,12 @@ func init() {
 // Generator implements meta.MetaGenerator for Astro.
 type Generator struct{}
 
-func (g *Generator) Scaffold(cfg models.Config) error {
+func (g *Generator) Scaffold(ctx context.Context, cfg models.Config) error {
 	args := buildScaffoldArgs(cfg)
-	return meta.ExecScaffold(models.FrameworkAstro, cfg.DryRun, "npm", args...)
+	return meta.ExecScaffold(ctx, models.FrameworkAstro, cfg.DryRun, "npm", args...)
 }
 
-func (g *Generator) PostScaffold(cfg models.Config) error {
+func (g *Generator) PostScaffold(ctx context.Context, fsys vfs.FS, cfg models.Config) error {
 	dir := cfg.ProjectPath
 
 	deps := make(map[string]string)
@@ -29,24 +31,24 @@ func (g *Generator) PostScaffold(cfg models.Config) error {
 	scripts := make(map[string]string)
 
 	// ESLint
-	devDeps["eslint"] = "^9.39.1"
-	devDeps["@eslint/js"] = "^9.39.1"
-	devDeps["globals"] = "^15.15.0"
-	devDeps["typescript-eslint"] = "^8.56.1"
+	devDeps["eslint"] = versions.Version("eslint")
+	devDeps["@eslint/js"] = versions.Version("@eslint/js")
+	devDeps["globals"] = versions.Version("globals")
+	devDeps["typescript-eslint"] = versions.Version("typescript-eslint")
 	scripts["lint"] = "eslint ."
 
 	// Tailwind (Astro 5.2+: use @tailwindcss/vite, not @astrojs/tailwind)
 	if cfg.Styling == models.StylingTailwind {
-		devDeps["tailwindcss"] = "^4.2.1"
-		devDeps["@tailwindcss/vite"] = "^4.2.1"
+		devDeps["tailwindcss"] = versions.Version("tailwindcss")
+		devDeps["@tailwindcss/vite"] = versions.Version("@tailwindcss/vite")
 
 		// Create global CSS with Tailwind import
 		stylesDir := filepath.Join(dir, "src", "styles")
-		if err := os.MkdirAll(stylesDir, 0755); err != nil {
+		if err := fsys.MkdirAll(stylesDir); err != nil {
 			return fmt.Errorf("failed to create styles directory: %w", err)
 		}
 		globalCSS := "@import \"tailwindcss\";\n"
-		if err := os.WriteFile(filepath.Join(stylesDir, "global.css"), []byte(globalCSS), 0644); err != nil {
+		if err := vfs.WriteString(fsys, filepath.Join(stylesDir, "global.css"), globalCSS); err != nil {
 			return fmt.Errorf("failed to write global.css: %w", err)
 		}
 
@@ -61,27 +63,27 @@ export default defineConfig({
   },
 })
 `
-		if err := os.WriteFile(filepath.Join(dir, "astro.config.mjs"), []byte(astroConfig), 0644); err != nil {
+		if err := vfs.WriteString(fsys, filepath.Join(dir, "astro.config.mjs"), astroConfig); err != nil {
 			return fmt.Errorf("failed to write astro.config.mjs: %w", err)
 		}
 	}
 
 	if len(deps) > 0 || len(devDeps) > 0 || len(scripts) > 0 {
-		if err := shared.MergePackageJSON(dir, deps, devDeps, scripts); err != nil {
+		if err := shared.MergePackageJSON(fsys, dir, deps, devDeps, scripts); err != nil {
 			return err
 		}
 	}
 
 	// Testing
 	if cfg.Testing == models.TestingVitest {
-		if err := shared.ScaffoldVitest(dir, "astro"); err != nil {
+		if err := shared.ScaffoldVitest(fsys, dir, "astro"); err != nil {
 			return err
 		}
 	}
 
 	// Feature-based structure
 	if cfg.Structure == models.StructureFeatureBased {
-		if err := shared.ScaffoldFeatureStructure(dir, "astro"); err != nil {
+		if err := shared.ScaffoldFeatureStructure(fsys, dir, "astro"); err != nil {
 			return err
 		}
 	}
@@ -35,9 +35,13 @@ func getFileExtension(config models.Config) string {
 func getMainFileExtension(config models.Config) string {
 	isTS := config.Language == models.LangTypeScript
 
-	// Angular, Vanilla, Vue, and Svelte use plain .js or .ts (no JSX)
-	if config.Framework == models.FrameworkAngular ||
-		config.Framework == models.FrameworkVanilla ||
+	// Angular is always TypeScript, matching the index.html template
+	if config.Framework == models.FrameworkAngular {
+		return "ts"
+	}
+
+	// Vanilla, Vue, and Svelte use plain .js or .ts (no JSX)
+	if config.Framework == models.FrameworkVanilla ||
 		config.Framework == models.FrameworkVue ||
 		config.Framework == models.FrameworkSvelte {
 		if isTS {
@@ -155,13 +159,17 @@ bootstrapApplication(AppComponent)
 `
 	} else if config.Framework == models.FrameworkSvelte {
 		// Svelte 5 uses mount() instead of new App()
+		target := "document.getElementById('root')"
+		if isTS {
+			target += "!"
+		}
 		return fmt.Sprintf(`import { mount } from 'svelte'
 import App from './App.svelte'
 
 mount(App, {
-  target: document.getElementById('root')!
+  target: %s
 })
-`)
+`, target)
 	} else if config.Framework == models.FrameworkSolid {
 		// Solid uses render from solid-js/web
 		return fmt.Sprintf(`import { render } from 'solid-js/web'
@@ -193,6 +201,27 @@ if (root) {
 `, ext)
 }
 
+// GenerateVueRouter creates src/router/index, which the Vue main file
+// imports when Vue Router is selected
+func GenerateVueRouter(config models.Config) string {
+	return fmt.Sprintf(`import { h } from 'vue'
+import { createRouter, createWebHistory } from 'vue-router'
+
+const Home = { render: () => h('p', 'Welcome to your new %s app!') }
+const About = { render: () => h('p', 'This is the about page.') }
+
+const router = createRouter({
+  history: createWebHistory(),
+  routes: [
+    { path: '/', name: 'home', component: Home },
+    { path: '/about', name: 'about', component: About },
+  ],
+})
+
+export default router
+`, config.ProjectName)
+}
+
 // GenerateAppFile creates the App component
 func GenerateAppFile(config models.Config) string {
 	isTS := config.Language == models.LangTypeScript
@@ -328,6 +357,15 @@ export default App
 			langAttr = ` lang="ts"`
 		}
 
+		routerView := ""
+		if config.Routing == models.RoutingVueRouter {
+			routerView = `
+      <nav>
+        <RouterLink to="/">Home</RouterLink> | <RouterLink to="/about">About</RouterLink>
+      </nav>
+      <RouterView />`
+		}
+
 		return fmt.Sprintf(`<script setup%s>
 import { ref } from 'vue'
 
@@ -345,7 +383,7 @@ const count = ref(0)
         class="%s"
       >
         Count is {{ count }}
-      </button>
+      </button>%s
     </div>
   </div>
 </template>
@@ -360,6 +398,7 @@ const count = ref(0)
 			getTitleClass(config),
 			config.ProjectName,
 			getTailwindButtonClass(config, "blue"),
+			routerView,
 			getVueStyles(config))
 	} else if config.Framework == models.FrameworkAngular {
 		// Angular standalone component
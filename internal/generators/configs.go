@@ -3,6 +3,7 @@ package generators
 import (
 	"fmt"
 	"frontforge/internal/models"
+	"frontforge/internal/vfs"
 	"path/filepath"
 	"strings"
 )
@@ -133,12 +134,7 @@ func GenerateTSConfig(config models.Config) TSConfigSet {
 }
 
 // GenerateProjectStructure creates the directory structure for Vite-based frameworks
-func GenerateProjectStructure(
-	projectPath string,
-	config models.Config,
-	mkdirFunc func(string) error,
-	writeFunc func(string, string) error,
-) error {
+func GenerateProjectStructure(fsys vfs.FS, projectPath string, config models.Config) error {
 	// Create base directories
 	dirs := []string{
 		filepath.Join(projectPath, "src"),
@@ -158,7 +154,7 @@ func GenerateProjectStructure(
 
 		// Create all directories
 		for _, dir := range dirs {
-			if err := mkdirFunc(dir); err != nil {
+			if err := fsys.MkdirAll(dir); err != nil {
 				return err
 			}
 		}
@@ -188,7 +184,7 @@ features/
     └── services/
 ` + "```" + `
 `
-		if err := writeFunc(filepath.Join(projectPath, "src", "features", "README.md"), featureReadme); err != nil {
+		if err := writeFile(fsys, filepath.Join(projectPath, "src", "features", "README.md"), featureReadme); err != nil {
 			return err
 		}
 	} else {
@@ -205,7 +201,7 @@ features/
 
 		// Create all directories
 		for _, dir := range dirs {
-			if err := mkdirFunc(dir); err != nil {
+			if err := fsys.MkdirAll(dir); err != nil {
 				return err
 			}
 		}
@@ -227,5 +223,5 @@ features/
 }
 `, typeAnnotation)
 
-	return writeFunc(filepath.Join(projectPath, "src", "lib", fmt.Sprintf("utils.%s", ext)), utilsFile)
+	return writeFile(fsys, filepath.Join(projectPath, "src", "lib", fmt.Sprintf("utils.%s", ext)), utilsFile)
 }
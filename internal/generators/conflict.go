@@ -0,0 +1,332 @@
+package generators
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// ConflictOutcome records how a generated file that clashed with an
+// existing one was committed
+type ConflictOutcome struct {
+	Path   string `json:"path"`   // Relative to the project root
+	Action string `json:"action"` // skip, overwrite, backup or merge
+}
+
+// resolveConflict applies a conflict policy to one file, returning the
+// resolution and the action actually taken. Merging falls back to keeping
+// the existing file when it can't be merged.
+func resolveConflict(policy string, c vfs.Conflict) (vfs.Resolution, string) {
+	switch policy {
+	case models.OnConflictSkip, models.OnConflictPrompt:
+		return vfs.Resolution{Keep: true}, models.OnConflictSkip
+	case models.OnConflictBackup:
+		return vfs.Resolution{Backup: true}, models.OnConflictBackup
+	case models.OnConflictMerge:
+		merged, ok := MergeFile(c.Path, c.Existing, c.Generated)
+		if !ok {
+			return vfs.Resolution{Keep: true}, models.OnConflictSkip
+		}
+		return vfs.Resolution{Data: merged}, models.OnConflictMerge
+	default:
+		return vfs.Resolution{}, models.OnConflictOverwrite
+	}
+}
+
+// conflictFS applies a conflict policy to files that already exist in the
+// wrapped FS with other content, for filesystems written in place rather
+// than through a Transaction. Kept files are answered with vfs.ErrKept so
+// they stay out of the manifest, and merged files read back as generated so
+// the manifest records that as their base.
+type conflictFS struct {
+	vfs.FS
+
+	projectPath string
+	policy      string
+	outcomes    []ConflictOutcome
+	generated   map[string][]byte // Generated content of merged files
+}
+
+// WriteFile writes the file, or resolves the conflict with the existing one
+func (c *conflictFS) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	existing, err := c.FS.ReadFile(path)
+	if err != nil || bytes.Equal(existing, data) || isStateFile(c.projectPath, path) {
+		delete(c.generated, filepath.Clean(path))
+		return c.FS.WriteFile(path, data, perm)
+	}
+	res, action := resolveConflict(c.policy, vfs.Conflict{Path: path, Existing: existing, Generated: data})
+	rel, _ := filepath.Rel(c.projectPath, path)
+	c.outcomes = append(c.outcomes, ConflictOutcome{Path: filepath.ToSlash(rel), Action: action})
+	switch {
+	case res.Keep:
+		return vfs.ErrKept
+	case res.Backup:
+		if err := c.FS.WriteFile(path+".bak", existing, perm); err != nil {
+			return err
+		}
+	case res.Data != nil:
+		if c.generated == nil {
+			c.generated = make(map[string][]byte)
+		}
+		c.generated[filepath.Clean(path)] = data
+		data = res.Data
+	}
+	return c.FS.WriteFile(path, data, perm)
+}
+
+// ReadFile returns the generated content of merged files, and reads other
+// files from the wrapped FS
+func (c *conflictFS) ReadFile(path string) ([]byte, error) {
+	if data, ok := c.generated[filepath.Clean(path)]; ok {
+		return data, nil
+	}
+	return c.FS.ReadFile(path)
+}
+
+// HostPath forwards to the wrapped FS
+func (c *conflictFS) HostPath(path string) (string, bool) {
+	return vfs.HostPath(c.FS, path)
+}
+
+// Remove forwards to the wrapped FS
+func (c *conflictFS) Remove(path string) error {
+	return vfs.Remove(c.FS, path)
+}
+
+// CanMerge reports whether MergeFile understands the file at path:
+// package.json, tsconfig and jsconfig files, and .gitignore
+func CanMerge(path string) bool {
+	name := filepath.Base(path)
+	switch {
+	case name == ".gitignore", name == "package.json":
+		return true
+	case strings.HasSuffix(name, ".json"):
+		return strings.HasPrefix(name, "tsconfig") || strings.HasPrefix(name, "jsconfig")
+	}
+	return false
+}
+
+// MergeFile combines an existing file with its generated replacement. JSON
+// files are deep-merged and .gitignore gains the missing rules. It returns
+// false for files CanMerge rejects and for JSON that doesn't parse (e.g. a
+// tsconfig with comments).
+func MergeFile(path string, existing, generated []byte) ([]byte, bool) {
+	if !CanMerge(path) {
+		return nil, false
+	}
+	if filepath.Base(path) == ".gitignore" {
+		return mergeLines(existing, generated), true
+	}
+	merged, err := mergeJSON(existing, generated)
+	return merged, err == nil
+}
+
+// mergeLines appends the rules from generated that existing lacks, after a
+// blank line. Blank lines and comments aren't copied.
+func mergeLines(existing, generated []byte) []byte {
+	have := make(map[string]bool)
+	for _, line := range strings.Split(string(existing), "\n") {
+		have[strings.TrimSpace(line)] = true
+	}
+
+	var missing []string
+	for _, line := range strings.Split(string(generated), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || have[line] {
+			continue
+		}
+		have[line] = true
+		missing = append(missing, line)
+	}
+	if len(missing) == 0 {
+		return existing
+	}
+
+	var buf bytes.Buffer
+	buf.Write(existing)
+	if len(existing) > 0 {
+		if !bytes.HasSuffix(existing, []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(strings.Join(missing, "\n"))
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// mergeJSON deep-merges generated into existing. Existing values win, keys
+// only in generated are appended, arrays gain the missing items, and key
+// order is kept so the diff stays small.
+func mergeJSON(existing, generated []byte) ([]byte, error) {
+	a, err := decodeOrdered(existing)
+	if err != nil {
+		return nil, err
+	}
+	b, err := decodeOrdered(generated)
+	if err != nil {
+		return nil, err
+	}
+
+	var compact bytes.Buffer
+	if err := encodeOrdered(&compact, mergeValues(a, b)); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	if bytes.HasSuffix(existing, []byte("\n")) {
+		out.WriteByte('\n')
+	}
+	return out.Bytes(), nil
+}
+
+// orderedObject is a JSON object that remembers its key order
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func mergeValues(existing, generated interface{}) interface{} {
+	switch a := existing.(type) {
+	case *orderedObject:
+		b, ok := generated.(*orderedObject)
+		if !ok {
+			return existing
+		}
+		for _, key := range b.keys {
+			if value, ok := a.values[key]; ok {
+				a.values[key] = mergeValues(value, b.values[key])
+			} else {
+				a.keys = append(a.keys, key)
+				a.values[key] = b.values[key]
+			}
+		}
+		return a
+	case []interface{}:
+		b, ok := generated.([]interface{})
+		if !ok {
+			return existing
+		}
+		for _, item := range b {
+			found := false
+			for _, have := range a {
+				if reflect.DeepEqual(have, item) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				a = append(a, item)
+			}
+		}
+		return a
+	}
+	return existing
+}
+
+// decodeOrdered parses one JSON value, keeping object key order and numbers
+// as written
+func decodeOrdered(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	value, err := decodeValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return value, nil
+}
+
+func decodeValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := &orderedObject{values: make(map[string]interface{})}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := keyTok.(string)
+			value, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := obj.values[key]; !dup {
+				obj.keys = append(obj.keys, key)
+			}
+			obj.values[key] = value
+		}
+		_, err := dec.Token() // Closing brace
+		return obj, err
+	case json.Delim('['):
+		arr := []interface{}{}
+		for dec.More() {
+			value, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err := dec.Token() // Closing bracket
+		return arr, err
+	}
+	return tok, nil
+}
+
+// encodeOrdered writes compact JSON without HTML escaping, so scripts such
+// as "tsc && vite build" stay readable
+func encodeOrdered(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case *orderedObject:
+		buf.WriteByte('{')
+		for i, key := range v.keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeOrdered(buf, key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := encodeOrdered(buf, v.values[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeOrdered(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return err
+	}
+	buf.Truncate(buf.Len() - 1) // Encode adds a newline
+	return nil
+}
@@ -0,0 +1,165 @@
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+	"path/filepath"
+)
+
+// lockfiles maps lock file names to the package manager that writes them
+var lockfiles = []struct {
+	file string
+	pm   string
+}{
+	{"pnpm-lock.yaml", models.PackageManagerPnpm},
+	{"yarn.lock", models.PackageManagerYarn},
+	{"bun.lockb", models.PackageManagerBun},
+	{"bun.lock", models.PackageManagerBun},
+	{"package-lock.json", models.PackageManagerNpm},
+}
+
+// frameworkPackages identifies a framework by a package it depends on,
+// meta-frameworks first since they also depend on their base framework
+var frameworkPackages = []struct {
+	pkg       string
+	framework string
+}{
+	{"next", models.FrameworkNextJS},
+	{"astro", models.FrameworkAstro},
+	{"@sveltejs/kit", models.FrameworkSvelteKit},
+	{"@angular/core", models.FrameworkAngular},
+	{"vue", models.FrameworkVue},
+	{"react", models.FrameworkReact},
+	{"svelte", models.FrameworkSvelte},
+	{"solid-js", models.FrameworkSolid},
+	{"vite", models.FrameworkVanilla},
+}
+
+// fixedGroups are chosen when a project is created and can't be detected
+// from its dependencies
+var fixedGroups = map[string]bool{
+	"language":       true,
+	"framework":      true,
+	"packageManager": true,
+	"structure":      true,
+}
+
+// DetectPackageManager guesses the package manager from lock files in dir
+func DetectPackageManager(fsys vfs.FS, dir string) string {
+	for _, lf := range lockfiles {
+		if vfs.Exists(fsys, filepath.Join(dir, lf.file)) {
+			return lf.pm
+		}
+	}
+	return models.PackageManagerNpm
+}
+
+// DetectConfig works out the options of an existing project from its
+// package.json and file layout: the framework from its dependencies, the
+// language from the main entry file or tsconfig.json, and every other option
+// from the first package it installs. Options without packages (CSS Modules,
+// file-based routing, ...) are only detected where a file gives them away.
+func DetectConfig(fsys vfs.FS, dir string) (models.Config, error) {
+	var pkg struct {
+		Name            string            `json:"name"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	data, err := fsys.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return models.Config{}, fmt.Errorf("failed to read package.json: %w", err)
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return models.Config{}, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+	installed := func(name string) bool {
+		_, dep := pkg.Dependencies[name]
+		_, devDep := pkg.DevDependencies[name]
+		return dep || devDep
+	}
+
+	config := models.Config{
+		ProjectName:    pkg.Name,
+		ProjectPath:    dir,
+		PackageManager: DetectPackageManager(fsys, dir),
+	}
+	for _, fp := range frameworkPackages {
+		if installed(fp.pkg) {
+			config.Framework = fp.framework
+			break
+		}
+	}
+	if config.Framework == "" {
+		return config, fmt.Errorf("could not detect the framework: package.json depends on none of React, Vue, Angular, Svelte, Solid or Vite")
+	}
+
+	config.Language = models.LangJavaScript
+	for _, name := range []string{"main.ts", "main.tsx"} {
+		if vfs.Exists(fsys, filepath.Join(dir, "src", name)) {
+			config.Language = models.LangTypeScript
+		}
+	}
+	if vfs.Exists(fsys, filepath.Join(dir, "tsconfig.json")) {
+		config.Language = models.LangTypeScript
+	}
+
+	config.Structure = models.StructureLayerBased
+	if vfs.Exists(fsys, filepath.Join(dir, "src", "features")) {
+		config.Structure = models.StructureFeatureBased
+	}
+
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		if fixedGroups[g.Key] {
+			continue
+		}
+		*g.Field(&config) = detectOption(g, config.Framework, installed)
+	}
+	if config.Styling == models.StylingVanilla && vfs.Exists(fsys, filepath.Join(dir, "src", "App.module.css")) {
+		config.Styling = models.StylingCSSModules
+	}
+	return config, nil
+}
+
+// detectOption returns the option of g whose first package for framework is
+// installed, preferring options offered in the TUI. Without one it returns
+// the "none" option, or for styling plain CSS.
+func detectOption(g *models.OptionGroup, framework string, installed func(string) bool) string {
+	for _, includeHidden := range []bool{false, true} {
+		for _, o := range g.OptionsFor(framework, includeHidden) {
+			if o.Hidden != includeHidden {
+				continue
+			}
+			for _, p := range o.Packages {
+				if p.Frameworks == nil || containsFramework(p.Frameworks, framework) {
+					if installed(p.Name) {
+						return o.Value
+					}
+					break
+				}
+			}
+		}
+	}
+	return neutralOption(g, framework)
+}
+
+// neutralOption returns the option of g that adds nothing to a project: its
+// "none" option, or for styling plain CSS
+func neutralOption(g *models.OptionGroup, framework string) string {
+	if g.Key == "styling" {
+		return models.StylingVanilla
+	}
+	none, _ := g.Parse("none")
+	return g.Resolve(none, framework)
+}
+
+func containsFramework(frameworks []string, framework string) bool {
+	for _, f := range frameworks {
+		if f == framework {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,459 @@
+package generators
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Doctor check statuses
+const (
+	DoctorPass = "pass"
+	DoctorWarn = "warn" // Worth a look; doesn't fail the run
+	DoctorFail = "fail"
+	DoctorSkip = "skip" // Doesn't apply to the project
+)
+
+// DoctorCheck is the outcome of one check on an existing project
+type DoctorCheck struct {
+	ID      string   `json:"id"` // One of the Check* IDs
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Message string   `json:"message,omitempty"`
+	Details []string `json:"details,omitempty"` // One line per problem
+}
+
+// Diagnosis is the result of auditing an existing project
+type Diagnosis struct {
+	Config   models.Config `json:"config"`
+	Detected bool          `json:"detected"` // Config was detected from package.json rather than read from the manifest
+	Checks   []DoctorCheck `json:"checks"`
+}
+
+// Failed reports whether any check failed
+func (d *Diagnosis) Failed() bool {
+	for _, c := range d.Checks {
+		if c.Status == DoctorFail {
+			return true
+		}
+	}
+	return false
+}
+
+// Diagnose audits the project in projectPath against what frontforge would
+// generate for it. The options come from the manifest, or are detected as
+// for add (see DetectConfig). Unlike ValidateProject it runs on any project,
+// including meta-framework ones, and adds checks that only matter once a
+// project has lived a while: scripts, tsconfig references and types, ESLint
+// plugins that aren't installed, and drift from the manifest.
+func Diagnose(fsys vfs.FS, projectPath string) (*Diagnosis, error) {
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	d := &Diagnosis{}
+	m, err := manifest.Load(fsys, projectPath)
+	switch {
+	case err == nil:
+		d.Config = m.Config
+		d.Config.ProjectPath = projectPath
+	case errors.Is(err, manifest.ErrNotFound):
+		if d.Config, err = DetectConfig(fsys, projectPath); err != nil {
+			return nil, err
+		}
+		d.Detected = true
+	default:
+		return nil, err
+	}
+	var pkg PackageJSON
+	if data, err := fsys.ReadFile(filepath.Join(projectPath, "package.json")); err == nil {
+		_ = json.Unmarshal(data, &pkg)
+	}
+
+	// Meta-framework projects come from upstream CLIs; only package.json is ours to check
+	var results []ValidationResult
+	var core, entry DoctorCheck
+	var scripts []string
+	if models.IsMetaFramework(d.Config.Framework) {
+		results = validatePackageJSON(fsys, projectPath, d.Config)
+		core = metaCoreFiles(fsys, projectPath, d.Config.Framework)
+		entry = DoctorCheck{ID: CheckIndexHTML, Name: "index.html entry", Status: DoctorSkip,
+			Message: fmt.Sprintf("%s projects have no index.html", d.Config.Framework)}
+		scripts = []string{"dev", "build"}
+	} else {
+		results = ValidateFS(fsys, projectPath, d.Config)
+		core = fromResults(results, CheckCoreFiles, "Core files")
+		if vite := fromResults(results, CheckViteConfig, ""); vite.Status == DoctorFail {
+			core.Status = DoctorFail
+			core.Details = append(core.Details, vite.Details...)
+			core.Message = fmt.Sprintf("%d problem(s)", len(core.Details))
+		}
+		entry = fromResults(results, CheckIndexHTML, "index.html entry")
+		scripts = sortedKeys(keySet(GeneratePackageJSON(d.Config).Scripts))
+	}
+	d.Checks = append(d.Checks, core, fromResults(results, CheckPackageJSON, "package.json"), checkScripts(pkg, scripts), entry)
+	if !models.IsMetaFramework(d.Config.Framework) {
+		d.Checks = append(d.Checks, fromResults(results, CheckImports, "Imports"))
+	}
+	d.Checks = append(d.Checks,
+		checkTSConfig(fsys, projectPath, d.Config, pkg),
+		checkESLintPlugins(fsys, projectPath, pkg),
+		checkDrift(fsys, projectPath, m, pkg),
+	)
+	return d, nil
+}
+
+// fromResults folds the validation results with id into one check
+func fromResults(results []ValidationResult, id, name string) DoctorCheck {
+	c := DoctorCheck{ID: id, Name: name, Status: DoctorPass}
+	for _, r := range results {
+		if r.ID != id || r.Passed {
+			continue
+		}
+		c.Status = DoctorFail
+		c.Details = append(c.Details, fmt.Sprintf("%s: %s", r.Check, r.Message))
+	}
+	if len(c.Details) > 0 {
+		c.Message = fmt.Sprintf("%d problem(s)", len(c.Details))
+	}
+	return c
+}
+
+// metaConfigFiles are the config files each meta-framework needs, by base
+// name without extension
+var metaConfigFiles = map[string][]string{
+	models.FrameworkNextJS:    {"next.config"},
+	models.FrameworkAstro:     {"astro.config"},
+	models.FrameworkSvelteKit: {"svelte.config", "vite.config"},
+}
+
+// metaCoreFiles checks the files a meta-framework project can't run without
+func metaCoreFiles(fsys vfs.FS, projectPath, framework string) DoctorCheck {
+	c := DoctorCheck{ID: CheckCoreFiles, Name: "Core files", Status: DoctorPass}
+	for _, name := range metaConfigFiles[framework] {
+		found := false
+		for _, ext := range []string{"js", "mjs", "cjs", "ts", "mts"} {
+			if vfs.Exists(fsys, filepath.Join(projectPath, name+"."+ext)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.Status = DoctorFail
+			c.Details = append(c.Details, fmt.Sprintf("%s.* not found", name))
+		}
+	}
+	if len(c.Details) > 0 {
+		c.Message = fmt.Sprintf("%d problem(s)", len(c.Details))
+	}
+	return c
+}
+
+// checkScripts looks for the package.json scripts frontforge generates.
+// Missing ones are a warning; the caller fails the check without "dev".
+func checkScripts(pkg PackageJSON, expected []string) DoctorCheck {
+	c := DoctorCheck{ID: CheckScripts, Name: "package.json scripts", Status: DoctorPass}
+	for _, name := range expected {
+		if _, ok := pkg.Scripts[name]; !ok {
+			c.Details = append(c.Details, fmt.Sprintf("no '%s' script", name))
+		}
+	}
+	if len(c.Details) > 0 {
+		c.Status = DoctorWarn
+		c.Message = fmt.Sprintf("%d expected script(s) missing", len(c.Details))
+		if _, ok := pkg.Scripts["dev"]; !ok {
+			c.Status = DoctorFail
+		}
+	}
+	return c
+}
+
+// tsconfigTypes maps compilerOptions.types entries to the package that
+// provides them, where the name alone doesn't say
+var tsconfigTypes = map[string]string{
+	"node": "@types/node",
+	"jest": "@types/jest",
+}
+
+// checkTSConfig checks that the TypeScript configs parse, that the projects
+// the root config references exist, and that the packages named in
+// compilerOptions.types are declared
+func checkTSConfig(fsys vfs.FS, projectPath string, config models.Config, pkg PackageJSON) DoctorCheck {
+	c := DoctorCheck{ID: CheckTSConfig, Name: "TypeScript configs", Status: DoctorPass}
+	root := filepath.Join(projectPath, "tsconfig.json")
+	if !vfs.Exists(fsys, root) {
+		c.Status, c.Message = DoctorSkip, "no tsconfig.json"
+		if config.Language == models.LangTypeScript {
+			c.Status, c.Message = DoctorFail, "tsconfig.json not found"
+		}
+		return c
+	}
+
+	queue, seen := []string{"tsconfig.json"}, map[string]bool{}
+	for len(queue) > 0 {
+		rel := queue[0]
+		queue = queue[1:]
+		if seen[rel] {
+			continue
+		}
+		seen[rel] = true
+
+		var tsconfig struct {
+			References []struct {
+				Path string `json:"path"`
+			} `json:"references"`
+			CompilerOptions struct {
+				Types []string `json:"types"`
+			} `json:"compilerOptions"`
+		}
+		data, err := fsys.ReadFile(filepath.Join(projectPath, filepath.FromSlash(rel)))
+		if err != nil {
+			c.Details = append(c.Details, fmt.Sprintf("%s: %v", rel, err))
+			continue
+		}
+		if err := json.Unmarshal(stripJSONComments(data), &tsconfig); err != nil {
+			c.Details = append(c.Details, fmt.Sprintf("%s: invalid JSON: %v", rel, err))
+			continue
+		}
+		for _, ref := range tsconfig.References {
+			target := filepath.ToSlash(filepath.Join(filepath.Dir(rel), ref.Path))
+			if info, err := fsys.Stat(filepath.Join(projectPath, filepath.FromSlash(target))); err == nil && info.IsDir() {
+				target += "/tsconfig.json"
+			}
+			if !vfs.Exists(fsys, filepath.Join(projectPath, filepath.FromSlash(target))) {
+				c.Details = append(c.Details, fmt.Sprintf("%s references %s, which doesn't exist", rel, ref.Path))
+				continue
+			}
+			queue = append(queue, target)
+		}
+		for _, t := range tsconfig.CompilerOptions.Types {
+			name, ok := tsconfigTypes[t]
+			if !ok {
+				name = packageName(t)
+			}
+			if !pkg.declares(name) {
+				c.Details = append(c.Details, fmt.Sprintf("%s uses types from %s, which isn't in package.json", rel, name))
+			}
+		}
+	}
+	if len(c.Details) > 0 {
+		c.Status = DoctorFail
+		c.Message = fmt.Sprintf("%d problem(s)", len(c.Details))
+	}
+	return c
+}
+
+// eslintFlatConfigs and eslintLegacyConfigs are the config file names ESLint
+// looks for
+var (
+	eslintFlatConfigs   = []string{"eslint.config.js", "eslint.config.mjs", "eslint.config.cjs", "eslint.config.ts"}
+	eslintLegacyConfigs = []string{".eslintrc.json", ".eslintrc"}
+)
+
+// checkESLintPlugins checks that every package the ESLint config imports,
+// or names as a plugin, is declared in package.json, and installed when
+// node_modules exists
+func checkESLintPlugins(fsys vfs.FS, projectPath string, pkg PackageJSON) DoctorCheck {
+	c := DoctorCheck{ID: CheckESLintPlugins, Name: "ESLint plugins", Status: DoctorPass}
+	referenced := make(map[string]string) // Package -> config file
+	for _, name := range eslintFlatConfigs {
+		data, err := fsys.ReadFile(filepath.Join(projectPath, name))
+		if err != nil {
+			continue
+		}
+		for _, spec := range importSpecifiers(name, string(data)) {
+			if !strings.HasPrefix(spec, ".") && !strings.HasPrefix(spec, "/") && !strings.HasPrefix(spec, "node:") {
+				referenced[packageName(spec)] = name
+			}
+		}
+	}
+	for _, name := range eslintLegacyConfigs {
+		data, err := fsys.ReadFile(filepath.Join(projectPath, name))
+		if err != nil {
+			continue
+		}
+		var legacy struct {
+			Plugins []string    `json:"plugins"`
+			Extends interface{} `json:"extends"`
+		}
+		if json.Unmarshal(stripJSONComments(data), &legacy) != nil {
+			continue
+		}
+		for _, plugin := range legacy.Plugins {
+			referenced[eslintPluginPackage(plugin)] = name
+		}
+		extends, _ := legacy.Extends.([]interface{})
+		if s, ok := legacy.Extends.(string); ok {
+			extends = []interface{}{s}
+		}
+		for _, e := range extends {
+			if s, ok := e.(string); ok && strings.HasPrefix(s, "plugin:") {
+				referenced[eslintPluginPackage(strings.SplitN(strings.TrimPrefix(s, "plugin:"), "/", 2)[0])] = name
+			}
+		}
+	}
+	if len(referenced) == 0 {
+		c.Status, c.Message = DoctorSkip, "no ESLint config"
+		return c
+	}
+
+	nodeModules := filepath.Join(projectPath, "node_modules")
+	installed := vfs.Exists(fsys, nodeModules)
+	for _, name := range sortedKeys(keySet(referenced)) {
+		switch {
+		case !pkg.declares(name):
+			c.Status = DoctorFail
+			c.Details = append(c.Details, fmt.Sprintf("%s (%s) isn't in package.json", name, referenced[name]))
+		case installed && !vfs.Exists(fsys, filepath.Join(nodeModules, filepath.FromSlash(name))):
+			if c.Status == DoctorPass {
+				c.Status = DoctorWarn
+			}
+			c.Details = append(c.Details, fmt.Sprintf("%s is in package.json but not installed", name))
+		}
+	}
+	if len(c.Details) > 0 {
+		c.Message = fmt.Sprintf("%d package(s) referenced but not installed", len(c.Details))
+	}
+	return c
+}
+
+// eslintPluginPackage returns the package of a legacy ESLint plugin name:
+// "react" is eslint-plugin-react, "@scope" is @scope/eslint-plugin and
+// "@scope/name" is @scope/eslint-plugin-name
+func eslintPluginPackage(plugin string) string {
+	if strings.HasPrefix(plugin, "eslint-plugin-") || strings.Contains(plugin, "/eslint-plugin") {
+		return plugin
+	}
+	if strings.HasPrefix(plugin, "@") {
+		scope, name, found := strings.Cut(plugin, "/")
+		if !found {
+			return scope + "/eslint-plugin"
+		}
+		return scope + "/eslint-plugin-" + name
+	}
+	return "eslint-plugin-" + plugin
+}
+
+// checkDrift compares the project with its manifest: generated files that
+// were edited or deleted, and dependencies whose range changed. Drift is
+// normal for a project in use, so it's only a warning.
+func checkDrift(fsys vfs.FS, projectPath string, m *manifest.Manifest, pkg PackageJSON) DoctorCheck {
+	c := DoctorCheck{ID: CheckManifestDrift, Name: "Manifest drift", Status: DoctorPass}
+	if m == nil {
+		c.Status, c.Message = DoctorSkip, "no .frontforge/manifest.json"
+		return c
+	}
+
+	var edited, deleted int
+	for _, rel := range sortedKeys(keySet(m.Files)) {
+		data, err := fsys.ReadFile(filepath.Join(projectPath, filepath.FromSlash(rel)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			deleted++
+			c.Details = append(c.Details, fmt.Sprintf("deleted %s", rel))
+		case err != nil:
+			c.Details = append(c.Details, fmt.Sprintf("%s: %v", rel, err))
+		case !m.Owned(rel, data):
+			edited++
+			c.Details = append(c.Details, fmt.Sprintf("edited %s", rel))
+		}
+	}
+	dependencies := 0
+	for _, section := range []struct {
+		recorded, current map[string]string
+	}{
+		{m.Dependencies, pkg.Dependencies},
+		{m.DevDependencies, pkg.DevDependencies},
+	} {
+		for _, name := range sortedKeys(keySet(section.recorded)) {
+			current, ok := section.current[name]
+			switch {
+			case !ok:
+				dependencies++
+				c.Details = append(c.Details, fmt.Sprintf("removed %s", name))
+			case current != section.recorded[name]:
+				dependencies++
+				c.Details = append(c.Details, fmt.Sprintf("changed %s %s -> %s", name, section.recorded[name], current))
+			}
+		}
+	}
+	if len(c.Details) > 0 {
+		c.Status = DoctorWarn
+		c.Message = fmt.Sprintf("%d file(s) edited, %d deleted and %d dependency change(s) since frontforge %s generated the project", edited, deleted, dependencies, m.Tool)
+	}
+	return c
+}
+
+// declares reports whether package.json lists name in any dependency section
+func (p PackageJSON) declares(name string) bool {
+	_, dep := p.Dependencies[name]
+	_, devDep := p.DevDependencies[name]
+	return dep || devDep
+}
+
+// packageName returns the package of a bare module specifier, such as
+// "vitest" for "vitest/globals" or "@scope/pkg" for "@scope/pkg/sub"
+func packageName(spec string) string {
+	parts := strings.SplitN(spec, "/", 3)
+	if strings.HasPrefix(spec, "@") && len(parts) > 1 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
+// stripJSONComments blanks out // and /* */ comments outside strings, as
+// tsconfig and .eslintrc allow, and drops trailing commas
+func stripJSONComments(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		b := data[i]
+		switch {
+		case inString:
+			out = append(out, b)
+			if b == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if b == '"' {
+				inString = false
+			}
+		case b == '"':
+			inString = true
+			out = append(out, b)
+		case b == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case b == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+		default:
+			out = append(out, b)
+		}
+	}
+	return trailingComma.ReplaceAll(out, []byte("$1"))
+}
+
+// trailingComma matches a comma before a closing bracket or brace
+var trailingComma = regexp.MustCompile(`,(\s*[}\]])`)
+
+func keySet(m map[string]string) map[string]bool {
+	set := make(map[string]bool, len(m))
+	for key := range m {
+		set[key] = true
+	}
+	return set
+}
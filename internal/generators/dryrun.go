@@ -1,18 +1,39 @@
 package generators
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/textdiff"
+	"frontforge/internal/vfs"
+	"io"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 )
 
+// Statuses of dry-run entries compared with the target directory
+const (
+	DryRunNew       = "new"       // Not on disk yet
+	DryRunModified  = "modified"  // On disk with different content
+	DryRunIdentical = "identical" // On disk with the same content
+	DryRunUntouched = "untouched" // On disk but not generated; left alone
+)
+
 // DryRunEntry represents a file or directory that would be created
 type DryRunEntry struct {
 	Path    string // Relative path from project root
 	IsDir   bool
 	Size    int // Content size in bytes
 	Content string
+	Status  string // One of the DryRun* statuses; empty until Compare
+
+	existing string // Content on disk, for modified files
 }
 
 // DryRunManifest collects all files/dirs that would be generated
@@ -31,6 +52,49 @@ func NewDryRunManifest(projectPath, projectName string) *DryRunManifest {
 	}
 }
 
+// Plan generates a Vite-based project in memory and returns what would be
+// written, without reading or writing the disk. Meta-frameworks can't be
+// planned since their files come from the upstream CLI. Generation stops
+// when ctx is done.
+func Plan(ctx context.Context, config models.Config, out io.Writer) (*DryRunManifest, error) {
+	if models.IsMetaFramework(config.Framework) {
+		return nil, fmt.Errorf("%s projects are created by the upstream CLI and can't be previewed", config.Framework)
+	}
+	config.DryRun = true
+	projectPath, err := resolveProjectPath(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Everything above the project exists, as it would on disk
+	mem := vfs.NewMemory()
+	if err := mem.MkdirAll(filepath.Dir(projectPath)); err != nil {
+		return nil, err
+	}
+	dryRun := vfs.NewDryRun(mem)
+	if _, err := generateFS(ctx, dryRun, config, out); err != nil {
+		return nil, err
+	}
+	return newDryRunManifest(dryRun, projectPath, config.ProjectName), nil
+}
+
+// newDryRunManifest lists what a dry run would write, leaving out the base
+// copies since they mirror the files already listed
+func newDryRunManifest(dryRun *vfs.DryRun, projectPath, projectName string) *DryRunManifest {
+	m := NewDryRunManifest(projectPath, projectName)
+	for _, entry := range dryRun.Entries() {
+		if entry.Path == manifest.BasePath(projectPath) {
+			continue
+		}
+		if entry.IsDir {
+			m.AddDir(entry.Path)
+		} else {
+			m.AddFile(entry.Path, string(entry.Data))
+		}
+	}
+	return m
+}
+
 // AddFile records a file that would be created
 func (m *DryRunManifest) AddFile(path, content string) {
 	relPath, _ := filepath.Rel(m.ProjectPath, path)
@@ -54,15 +118,20 @@ func (m *DryRunManifest) AddDir(path string) {
 	})
 }
 
-// Print outputs the manifest as a tree
+// Print outputs the manifest as a tree to stdout
 func (m *DryRunManifest) Print() {
-	fmt.Println()
-	fmt.Println("Dry run - files that would be generated:")
-	fmt.Println()
+	m.Fprint(os.Stdout)
+}
+
+// Fprint outputs the manifest as a tree to w
+func (m *DryRunManifest) Fprint(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Dry run - files that would be generated:")
+	fmt.Fprintln(w)
 
 	// Build tree structure
 	tree := m.buildTree()
-	m.printTree(tree, "", true, m.ProjectName)
+	m.printTree(w, tree, "", true, m.ProjectName)
 
 	// Count files
 	fileCount := 0
@@ -72,9 +141,172 @@ func (m *DryRunManifest) Print() {
 		}
 	}
 
-	fmt.Println()
-	fmt.Printf("%d files would be created\n", fileCount)
-	fmt.Println()
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%d files would be created\n", fileCount)
+	fmt.Fprintln(w)
+}
+
+// Compare sets the status of each entry against the files under the project
+// path in fsys, and adds the files there that generation would leave alone.
+// frontforge's state directory is left out: its manifest records when it was
+// generated, so it would always differ.
+func (m *DryRunManifest) Compare(fsys vfs.FS) {
+	generated := make(map[string]bool)
+	entries := m.Entries[:0]
+	for _, e := range m.Entries {
+		rel := filepath.ToSlash(e.Path)
+		if rel == vfs.StateDir || strings.HasPrefix(rel, vfs.StateDir+"/") {
+			continue
+		}
+		generated[rel] = true
+
+		path := filepath.Join(m.ProjectPath, e.Path)
+		if e.IsDir {
+			e.Status = DryRunNew
+			if info, err := fsys.Stat(path); err == nil && info.IsDir() {
+				e.Status = DryRunIdentical
+			}
+		} else if existing, err := fsys.ReadFile(path); err != nil {
+			e.Status = DryRunNew
+		} else if string(existing) == e.Content {
+			e.Status = DryRunIdentical
+		} else {
+			e.Status, e.existing = DryRunModified, string(existing)
+		}
+		entries = append(entries, e)
+	}
+	m.Entries = entries
+
+	dir, onDisk := vfs.HostPath(fsys, m.ProjectPath)
+	if !onDisk {
+		return
+	}
+	for _, rel := range listFiles(dir) {
+		if generated[rel] {
+			continue
+		}
+		entry := DryRunEntry{Path: filepath.FromSlash(rel), Status: DryRunUntouched}
+		if info, err := fsys.Stat(filepath.Join(m.ProjectPath, entry.Path)); err == nil {
+			entry.Size = int(info.Size())
+		}
+		m.Entries = append(m.Entries, entry)
+	}
+}
+
+// Changed reports whether committing would create or modify anything. Call
+// Compare first.
+func (m *DryRunManifest) Changed() bool {
+	for _, e := range m.Entries {
+		if e.Status == DryRunNew || e.Status == DryRunModified {
+			return true
+		}
+	}
+	return false
+}
+
+// FprintDiff lists each file's status, then prints unified diffs of the new
+// and modified ones. Call Compare first.
+func (m *DryRunManifest) FprintDiff(w io.Writer) {
+	var files []DryRunEntry
+	counts := make(map[string]int)
+	for _, e := range m.Entries {
+		if !e.IsDir {
+			files = append(files, e)
+			counts[e.Status]++
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Dry run - changes in %s:\n", m.ProjectPath)
+	fmt.Fprintln(w)
+	for _, e := range files {
+		fmt.Fprintf(w, "  %-10s %s\n", e.Status, filepath.ToSlash(e.Path))
+	}
+
+	for _, e := range files {
+		rel := filepath.ToSlash(e.Path)
+		switch e.Status {
+		case DryRunNew:
+			fmt.Fprintln(w)
+			fmt.Fprint(w, textdiff.Unified("/dev/null", "b/"+rel, "", e.Content, 3))
+		case DryRunModified:
+			fmt.Fprintln(w)
+			fmt.Fprint(w, textdiff.Unified("a/"+rel, "b/"+rel, e.existing, e.Content, 3))
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%d new, %d modified, %d identical, %d untouched\n",
+		counts[DryRunNew], counts[DryRunModified], counts[DryRunIdentical], counts[DryRunUntouched])
+	fmt.Fprintln(w)
+}
+
+// DryRunFile is one entry of a -dry-run=json document
+type DryRunFile struct {
+	Path    string  `json:"path"` // Slash-separated, relative to the project root
+	Type    string  `json:"type"` // "file" or "dir"
+	Size    int     `json:"size"`
+	SHA256  string  `json:"sha256,omitempty"`  // Hex digest of the content; files only
+	Content *string `json:"content,omitempty"` // Only when requested
+}
+
+// dryRunDocument is the document printed by -dry-run=json
+type dryRunDocument struct {
+	ProjectName string       `json:"projectName"`
+	ProjectPath string       `json:"projectPath"`
+	Entries     []DryRunFile `json:"entries"`
+}
+
+// Files returns the entries sorted by path, with SHA-256 digests and, if
+// withContent is set, the file contents
+func (m *DryRunManifest) Files(withContent bool) []DryRunFile {
+	files := make([]DryRunFile, 0, len(m.Entries))
+	for _, e := range m.Entries {
+		f := DryRunFile{Path: filepath.ToSlash(e.Path), Type: "dir"}
+		if !e.IsDir {
+			sum := sha256.Sum256([]byte(e.Content))
+			f.Type, f.Size, f.SHA256 = "file", e.Size, hex.EncodeToString(sum[:])
+			if withContent {
+				content := e.Content
+				f.Content = &content
+			}
+		}
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// FprintJSON writes the manifest to w as one indented JSON document
+func (m *DryRunManifest) FprintJSON(w io.Writer, withContent bool) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(dryRunDocument{
+		ProjectName: m.ProjectName,
+		ProjectPath: m.ProjectPath,
+		Entries:     m.Files(withContent),
+	})
+}
+
+// FprintFiles writes every file to w in path order, each under a
+// "==> path <==" header
+func (m *DryRunManifest) FprintFiles(w io.Writer) {
+	first := true
+	for _, f := range m.Files(true) {
+		if f.Type != "file" {
+			continue
+		}
+		if !first {
+			fmt.Fprintln(w)
+		}
+		first = false
+		fmt.Fprintf(w, "==> %s <==\n", f.Path)
+		fmt.Fprint(w, *f.Content)
+		if *f.Content != "" && !strings.HasSuffix(*f.Content, "\n") {
+			fmt.Fprintln(w)
+		}
+	}
 }
 
 type treeNode struct {
@@ -114,7 +346,7 @@ func (m *DryRunManifest) buildTree() *treeNode {
 	return root
 }
 
-func (m *DryRunManifest) printTree(node *treeNode, prefix string, isLast bool, name string) {
+func (m *DryRunManifest) printTree(w io.Writer, node *treeNode, prefix string, isLast bool, name string) {
 	if name != "" {
 		connector := "├──"
 		if isLast {
@@ -126,7 +358,7 @@ func (m *DryRunManifest) printTree(node *treeNode, prefix string, isLast bool, n
 			suffix = "/"
 		}
 
-		fmt.Printf("  %s%s %s%s\n", prefix, connector, name, suffix)
+		fmt.Fprintf(w, "  %s%s %s%s\n", prefix, connector, name, suffix)
 
 		if isLast {
 			prefix += "    "
@@ -151,6 +383,6 @@ func (m *DryRunManifest) printTree(node *treeNode, prefix string, isLast bool, n
 
 	for i, childName := range children {
 		child := node.children[childName]
-		m.printTree(child, prefix, i == len(children)-1, childName)
+		m.printTree(w, child, prefix, i == len(children)-1, childName)
 	}
 }
@@ -0,0 +1,243 @@
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"frontforge/internal/manifest"
+	"frontforge/internal/vfs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// importSpecifier matches the module of an import, export ... from, dynamic
+// import or require
+var importSpecifier = regexp.MustCompile(`(?:\bfrom\s*|\bimport\s*\(?\s*|\brequire\s*\(\s*)['"]([^'"\n]+)['"]`)
+
+// cssImport matches the module of a CSS @import
+var cssImport = regexp.MustCompile(`@import\s+(?:url\(\s*)?['"]([^'"\n]+)['"]`)
+
+// scriptExtensions are the files whose imports are checked, and the
+// extensions tried when resolving a relative import without one
+var scriptExtensions = []string{".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs", ".mts", ".cts", ".vue", ".svelte"}
+
+// nodeBuiltins are the Node.js modules config files may import without a
+// dependency
+var nodeBuiltins = map[string]bool{
+	"assert": true, "buffer": true, "child_process": true, "crypto": true, "events": true,
+	"fs": true, "http": true, "https": true, "module": true, "os": true, "path": true,
+	"process": true, "stream": true, "url": true, "util": true, "zlib": true,
+}
+
+// validateImports parses the import and require specifiers of every
+// generated source and config file (the files in the manifest, or on disk
+// without one). Bare specifiers must name a package.json dependency and
+// relative ones must resolve to a file.
+func validateImports(fsys vfs.FS, projectPath string) []ValidationResult {
+	files := sourceFiles(fsys, projectPath)
+	if len(files) == 0 {
+		return nil
+	}
+	var pkg PackageJSON
+	if data, err := fsys.ReadFile(filepath.Join(projectPath, "package.json")); err == nil {
+		_ = json.Unmarshal(data, &pkg)
+	}
+	srcAlias := hasSrcAlias(fsys, projectPath)
+
+	var results []ValidationResult
+	for _, rel := range files {
+		data, err := fsys.ReadFile(filepath.Join(projectPath, filepath.FromSlash(rel)))
+		if err != nil {
+			continue
+		}
+		var problems []string
+		for _, spec := range importSpecifiers(rel, string(data)) {
+			if problem := checkImport(fsys, projectPath, rel, spec, pkg, srcAlias); problem != "" {
+				problems = append(problems, problem)
+			}
+		}
+		if len(problems) > 0 {
+			results = append(results, ValidationResult{
+				ID:      CheckImports,
+				Check:   fmt.Sprintf("Imports: %s", rel),
+				Passed:  false,
+				Message: strings.Join(problems, "; "),
+			})
+		}
+	}
+	if len(results) == 0 {
+		results = append(results, ValidationResult{
+			ID:     CheckImports,
+			Check:  "Imports resolve",
+			Passed: true,
+		})
+	}
+	return results
+}
+
+// sourceFiles lists the files whose imports are checked, relative to
+// projectPath and sorted
+func sourceFiles(fsys vfs.FS, projectPath string) []string {
+	var all []string
+	if m, err := manifest.Load(fsys, projectPath); err == nil {
+		for rel := range m.Files {
+			all = append(all, rel)
+		}
+	} else if dir, onDisk := vfs.HostPath(fsys, projectPath); onDisk {
+		all = listFiles(dir)
+	}
+
+	var files []string
+	for _, rel := range all {
+		ext := path.Ext(rel)
+		if ext == ".css" || containsString(scriptExtensions, ext) {
+			files = append(files, rel)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// importSpecifiers returns the modules a source file imports, in order.
+// Comments are skipped; CSS files contribute their @import rules.
+func importSpecifiers(name, source string) []string {
+	re := importSpecifier
+	if path.Ext(name) == ".css" {
+		re = cssImport
+	}
+	var specs []string
+	for _, match := range re.FindAllStringSubmatch(stripComments(source), -1) {
+		specs = append(specs, match[1])
+	}
+	return specs
+}
+
+// checkImport describes what's wrong with one import of the file rel, or
+// returns ""
+func checkImport(fsys vfs.FS, projectPath, rel, spec string, pkg PackageJSON, srcAlias bool) string {
+	spec, _, _ = strings.Cut(spec, "?") // Vite query suffixes such as ?url
+	switch {
+	case strings.HasPrefix(spec, "node:"), strings.HasPrefix(spec, "virtual:"),
+		strings.HasPrefix(spec, "http:"), strings.HasPrefix(spec, "https:"):
+		return ""
+	case strings.HasPrefix(spec, "."):
+		if resolveImport(fsys, projectPath, path.Join(path.Dir(rel), spec)) {
+			return ""
+		}
+		return fmt.Sprintf("%s does not resolve to a file", spec)
+	case strings.HasPrefix(spec, "/"):
+		if resolveImport(fsys, projectPath, strings.TrimPrefix(spec, "/")) {
+			return ""
+		}
+		return fmt.Sprintf("%s does not resolve to a file", spec)
+	case strings.HasPrefix(spec, "@/"):
+		if !srcAlias {
+			return fmt.Sprintf("%s uses the @ alias, which vite.config doesn't define", spec)
+		}
+		if resolveImport(fsys, projectPath, "src/"+strings.TrimPrefix(spec, "@/")) {
+			return ""
+		}
+		return fmt.Sprintf("%s does not resolve to a file", spec)
+	}
+	name := packageName(spec)
+	if nodeBuiltins[name] || pkg.declares(name) {
+		return ""
+	}
+	return fmt.Sprintf("'%s' is not in package.json", name)
+}
+
+// resolveImport reports whether a slash-separated module path relative to
+// the project resolves to a file, as Vite and TypeScript would resolve it:
+// as is, with a script extension, as a directory index, or with .js
+// standing for .ts
+func resolveImport(fsys vfs.FS, projectPath, rel string) bool {
+	isFile := func(rel string) bool {
+		info, err := fsys.Stat(filepath.Join(projectPath, filepath.FromSlash(rel)))
+		return err == nil && !info.IsDir()
+	}
+	if isFile(rel) {
+		return true
+	}
+	for _, ext := range scriptExtensions {
+		if isFile(rel+ext) || isFile(rel+"/index"+ext) {
+			return true
+		}
+	}
+	for js, ts := range map[string][]string{".js": {".ts", ".tsx"}, ".jsx": {".tsx"}, ".mjs": {".mts"}} {
+		if base, ok := strings.CutSuffix(rel, js); ok {
+			for _, ext := range ts {
+				if isFile(base + ext) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// hasSrcAlias reports whether the Vite config maps "@" to src
+func hasSrcAlias(fsys vfs.FS, projectPath string) bool {
+	for _, name := range []string{"vite.config.ts", "vite.config.js"} {
+		data, err := fsys.ReadFile(filepath.Join(projectPath, name))
+		if err == nil && (strings.Contains(string(data), "'@'") || strings.Contains(string(data), `"@"`)) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripComments blanks out // and /* */ comments (and HTML comments, for
+// Vue and Svelte components) outside string literals
+func stripComments(source string) string {
+	var b strings.Builder
+	b.Grow(len(source))
+	var quote byte // Open string delimiter, or 0
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+		switch {
+		case quote != 0:
+			b.WriteByte(c)
+			if c == '\\' && i+1 < len(source) {
+				i++
+				b.WriteByte(source[i])
+			} else if c == quote || (c == '\n' && quote != '`') {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			b.WriteByte(c)
+		case strings.HasPrefix(source[i:], "//"):
+			end := strings.IndexByte(source[i:], '\n')
+			if end < 0 {
+				return b.String()
+			}
+			i += end - 1
+		case strings.HasPrefix(source[i:], "/*"):
+			end := strings.Index(source[i+2:], "*/")
+			if end < 0 {
+				return b.String()
+			}
+			i += end + 3
+		case strings.HasPrefix(source[i:], "<!--"):
+			end := strings.Index(source[i+4:], "-->")
+			if end < 0 {
+				return b.String()
+			}
+			i += end + 6
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -5,11 +5,19 @@ import (
 	"fmt"
 	"frontforge/internal/models"
 	"io"
+	"os"
 	"os/exec"
 )
 
-// RunInstall executes the package manager install command in the project directory
+// RunInstall executes the package manager install command in the project
+// directory, streaming its output to stdout
 func RunInstall(projectPath string, config models.Config) error {
+	return RunInstallTo(projectPath, config, os.Stdout)
+}
+
+// RunInstallTo executes the package manager install command in the project
+// directory, streaming its output to out
+func RunInstallTo(projectPath string, config models.Config, out io.Writer) error {
 	var cmd *exec.Cmd
 
 	// Determine the install command based on package manager
@@ -45,8 +53,8 @@ func RunInstall(projectPath string, config models.Config) error {
 	}
 
 	// Stream output in real-time
-	go streamOutput(stdout)
-	go streamOutput(stderr)
+	go streamOutput(stdout, out)
+	go streamOutput(stderr, out)
 
 	// Wait for completion
 	if err := cmd.Wait(); err != nil {
@@ -56,10 +64,10 @@ func RunInstall(projectPath string, config models.Config) error {
 	return nil
 }
 
-// streamOutput reads from a pipe and prints to stdout line by line
-func streamOutput(pipe io.ReadCloser) {
+// streamOutput reads from a pipe and prints to out line by line
+func streamOutput(pipe io.ReadCloser, out io.Writer) {
 	scanner := bufio.NewScanner(pipe)
 	for scanner.Scan() {
-		fmt.Println(scanner.Text())
+		fmt.Fprintln(out, scanner.Text())
 	}
 }
@@ -4,22 +4,25 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 	"time"
 )
 
 // ExecScaffold runs an external command with a timeout, capturing output.
+// The command is killed when ctx is done, and ctx's error is returned.
 // If dryRun is true, returns the command that would have been executed without running it.
-func ExecScaffold(framework string, dryRun bool, name string, args ...string) error {
+func ExecScaffold(ctx context.Context, framework string, dryRun bool, name string, args ...string) error {
 	cmdStr := fmt.Sprintf("%s %s", name, strings.Join(args, " "))
 
 	if dryRun {
-		fmt.Printf("[meta-scaffold] Would run: %s\n", cmdStr)
+		fmt.Fprintf(os.Stderr, "[meta-scaffold] Would run: %s\n", cmdStr)
 		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, 120*time.Second)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, name, args...)
@@ -29,6 +32,9 @@ func ExecScaffold(framework string, dryRun bool, name string, args ...string) er
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
+		if ctxErr := parent.Err(); ctxErr != nil {
+			return fmt.Errorf("%s: %w", cmdStr, ctxErr)
+		}
 		exitCode := -1
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			exitCode = exitErr.ExitCode()
@@ -52,16 +58,18 @@ func ExecScaffold(framework string, dryRun bool, name string, args ...string) er
 	return nil
 }
 
-// ExecInDir runs a command in a specific directory with timeout.
-func ExecInDir(dir, framework string, dryRun bool, name string, args ...string) error {
+// ExecInDir runs a command in a specific directory with timeout, like
+// ExecScaffold.
+func ExecInDir(ctx context.Context, dir, framework string, dryRun bool, name string, args ...string) error {
 	cmdStr := fmt.Sprintf("%s %s", name, strings.Join(args, " "))
 
 	if dryRun {
-		fmt.Printf("[meta-scaffold] Would run: %s (in %s)\n", cmdStr, dir)
+		fmt.Fprintf(os.Stderr, "[meta-scaffold] Would run: %s (in %s)\n", cmdStr, dir)
 		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, 120*time.Second)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, name, args...)
@@ -72,6 +80,9 @@ func ExecInDir(dir, framework string, dryRun bool, name string, args ...string)
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
+		if ctxErr := parent.Err(); ctxErr != nil {
+			return fmt.Errorf("%s: %w", cmdStr, ctxErr)
+		}
 		exitCode := -1
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			exitCode = exitErr.ExitCode()
@@ -1,6 +1,10 @@
 package meta
 
-import "frontforge/internal/models"
+import (
+	"context"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+)
 
 // OptionMatrix describes which options a meta-framework supports.
 // nil fields are hidden entirely in the TUI.
@@ -13,11 +17,13 @@ type OptionMatrix struct {
 
 // MetaGenerator defines the interface for meta-framework generators.
 type MetaGenerator interface {
-	// Scaffold runs the upstream CLI non-interactively.
-	Scaffold(cfg models.Config) error
+	// Scaffold runs the upstream CLI non-interactively. Cancelling ctx
+	// kills it.
+	Scaffold(ctx context.Context, cfg models.Config) error
 
-	// PostScaffold applies FrontForge additions (testing, state, etc.).
-	PostScaffold(cfg models.Config) error
+	// PostScaffold applies FrontForge additions (testing, state, etc.),
+	// writing through fsys. Commands it runs stop when ctx is done.
+	PostScaffold(ctx context.Context, fsys vfs.FS, cfg models.Config) error
 
 	// SupportedOptions returns what TUI should show for this framework.
 	SupportedOptions() OptionMatrix
@@ -43,7 +49,7 @@ func Get(framework string) (MetaGenerator, bool) {
 
 // RunMetaScaffold is the high-level entry point called from SetupProject.
 // It runs Scaffold + PostScaffold for a meta-framework config.
-func RunMetaScaffold(cfg models.Config) error {
+func RunMetaScaffold(ctx context.Context, fsys vfs.FS, cfg models.Config) error {
 	gen, ok := Get(cfg.Framework)
 	if !ok {
 		return &ScaffoldError{
@@ -55,16 +61,19 @@ func RunMetaScaffold(cfg models.Config) error {
 	}
 
 	if !cfg.NoScaffold {
-		if err := gen.Scaffold(cfg); err != nil {
+		// The upstream CLI writes to disk itself, wherever fsys keeps the project
+		scaffoldCfg := cfg
+		scaffoldCfg.ProjectPath, _ = vfs.HostPath(fsys, cfg.ProjectPath)
+		if err := gen.Scaffold(ctx, scaffoldCfg); err != nil {
 			return err
 		}
 	}
 
-	// In dry-run mode, scaffold is skipped so there's no project directory
-	// to apply post-scaffold transforms to. Just report what would happen.
-	if cfg.DryRun {
+	// In dry-run mode the upstream CLI doesn't run, so there's no project
+	// to apply post-scaffold transforms to unless it already exists
+	if cfg.DryRun && !cfg.NoScaffold {
 		return nil
 	}
 
-	return gen.PostScaffold(cfg)
+	return gen.PostScaffold(ctx, fsys, cfg)
 }
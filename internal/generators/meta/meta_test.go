@@ -1,7 +1,9 @@
 package meta
 
 import (
+	"context"
 	"frontforge/internal/models"
+	"frontforge/internal/vfs"
 	"strings"
 	"testing"
 )
@@ -16,12 +18,12 @@ type stubGenerator struct {
 	postCalled      bool
 }
 
-func (s *stubGenerator) Scaffold(cfg models.Config) error {
+func (s *stubGenerator) Scaffold(ctx context.Context, cfg models.Config) error {
 	s.scaffoldCalled = true
 	return s.scaffoldErr
 }
 
-func (s *stubGenerator) PostScaffold(cfg models.Config) error {
+func (s *stubGenerator) PostScaffold(ctx context.Context, fsys vfs.FS, cfg models.Config) error {
 	s.postCalled = true
 	return s.postScaffoldErr
 }
@@ -107,7 +109,7 @@ func TestRegisterOverwrite(t *testing.T) {
 
 func TestRunMetaScaffold_UnregisteredFramework(t *testing.T) {
 	cfg := models.Config{Framework: "NoSuchFramework"}
-	err := RunMetaScaffold(cfg)
+	err := RunMetaScaffold(context.Background(), vfs.NewMemory(), cfg)
 	if err == nil {
 		t.Fatal("expected error for unregistered framework")
 	}
@@ -132,7 +134,7 @@ func TestRunMetaScaffold_CallsScaffoldAndPostScaffold(t *testing.T) {
 	Register("stub-fw", stub)
 
 	cfg := models.Config{Framework: "stub-fw"}
-	err := RunMetaScaffold(cfg)
+	err := RunMetaScaffold(context.Background(), vfs.NewMemory(), cfg)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -152,7 +154,7 @@ func TestRunMetaScaffold_NoScaffoldFlag(t *testing.T) {
 	Register("stub-fw", stub)
 
 	cfg := models.Config{Framework: "stub-fw", NoScaffold: true}
-	err := RunMetaScaffold(cfg)
+	err := RunMetaScaffold(context.Background(), vfs.NewMemory(), cfg)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -177,7 +179,7 @@ func TestRunMetaScaffold_ScaffoldError(t *testing.T) {
 	Register("stub-fw", stub)
 
 	cfg := models.Config{Framework: "stub-fw"}
-	err := RunMetaScaffold(cfg)
+	err := RunMetaScaffold(context.Background(), vfs.NewMemory(), cfg)
 	if err == nil {
 		t.Fatal("expected error when Scaffold fails")
 	}
@@ -202,7 +204,7 @@ func TestRunMetaScaffold_PostScaffoldError(t *testing.T) {
 	Register("stub-fw", stub)
 
 	cfg := models.Config{Framework: "stub-fw"}
-	err := RunMetaScaffold(cfg)
+	err := RunMetaScaffold(context.Background(), vfs.NewMemory(), cfg)
 	if err == nil {
 		t.Fatal("expected error when PostScaffold fails")
 	}
@@ -1,9 +1,12 @@
 package nextjs
 
 import (
+	"context"
 	"frontforge/internal/generators/meta"
 	"frontforge/internal/generators/shared"
 	"frontforge/internal/models"
+	"frontforge/internal/versions"
+	"frontforge/internal/vfs"
 )
 
 func init() {
@@ -13,12 +16,12 @@ func init() {
 // Generator implements meta.MetaGenerator for Next.js.
 type Generator struct{}
 
-func (g *Generator) Scaffold(cfg models.Config) error {
+func (g *Generator) Scaffold(ctx context.Context, cfg models.Config) error {
 	args := buildScaffoldArgs(cfg)
-	return meta.ExecScaffold(models.FrameworkNextJS, cfg.DryRun, "npx", args...)
+	return meta.ExecScaffold(ctx, models.FrameworkNextJS, cfg.DryRun, "npx", args...)
 }
 
-func (g *Generator) PostScaffold(cfg models.Config) error {
+func (g *Generator) PostScaffold(ctx context.Context, fsys vfs.FS, cfg models.Config) error {
 	dir := cfg.ProjectPath
 
 	// Merge FrontForge-specific deps
@@ -27,50 +30,39 @@ func (g *Generator) PostScaffold(cfg models.Config) error {
 	scripts := make(map[string]string)
 
 	// ESLint (FrontForge standard)
-	devDeps["eslint"] = "^9.39.1"
-	devDeps["@eslint/js"] = "^9.39.1"
-	devDeps["globals"] = "^15.15.0"
-	devDeps["typescript-eslint"] = "^8.56.1"
-	devDeps["eslint-plugin-react-hooks"] = "^7.0.1"
-	devDeps["eslint-plugin-react-refresh"] = "^0.5.2"
+	devDeps["eslint"] = versions.Version("eslint")
+	devDeps["@eslint/js"] = versions.Version("@eslint/js")
+	devDeps["globals"] = versions.Version("globals")
+	devDeps["typescript-eslint"] = versions.Version("typescript-eslint")
+	devDeps["eslint-plugin-react-hooks"] = versions.Version("eslint-plugin-react-hooks")
+	devDeps["eslint-plugin-react-refresh"] = versions.Version("eslint-plugin-react-refresh")
 	scripts["lint"] = "eslint ."
 
-	// State management
-	switch cfg.StateManagement {
-	case models.StateZustand:
-		deps["zustand"] = "^5.0.11"
-	case models.StateReduxToolkit:
-		deps["@reduxjs/toolkit"] = "^2.11.2"
-		deps["react-redux"] = "^9.2.0"
-	}
-
-	// Data fetching
-	switch cfg.DataFetching {
-	case models.DataTanStackQuery:
-		deps["@tanstack/react-query"] = "^5.90.21"
-		devDeps["@tanstack/react-query-devtools"] = "^5.91.3"
-	case models.DataAxios:
-		deps["axios"] = "^1.13.5"
-	case models.DataSWR:
-		deps["swr"] = "^2.4.0"
+	// State management and data fetching libraries come from the option registry
+	for _, p := range models.SelectedPackages(cfg, "stateManagement", "dataFetching") {
+		if p.Dev {
+			devDeps[p.Name] = p.Version()
+		} else {
+			deps[p.Name] = p.Version()
+		}
 	}
 
 	if len(deps) > 0 || len(devDeps) > 0 || len(scripts) > 0 {
-		if err := shared.MergePackageJSON(dir, deps, devDeps, scripts); err != nil {
+		if err := shared.MergePackageJSON(fsys, dir, deps, devDeps, scripts); err != nil {
 			return err
 		}
 	}
 
 	// Testing
 	if cfg.Testing == models.TestingVitest {
-		if err := shared.ScaffoldVitest(dir, "nextjs"); err != nil {
+		if err := shared.ScaffoldVitest(fsys, dir, "nextjs"); err != nil {
 			return err
 		}
 	}
 
 	// Feature-based structure
 	if cfg.Structure == models.StructureFeatureBased {
-		if err := shared.ScaffoldFeatureStructure(dir, "nextjs"); err != nil {
+		if err := shared.ScaffoldFeatureStructure(fsys, dir, "nextjs"); err != nil {
 			return err
 		}
 	}
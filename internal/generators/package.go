@@ -1,6 +1,9 @@
 package generators
 
-import "frontforge/internal/models"
+import (
+	"frontforge/internal/models"
+	"frontforge/internal/versions"
+)
 
 // PackageJSON represents the structure of package.json
 type PackageJSON struct {
@@ -33,266 +36,41 @@ func GeneratePackageJSON(config models.Config) PackageJSON {
 	// Lint script
 	pkg.Scripts["lint"] = "eslint ."
 
-	// Test script
-	if config.Testing == models.TestingVitest {
-		pkg.Scripts["test"] = "vitest"
-	} else if config.Testing == models.TestingJest {
-		pkg.Scripts["test"] = "jest"
-	}
-
-	// Framework dependencies
-	switch config.Framework {
-	case models.FrameworkReact:
-		pkg.Dependencies["react"] = "^19.2.4"
-		pkg.Dependencies["react-dom"] = "^19.2.4"
-		pkg.DevDependencies["@vitejs/plugin-react"] = "^5.1.4"
-		pkg.DevDependencies["@types/react"] = "^19.2.14"
-		pkg.DevDependencies["@types/react-dom"] = "^19.2.3"
-	case models.FrameworkVue:
-		pkg.Dependencies["vue"] = "^3.5.29"
-		pkg.DevDependencies["@vitejs/plugin-vue"] = "^6.0.4"
-	case models.FrameworkSvelte:
-		pkg.Dependencies["svelte"] = "^5.53.3"
-		pkg.DevDependencies["@sveltejs/vite-plugin-svelte"] = "^6.2.4"
-	case models.FrameworkSolid:
-		pkg.Dependencies["solid-js"] = "^1.9.11"
-		pkg.DevDependencies["vite-plugin-solid"] = "^2.11.10"
-	case models.FrameworkAngular:
-		// Angular 21 core packages
-		pkg.Dependencies["@angular/core"] = "^21.1.5"
-		pkg.Dependencies["@angular/common"] = "^21.1.5"
-		pkg.Dependencies["@angular/platform-browser"] = "^21.1.5"
-		pkg.Dependencies["@angular/platform-browser-dynamic"] = "^21.1.5"
-		pkg.Dependencies["@angular/compiler"] = "^21.1.5"
-		// Angular dependencies
-		pkg.Dependencies["rxjs"] = "^7.8.1"
-		pkg.Dependencies["zone.js"] = "^0.15.0"
-		pkg.Dependencies["tslib"] = "^2.8.1"
-		// Vite plugin for Angular (AnalogJS)
-		pkg.DevDependencies["@analogjs/vite-plugin-angular"] = "^2.2.3"
-	}
-
-	// Vite
-	pkg.DevDependencies["vite"] = "^7.3.1"
-
-	// TypeScript
+	// Type check script; .vue and .svelte files need their own checkers
 	if config.Language == models.LangTypeScript {
-		pkg.DevDependencies["typescript"] = "^5.9.3"
-	}
-
-	// Routing
-	switch config.Routing {
-	case models.RoutingReactRouter:
-		pkg.Dependencies["react-router"] = "^7.13.1"
-	case models.RoutingTanStackRouter:
-		pkg.Dependencies["@tanstack/react-router"] = "^1.163.2"
-	case models.RoutingVueRouter:
-		pkg.Dependencies["vue-router"] = "^4.4.5"
-	}
-
-	// Styling
-	switch config.Styling {
-	case models.StylingTailwind:
-		pkg.DevDependencies["tailwindcss"] = "^4.2.1"
-		pkg.DevDependencies["@tailwindcss/vite"] = "^4.2.1"
-	case models.StylingBootstrap:
-		pkg.Dependencies["bootstrap"] = "^5.3.8"
-	case models.StylingSass:
-		pkg.DevDependencies["sass"] = "^1.97.3"
-	case models.StylingStyled:
-		pkg.Dependencies["styled-components"] = "^6.3.11"
-	}
-
-	// State Management
-	switch config.StateManagement {
-	case models.StateZustand:
-		pkg.Dependencies["zustand"] = "^5.0.11"
-	case models.StateReduxToolkit:
-		pkg.Dependencies["@reduxjs/toolkit"] = "^2.11.2"
-		pkg.Dependencies["react-redux"] = "^9.2.0"
-	case models.StatePinia:
-		pkg.Dependencies["pinia"] = "^3.0.4"
-	}
-
-	// Data Fetching
-	switch config.DataFetching {
-	case models.DataTanStackQuery:
-		pkg.Dependencies["@tanstack/react-query"] = "^5.90.21"
-		pkg.DevDependencies["@tanstack/react-query-devtools"] = "^5.91.3"
-	case models.DataAxios:
-		pkg.Dependencies["axios"] = "^1.13.5"
-	case models.DataSWR:
-		pkg.Dependencies["swr"] = "^2.4.0"
-	}
-
-	// Testing
-	if config.Testing == models.TestingVitest {
-		pkg.DevDependencies["vitest"] = "^4.0.18"
-		pkg.DevDependencies["@testing-library/jest-dom"] = "^6.9.1"
-		pkg.DevDependencies["jsdom"] = "^28.1.0"
-
-		// Framework-specific testing libraries
 		switch config.Framework {
-		case models.FrameworkReact:
-			pkg.DevDependencies["@testing-library/react"] = "^16.3.2"
 		case models.FrameworkVue:
-			pkg.DevDependencies["@vue/test-utils"] = "^2.4.6"
+			pkg.Scripts["typecheck"] = "vue-tsc -b"
 		case models.FrameworkSvelte:
-			pkg.DevDependencies["@testing-library/svelte"] = "^5.3.1"
+			pkg.Scripts["typecheck"] = "svelte-check --tsconfig ./tsconfig.app.json"
 		default:
-			// Vanilla JS/TS uses @testing-library/dom
-			pkg.DevDependencies["@testing-library/dom"] = "^10.4.0"
-		}
-	} else if config.Testing == models.TestingJest {
-		pkg.DevDependencies["jest"] = "^30.2.0"
-		pkg.DevDependencies["@testing-library/jest-dom"] = "^6.9.1"
-
-		// Framework-specific testing libraries
-		switch config.Framework {
-		case models.FrameworkReact:
-			pkg.DevDependencies["@testing-library/react"] = "^16.3.2"
-		case models.FrameworkVue:
-			pkg.DevDependencies["@vue/test-utils"] = "^2.4.6"
-		case models.FrameworkSvelte:
-			pkg.DevDependencies["@testing-library/svelte"] = "^5.3.1"
-		default:
-			pkg.DevDependencies["@testing-library/dom"] = "^10.4.0"
+			pkg.Scripts["typecheck"] = "tsc -b"
 		}
 	}
 
-	// ESLint
-	pkg.DevDependencies["eslint"] = "^9.39.1"
-	pkg.DevDependencies["@eslint/js"] = "^9.39.1"
-	pkg.DevDependencies["globals"] = "^15.15.0"
-	pkg.DevDependencies["typescript-eslint"] = "^8.56.1"
-
-	// Framework-specific ESLint plugins
-	switch config.Framework {
-	case models.FrameworkReact:
-		pkg.DevDependencies["eslint-plugin-react-hooks"] = "^7.0.1"
-		pkg.DevDependencies["eslint-plugin-react-refresh"] = "^0.5.2"
-	case models.FrameworkVue:
-		pkg.DevDependencies["eslint-plugin-vue"] = "^10.2.0"
-	}
-
-	// UI Component Libraries
-	switch config.UILibrary {
-	case models.UILibraryShadcn:
-		// Shadcn requires manual setup, add base dependencies
-		pkg.Dependencies["class-variance-authority"] = "^0.7.1"
-		pkg.Dependencies["clsx"] = "^2.1.1"
-		pkg.Dependencies["tailwind-merge"] = "^3.4.0"
-		pkg.Dependencies["@radix-ui/react-slot"] = "^1.1.1"
-	case models.UILibraryMUI:
-		pkg.Dependencies["@mui/material"] = "^7.3.8"
-		pkg.Dependencies["@emotion/react"] = "^11.14.0"
-		pkg.Dependencies["@emotion/styled"] = "^11.14.0"
-	case models.UILibraryChakra:
-		pkg.Dependencies["@chakra-ui/react"] = "^3.33.0"
-		pkg.Dependencies["@emotion/react"] = "^11.14.0"
-		pkg.Dependencies["@emotion/styled"] = "^11.14.0"
-	case models.UILibraryAntD:
-		pkg.Dependencies["antd"] = "^6.0.0"
-	case models.UILibraryHeadless:
-		pkg.Dependencies["@headlessui/react"] = "^2.2.9"
-	case models.UILibraryVuetify:
-		pkg.Dependencies["vuetify"] = "^3.7.7"
-	case models.UILibraryPrimeVue:
-		pkg.Dependencies["primevue"] = "^4.3.0"
-	case models.UILibraryElementUI:
-		pkg.Dependencies["element-plus"] = "^2.9.4"
-	case models.UILibraryNaiveUI:
-		pkg.Dependencies["naive-ui"] = "^2.41.0"
-	case models.UILibraryAngularMaterial:
-		pkg.Dependencies["@angular/material"] = "^21.1.5"
-	case models.UILibraryPrimeNG:
-		pkg.Dependencies["primeng"] = "^21.0.1"
-	case models.UILibraryNGZorro:
-		pkg.Dependencies["ng-zorro-antd"] = "^21.1.0"
-	}
-
-	// Form Management
-	switch config.FormManagement {
-	case models.FormReactHookForm:
-		pkg.Dependencies["react-hook-form"] = "^7.71.2"
-		pkg.Dependencies["@hookform/resolvers"] = "^5.2.2"
-		pkg.Dependencies["zod"] = "^4.3.6"
-	case models.FormFormik:
-		pkg.Dependencies["formik"] = "^2.4.9"
-		pkg.Dependencies["yup"] = "^1.7.1"
-	case models.FormTanStackForm:
-		pkg.Dependencies["@tanstack/react-form"] = "^1.28.3"
-	case models.FormVeeValidate:
-		pkg.Dependencies["vee-validate"] = "^4.15.1"
-		pkg.Dependencies["yup"] = "^1.7.1"
-	case models.FormZod:
-		pkg.Dependencies["zod"] = "^4.3.6"
-	case models.FormYup:
-		pkg.Dependencies["yup"] = "^1.7.1"
-	}
-
-	// Animation
-	switch config.Animation {
-	case models.AnimationFramerMotion:
-		pkg.Dependencies["motion"] = "^12.34.3"
-	case models.AnimationGSAP:
-		pkg.Dependencies["gsap"] = "^3.14.2"
-	case models.AnimationAutoAnimate:
-		pkg.Dependencies["@formkit/auto-animate"] = "^0.9.2"
-	case models.AnimationReactSpring:
-		pkg.Dependencies["@react-spring/web"] = "^10.0.3"
-	}
-
-	// Icons
-	switch config.Icons {
-	case models.IconsReactIcons:
-		pkg.Dependencies["react-icons"] = "^5.4.0"
-	case models.IconsVueIcons:
-		pkg.Dependencies["@vicons/ionicons5"] = "^0.12.0"
-	case models.IconsHeroicons:
-		pkg.Dependencies["@heroicons/react"] = "^2.2.0"
-	case models.IconsLucide:
-		pkg.Dependencies["lucide-react"] = "^0.575.0"
-	case models.IconsFontAwesome:
-		pkg.Dependencies["@fortawesome/fontawesome-svg-core"] = "^6.7.2"
-		pkg.Dependencies["@fortawesome/free-solid-svg-icons"] = "^6.7.2"
-		pkg.Dependencies["@fortawesome/react-fontawesome"] = "^0.2.3"
-	}
-
-	// Data Visualization
-	switch config.DataViz {
-	case models.DataVizRecharts:
-		pkg.Dependencies["recharts"] = "^3.7.0"
-	case models.DataVizChartJS:
-		pkg.Dependencies["chart.js"] = "^4.5.0"
-		pkg.Dependencies["react-chartjs-2"] = "^5.3.0"
-	case models.DataVizECharts:
-		pkg.Dependencies["echarts"] = "^6.0.0"
-		pkg.Dependencies["echarts-for-react"] = "^3.0.2"
-	case models.DataVizNivo:
-		pkg.Dependencies["@nivo/core"] = "^0.89.0"
-		pkg.Dependencies["@nivo/line"] = "^0.89.0"
-		pkg.Dependencies["@nivo/bar"] = "^0.89.0"
+	// Test script
+	if config.Testing == models.TestingVitest {
+		pkg.Scripts["test"] = "vitest"
+	} else if config.Testing == models.TestingJest {
+		pkg.Scripts["test"] = "jest"
 	}
 
-	// Utilities
-	switch config.Utilities {
-	case models.UtilsDateFns:
-		pkg.Dependencies["date-fns"] = "^4.1.0"
-	case models.UtilsDayJS:
-		pkg.Dependencies["dayjs"] = "^1.11.14"
-	case models.UtilsLodash:
-		pkg.Dependencies["lodash-es"] = "^4.17.21"
-		pkg.DevDependencies["@types/lodash-es"] = "^4.17.12"
-	}
+	// Vite
+	pkg.DevDependencies["vite"] = versions.Version("vite")
 
-	// Internationalization
-	switch config.I18n {
-	case models.I18nReactI18next:
-		pkg.Dependencies["react-i18next"] = "^16.5.4"
-		pkg.Dependencies["i18next"] = "^25.8.13"
-	case models.I18nVueI18n:
-		pkg.Dependencies["vue-i18n"] = "^11.2.8"
+	// ESLint
+	pkg.DevDependencies["eslint"] = versions.Version("eslint")
+	pkg.DevDependencies["@eslint/js"] = versions.Version("@eslint/js")
+	pkg.DevDependencies["globals"] = versions.Version("globals")
+	pkg.DevDependencies["typescript-eslint"] = versions.Version("typescript-eslint")
+
+	// Framework, language and library dependencies come from the option registry
+	for _, p := range models.SelectedPackages(config) {
+		if p.Dev {
+			pkg.DevDependencies[p.Name] = p.Version()
+		} else {
+			pkg.Dependencies[p.Name] = p.Version()
+		}
 	}
 
 	return pkg
@@ -4,24 +4,34 @@
 // all necessary files and directories based on the user's configuration.
 //
 // Key features:
-//   - Automatic cleanup on generation failure (rollback pattern)
+//   - Transactional output: files are staged and moved into place only on success
 //   - Path validation and safety checks
 //   - Support for React, Vue, Angular, Svelte, and Solid frameworks
 //   - TypeScript and JavaScript support
 //   - Vite-based build configuration
 //
 // Main entry point is SetupProject(), which coordinates all file generation
-// and ensures atomic operations with cleanup on error.
+// and commits it atomically, leaving nothing behind on error or interrupt.
 package generators
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"frontforge/internal/generators/meta"
+	"frontforge/internal/manifest"
 	"frontforge/internal/models"
 	"frontforge/internal/templates"
+	"frontforge/internal/versions"
+	"frontforge/internal/vfs"
+	"io"
+	"io/fs"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
 
 	// Register meta-framework generators via init()
 	_ "frontforge/internal/generators/astro"
@@ -29,118 +39,218 @@ import (
 	_ "frontforge/internal/generators/sveltekit"
 )
 
-// SetupProject orchestrates the entire project generation
-// On error, automatically cleans up any partially created files
-// If config.DryRun is true, prints a manifest without writing files
+// Result describes a completed generation
+type Result struct {
+	Files      []string           // Files written (or that would be written), relative to the project root
+	Validation []ValidationResult // Post-generation checks; empty in dry-run mode
+	Conflicts  []ConflictOutcome  // Existing files that differed from the generated ones
+	DryRun     *DryRunManifest    // What would be written; nil unless config.DryRun
+}
+
+// SetupProject orchestrates the entire project generation, printing
+// progress and warnings to stdout. See Generate.
 func SetupProject(config models.Config) error {
-	// Use the project path from config (set by CLI flags)
-	projectPath := config.ProjectPath
-	if projectPath == "" {
-		// Fallback to current directory if not set (shouldn't happen with new flag system)
-		var err error
-		projectPath, err = os.Getwd()
+	_, err := Generate(config, os.Stdout)
+	return err
+}
+
+// Generate orchestrates the entire project generation on disk
+// Output is staged and committed only on success (see Stage); existing files
+// that differ are handled by config.OnConflict
+// If config.DryRun is true, prints a manifest (or, with the diff format, the
+// changes against disk) without writing files. The json and files formats
+// are left to the caller, from Result.DryRun.
+// Human-readable output (manifest, validation warnings) goes to out
+func Generate(config models.Config, out io.Writer) (*Result, error) {
+	if !config.DryRun {
+		staged, err := Stage(config, out)
 		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
+			return nil, err
 		}
+		return staged.Commit(nil)
 	}
 
-	// Dry-run mode: collect manifest instead of writing files
-	var manifest *DryRunManifest
-	if config.DryRun {
-		manifest = NewDryRunManifest(projectPath, config.ProjectName)
-		defer manifest.Print()
+	// Dry-run mode: collect writes in memory, reading through to disk
+	dryRun := vfs.NewDryRun(vfs.Disk{})
+	result, err := GenerateFS(dryRun, config, out)
+	if err != nil {
+		return nil, err
 	}
 
-	// Track all created paths for cleanup on failure
-	var createdPaths []string
-	success := false
+	projectPath, err := resolveProjectPath(config)
+	if err != nil {
+		return nil, err
+	}
+	manifest := newDryRunManifest(dryRun, projectPath, config.ProjectName)
+	result.DryRun = manifest
+	switch config.DryRunFormat {
+	case models.DryRunDiff:
+		manifest.Compare(vfs.Disk{})
+		manifest.FprintDiff(out)
+	case models.DryRunJSON, models.DryRunFiles:
+		// Machine-readable output belongs on stdout; callers print result.DryRun
+	default:
+		manifest.Fprint(out)
+	}
+	return result, nil
+}
 
-	// Cleanup function that removes created files in reverse order
-	cleanup := func() {
-		for i := len(createdPaths) - 1; i >= 0; i-- {
-			_ = os.RemoveAll(createdPaths[i])
-		}
+// GenerateFS generates the project through fsys
+// Nothing is cleaned up on error; Generate uses a transaction for that
+func GenerateFS(fsys vfs.FS, config models.Config, out io.Writer) (*Result, error) {
+	return generateFS(context.Background(), fsys, config, out)
+}
+
+// generateFS is GenerateFS stopping when ctx is done: between files, and by
+// killing a meta-framework's upstream CLI
+func generateFS(ctx context.Context, fsys vfs.FS, config models.Config, out io.Writer) (*Result, error) {
+	result := &Result{}
+	if ctx.Done() != nil {
+		fsys = vfs.Watch(fsys, func(string, bool) error { return ctx.Err() })
 	}
 
-	// Defer cleanup if generation fails
-	defer func() {
-		if !success && !config.DryRun {
-			cleanup()
-		}
-	}()
+	// Callers fix or reject incompatible options first; this is the last line of defense
+	if violations := models.CheckCompatibility(config); len(violations) > 0 {
+		return nil, &models.CompatibilityError{Violations: violations}
+	}
+
+	projectPath, err := resolveProjectPath(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Optionally replace catalog versions with the latest published ones.
+	// Generators read versions lazily, so the resolver only sees selected packages.
+	if config.ResolveLatest {
+		cwd, _ := os.Getwd()
+		resolver := versions.NewResolver(cwd, projectPath)
+		defer versions.UseResolver(resolver)()
+		defer printResolutions(out, resolver)
+	}
+
+	recorder := vfs.Record(fsys)
+	fsys = recorder
 
 	// Create the project directory if it doesn't exist (for new folder mode)
 	// This will do nothing if the directory already exists (current directory mode)
-	dirExisted := false
-	if !config.DryRun {
-		if _, err := os.Stat(projectPath); os.IsNotExist(err) {
-			if err := os.MkdirAll(projectPath, 0755); err != nil {
-				return fmt.Errorf("failed to create project directory: %w", err)
-			}
-			createdPaths = append(createdPaths, projectPath)
-		} else {
-			dirExisted = true
+	if !vfs.Exists(fsys, projectPath) {
+		if err := fsys.MkdirAll(projectPath); err != nil {
+			return nil, fmt.Errorf("failed to create project directory: %w", err)
 		}
 	}
 
-	// Helper to track file creation
-	trackPath := func(path string) {
-		// Only track if directory didn't exist before (avoid deleting user's directory)
-		if !dirExisted && !config.DryRun {
-			createdPaths = append(createdPaths, path)
+	// Record generated files relative to the project root
+	recordFiles := func(paths []string) {
+		for _, path := range paths {
+			if rel, err := filepath.Rel(projectPath, path); err == nil {
+				result.Files = append(result.Files, filepath.ToSlash(rel))
+			}
 		}
 	}
 
-	// Helper to write or collect files
-	writeOrCollect := func(path, content string) error {
-		if manifest != nil {
-			manifest.AddFile(path, content)
-			return nil
+	// Meta-framework path: shell out to upstream CLI, then apply post-scaffold transforms
+	if models.IsMetaFramework(config.Framework) {
+		if err := meta.RunMetaScaffold(ctx, fsys, config); err != nil {
+			return nil, err
 		}
-		return writeFile(path, content)
-	}
-
-	// Helper to write or collect JSON
-	writeOrCollectJSON := func(path string, data interface{}) error {
-		bytes, err := json.MarshalIndent(data, "", "  ")
-		if err != nil {
-			return err
+		if dir, onDisk := vfs.HostPath(fsys, projectPath); onDisk {
+			// Include what the upstream CLI created
+			result.Files = listFiles(dir)
+		} else {
+			recordFiles(recorder.Files())
 		}
-		content := string(bytes)
-		if manifest != nil {
-			manifest.AddFile(path, content)
-			return nil
+		if err := writeManifest(fsys, projectPath, config, result); err != nil {
+			return nil, err
 		}
-		return writeJSON(path, data)
+		return result, nil
 	}
 
-	// Helper to create or collect directories
-	mkdirOrCollect := func(path string) error {
-		if manifest != nil {
-			manifest.AddDir(path)
-			return nil
-		}
-		return os.MkdirAll(path, 0755)
+	// Vite-based framework path below
+	if err := writeProjectFiles(fsys, projectPath, config); err != nil {
+		return nil, err
 	}
 
-	// Meta-framework path: shell out to upstream CLI, then apply post-scaffold transforms
-	if models.IsMetaFramework(config.Framework) {
-		if err := meta.RunMetaScaffold(config); err != nil {
-			return err
+	recordFiles(recorder.Files())
+	if err := writeManifest(fsys, projectPath, config, result); err != nil {
+		return nil, err
+	}
+
+	// Run post-generation validation
+	if !config.DryRun {
+		result.Validation = ValidateFS(fsys, projectPath, config)
+	}
+	failedChecks := 0
+	for _, check := range result.Validation {
+		if !check.Passed {
+			failedChecks++
+			fmt.Fprintf(out, "  Warning: %s - %s\n", check.Check, check.Message)
 		}
-		success = true
-		return nil
 	}
 
-	// Vite-based framework path below
+	if failedChecks > 0 {
+		fmt.Fprintf(out, "\nValidation completed with %d warning(s). Project may not work correctly.\n", failedChecks)
+	}
+
+	return result, nil
+}
+
+// GenerateInto is GenerateFS for any filesystem. Files that already exist in
+// fsys with other content are handled with config.OnConflict, as Commit does
+// for staged projects, and listed in Result.Conflicts. Meta-frameworks
+// written anywhere but the disk are scaffolded in a temporary directory
+// first, then copied into fsys without node_modules or .git. Generation
+// stops when ctx is done.
+func GenerateInto(ctx context.Context, fsys vfs.FS, config models.Config, out io.Writer) (*Result, error) {
+	projectPath, err := resolveProjectPath(config)
+	if err != nil {
+		return nil, err
+	}
+	policy := &conflictFS{FS: fsys, projectPath: projectPath, policy: config.OnConflict}
+
+	var result *Result
+	if _, onDisk := vfs.HostPath(fsys, projectPath); onDisk || !models.IsMetaFramework(config.Framework) {
+		result, err = generateFS(ctx, policy, config, out)
+	} else {
+		result, err = generateMetaInto(ctx, policy, config, projectPath, out)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Conflicts = policy.outcomes
+	return result, nil
+}
 
+// generateMetaInto runs the upstream CLI in a temporary directory and copies
+// what it created into fsys at projectPath
+func generateMetaInto(ctx context.Context, fsys vfs.FS, config models.Config, projectPath string, out io.Writer) (*Result, error) {
+	tmp, err := os.MkdirTemp("", "frontforge-scaffold-")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	// The upstream CLIs name the package after the directory
+	scaffold := config
+	scaffold.ProjectPath = filepath.Join(tmp, config.ProjectName)
+	result, err := generateFS(ctx, vfs.Disk{}, scaffold, out)
+	if err != nil {
+		return nil, err
+	}
+	if err := copyDir(fsys, scaffold.ProjectPath, projectPath); err != nil {
+		return nil, fmt.Errorf("copying the %s project: %w", config.Framework, err)
+	}
+	return result, nil
+}
+
+// writeProjectFiles writes the files of a Vite-based project into
+// projectPath, which must exist
+func writeProjectFiles(fsys vfs.FS, projectPath string, config models.Config) error {
 	// Generate package.json
 	packageJSON := GeneratePackageJSON(config)
 	packageJSONPath := filepath.Join(projectPath, "package.json")
-	if err := writeOrCollectJSON(packageJSONPath, packageJSON); err != nil {
+	if err := writeJSON(fsys, packageJSONPath, packageJSON); err != nil {
 		return fmt.Errorf("failed to write package.json: %w", err)
 	}
-	trackPath(packageJSONPath)
 
 	// Generate vite.config
 	viteConfig := GenerateViteConfig(config)
@@ -148,32 +258,32 @@ func SetupProject(config models.Config) error {
 	if config.Language == models.LangTypeScript {
 		ext = "ts"
 	}
-	if err := writeOrCollect(filepath.Join(projectPath, fmt.Sprintf("vite.config.%s", ext)), viteConfig); err != nil {
+	if err := writeFile(fsys, filepath.Join(projectPath, fmt.Sprintf("vite.config.%s", ext)), viteConfig); err != nil {
 		return fmt.Errorf("failed to write vite.config: %w", err)
 	}
 
 	// Generate TypeScript configs (Vite uses 3-file split)
 	if config.Language == models.LangTypeScript {
 		tsConfigs := GenerateTSConfig(config)
-		if err := writeOrCollectJSON(filepath.Join(projectPath, "tsconfig.json"), tsConfigs.Base); err != nil {
+		if err := writeJSON(fsys, filepath.Join(projectPath, "tsconfig.json"), tsConfigs.Base); err != nil {
 			return fmt.Errorf("failed to write tsconfig.json: %w", err)
 		}
-		if err := writeOrCollectJSON(filepath.Join(projectPath, "tsconfig.app.json"), tsConfigs.App); err != nil {
+		if err := writeJSON(fsys, filepath.Join(projectPath, "tsconfig.app.json"), tsConfigs.App); err != nil {
 			return fmt.Errorf("failed to write tsconfig.app.json: %w", err)
 		}
-		if err := writeOrCollectJSON(filepath.Join(projectPath, "tsconfig.node.json"), tsConfigs.Node); err != nil {
+		if err := writeJSON(fsys, filepath.Join(projectPath, "tsconfig.node.json"), tsConfigs.Node); err != nil {
 			return fmt.Errorf("failed to write tsconfig.node.json: %w", err)
 		}
 	}
 
 	// Generate project structure
-	if err := GenerateProjectStructure(projectPath, config, mkdirOrCollect, writeOrCollect); err != nil {
+	if err := GenerateProjectStructure(fsys, projectPath, config); err != nil {
 		return fmt.Errorf("failed to generate project structure: %w", err)
 	}
 
 	// Generate index.html
 	indexHTML := GenerateIndexHTML(config)
-	if err := writeOrCollect(filepath.Join(projectPath, "index.html"), indexHTML); err != nil {
+	if err := writeFile(fsys, filepath.Join(projectPath, "index.html"), indexHTML); err != nil {
 		return fmt.Errorf("failed to write index.html: %w", err)
 	}
 
@@ -182,14 +292,14 @@ func SetupProject(config models.Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to generate vite.svg: %w", err)
 	}
-	if err := writeOrCollect(filepath.Join(projectPath, "public", "vite.svg"), viteSVG); err != nil {
+	if err := writeFile(fsys, filepath.Join(projectPath, "public", "vite.svg"), viteSVG); err != nil {
 		return fmt.Errorf("failed to write vite.svg: %w", err)
 	}
 
 	// Generate main entry file
 	mainFile := GenerateMainFile(config)
 	mainExt := getMainFileExtension(config)
-	if err := writeOrCollect(filepath.Join(projectPath, "src", fmt.Sprintf("main.%s", mainExt)), mainFile); err != nil {
+	if err := writeFile(fsys, filepath.Join(projectPath, "src", fmt.Sprintf("main.%s", mainExt)), mainFile); err != nil {
 		return fmt.Errorf("failed to write main file: %w", err)
 	}
 
@@ -199,24 +309,34 @@ func SetupProject(config models.Config) error {
 
 	// Angular components go in app/ directory
 	if config.Framework == models.FrameworkAngular {
-		if err := mkdirOrCollect(filepath.Join(projectPath, "src", "app")); err != nil {
+		if err := fsys.MkdirAll(filepath.Join(projectPath, "src", "app")); err != nil {
 			return fmt.Errorf("failed to create app directory: %w", err)
 		}
-		if err := writeOrCollect(filepath.Join(projectPath, "src", "app", "app.component.ts"), appFile); err != nil {
+		if err := writeFile(fsys, filepath.Join(projectPath, "src", "app", "app.component.ts"), appFile); err != nil {
 			return fmt.Errorf("failed to write App component: %w", err)
 		}
 	} else {
-		if err := writeOrCollect(filepath.Join(projectPath, "src", fmt.Sprintf("App.%s", appExt)), appFile); err != nil {
+		if err := writeFile(fsys, filepath.Join(projectPath, "src", fmt.Sprintf("App.%s", appExt)), appFile); err != nil {
 			return fmt.Errorf("failed to write App file: %w", err)
 		}
 	}
 
+	// Generate the Vue Router setup the main file imports
+	if config.Framework == models.FrameworkVue && config.Routing == models.RoutingVueRouter {
+		if err := fsys.MkdirAll(filepath.Join(projectPath, "src", "router")); err != nil {
+			return fmt.Errorf("failed to create router directory: %w", err)
+		}
+		if err := writeFile(fsys, filepath.Join(projectPath, "src", "router", fmt.Sprintf("index.%s", ext)), GenerateVueRouter(config)); err != nil {
+			return fmt.Errorf("failed to write router: %w", err)
+		}
+	}
+
 	// Generate .gitignore
 	gitignore, err := templates.RenderStatic("static/gitignore.tmpl")
 	if err != nil {
 		return fmt.Errorf("failed to generate .gitignore: %w", err)
 	}
-	if err := writeOrCollect(filepath.Join(projectPath, ".gitignore"), gitignore); err != nil {
+	if err := writeFile(fsys, filepath.Join(projectPath, ".gitignore"), gitignore); err != nil {
 		return fmt.Errorf("failed to write .gitignore: %w", err)
 	}
 
@@ -225,7 +345,7 @@ func SetupProject(config models.Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to generate README: %w", err)
 	}
-	if err := writeOrCollect(filepath.Join(projectPath, "README.md"), readme); err != nil {
+	if err := writeFile(fsys, filepath.Join(projectPath, "README.md"), readme); err != nil {
 		return fmt.Errorf("failed to write README.md: %w", err)
 	}
 
@@ -235,7 +355,7 @@ func SetupProject(config models.Config) error {
 		if err != nil {
 			return fmt.Errorf("failed to read Tailwind CSS import file: %w", err)
 		}
-		if err := writeOrCollect(filepath.Join(projectPath, "src", "index.css"), indexCSS); err != nil {
+		if err := writeFile(fsys, filepath.Join(projectPath, "src", "index.css"), indexCSS); err != nil {
 			return fmt.Errorf("failed to write index.css: %w", err)
 		}
 	}
@@ -245,7 +365,7 @@ func SetupProject(config models.Config) error {
 		if err != nil {
 			return fmt.Errorf("failed to generate CSS Modules example: %w", err)
 		}
-		if err := writeOrCollect(filepath.Join(projectPath, "src", "App.module.css"), appModuleCSS); err != nil {
+		if err := writeFile(fsys, filepath.Join(projectPath, "src", "App.module.css"), appModuleCSS); err != nil {
 			return fmt.Errorf("failed to write CSS Modules example: %w", err)
 		}
 	}
@@ -255,7 +375,7 @@ func SetupProject(config models.Config) error {
 		if err != nil {
 			return fmt.Errorf("failed to generate Sass example: %w", err)
 		}
-		if err := writeOrCollect(filepath.Join(projectPath, "src", "styles.scss"), stylesScss); err != nil {
+		if err := writeFile(fsys, filepath.Join(projectPath, "src", "styles.scss"), stylesScss); err != nil {
 			return fmt.Errorf("failed to write Sass example: %w", err)
 		}
 	}
@@ -272,13 +392,13 @@ func SetupProject(config models.Config) error {
 		if err != nil {
 			return fmt.Errorf("failed to generate Vitest config: %w", err)
 		}
-		if err := writeOrCollect(filepath.Join(projectPath, fmt.Sprintf("vitest.config.%s", ext)), vitestConfig); err != nil {
+		if err := writeFile(fsys, filepath.Join(projectPath, fmt.Sprintf("vitest.config.%s", ext)), vitestConfig); err != nil {
 			return fmt.Errorf("failed to write Vitest config: %w", err)
 		}
 
 		// Create test directory
 		testDir := filepath.Join(projectPath, "src", "test")
-		if err := mkdirOrCollect(testDir); err != nil {
+		if err := fsys.MkdirAll(testDir); err != nil {
 			return fmt.Errorf("failed to create test directory: %w", err)
 		}
 
@@ -287,7 +407,7 @@ func SetupProject(config models.Config) error {
 		if err != nil {
 			return fmt.Errorf("failed to generate Vitest setup: %w", err)
 		}
-		if err := writeOrCollect(filepath.Join(testDir, fmt.Sprintf("setup.%s", ext)), setupFile); err != nil {
+		if err := writeFile(fsys, filepath.Join(testDir, fmt.Sprintf("setup.%s", ext)), setupFile); err != nil {
 			return fmt.Errorf("failed to write Vitest setup: %w", err)
 		}
 	}
@@ -297,40 +417,298 @@ func SetupProject(config models.Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to generate ESLint config: %w", err)
 	}
-	if err := writeOrCollect(filepath.Join(projectPath, "eslint.config.js"), eslintConfig); err != nil {
+	if err := writeFile(fsys, filepath.Join(projectPath, "eslint.config.js"), eslintConfig); err != nil {
 		return fmt.Errorf("failed to write ESLint config: %w", err)
 	}
 
-	// Run post-generation validation
-	validationResults := ValidateProject(projectPath, config)
-	failedChecks := 0
-	for _, result := range validationResults {
-		if !result.Passed {
-			failedChecks++
-			if !config.DryRun {
-				fmt.Printf("  Warning: %s - %s\n", result.Check, result.Message)
+	return nil
+}
+
+// Staged is a generated project waiting to be moved into place. Conflicts
+// can be reviewed before calling Commit; call Discard to abandon it.
+type Staged struct {
+	Result    *Result
+	Conflicts []vfs.Conflict // Files that would replace different existing content
+
+	config      models.Config
+	projectPath string // Absolute
+	tx          *vfs.Transaction
+	stop        func() // Stops the interrupt handler; safe to call twice
+}
+
+// Stage generates the project into a staging directory without touching the
+// target (see vfs.Transaction). Ctrl+C or SIGTERM before Commit or Discard
+// discards the staged output and exits.
+func Stage(config models.Config, out io.Writer) (*Staged, error) {
+	return stage(context.Background(), config, out, nil, true)
+}
+
+// StageWith is Stage for library callers, which handle signals themselves:
+// nothing exits the process, and generation stops when ctx is done. wrap,
+// if not nil, wraps the staging filesystem generators write through (see
+// vfs.Watch).
+func StageWith(ctx context.Context, config models.Config, out io.Writer, wrap func(vfs.FS) vfs.FS) (*Staged, error) {
+	return stage(ctx, config, out, wrap, false)
+}
+
+func stage(ctx context.Context, config models.Config, out io.Writer, wrap func(vfs.FS) vfs.FS, handleSignals bool) (*Staged, error) {
+	projectPath, err := resolveProjectPath(config)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := vfs.Begin(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stage project: %w", err)
+	}
+	if tx.Recovered {
+		fmt.Fprintf(out, "Rolled back an interrupted generation in %s\n", projectPath)
+	}
+
+	s := &Staged{config: config, tx: tx, stop: func() {}}
+	s.projectPath, _ = filepath.Abs(projectPath)
+	if handleSignals {
+		// Ctrl+C or SIGTERM: discard the staged output before exiting
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		finished := make(chan struct{})
+		go func() {
+			select {
+			case <-signals:
+				_ = tx.Rollback()
+				os.Exit(130)
+			case <-finished:
 			}
+		}()
+		var once sync.Once
+		s.stop = func() {
+			once.Do(func() {
+				signal.Stop(signals)
+				close(finished)
+			})
+		}
+	}
+
+	var fsys vfs.FS = tx
+	if wrap != nil {
+		fsys = wrap(tx)
+	}
+	if s.Result, err = generateFS(ctx, fsys, config, out); err != nil {
+		s.Discard()
+		return nil, err
+	}
+	conflicts, err := tx.Conflicts()
+	if err != nil {
+		s.Discard()
+		return nil, fmt.Errorf("failed to compare with existing files: %w", err)
+	}
+	for _, c := range conflicts {
+		// The manifest and base copies always describe the latest generation
+		if !isStateFile(s.projectPath, c.Path) {
+			s.Conflicts = append(s.Conflicts, c)
+		}
+	}
+	return s, nil
+}
+
+// Commit moves the staged project into place, handling each conflict with
+// config.OnConflict. choices maps a conflict's path to an action that
+// overrides the policy; with the prompt policy, conflicts without a choice
+// keep the existing file. Kept files are left out of the manifest, and merged
+// ones are recorded with the generated content.
+func (s *Staged) Commit(choices map[string]string) (*Result, error) {
+	defer s.stop()
+	policy := func(path string) string {
+		if choice, ok := choices[path]; ok {
+			return choice
+		}
+		return s.config.OnConflict
+	}
+
+	// The staged manifest describes every generated file; files that stay
+	// as they are must not be taken for generated ones
+	var kept []string
+	for _, c := range s.Conflicts {
+		if res, _ := resolveConflict(policy(c.Path), c); res.Keep {
+			rel, _ := filepath.Rel(s.projectPath, c.Path)
+			kept = append(kept, filepath.ToSlash(rel))
+		}
+	}
+	if err := s.forget(kept); err != nil {
+		s.Discard()
+		return nil, err
+	}
+
+	s.tx.Resolve = func(c vfs.Conflict) (vfs.Resolution, error) {
+		if isStateFile(s.projectPath, c.Path) {
+			return vfs.Resolution{}, nil
 		}
+		res, action := resolveConflict(policy(c.Path), c)
+		rel, _ := filepath.Rel(s.projectPath, c.Path)
+		s.Result.Conflicts = append(s.Result.Conflicts, ConflictOutcome{Path: filepath.ToSlash(rel), Action: action})
+		return res, nil
+	}
+	if err := s.tx.Commit(); err != nil {
+		s.Result.Conflicts = nil
+		return nil, fmt.Errorf("failed to move the project into place: %w", err)
 	}
+	return s.Result, nil
+}
 
-	if failedChecks > 0 && !config.DryRun {
-		fmt.Printf("\nValidation completed with %d warning(s). Project may not work correctly.\n", failedChecks)
+// forget takes kept files, by slash-separated path relative to the project,
+// out of the staged manifest, base copies and Result.Files
+func (s *Staged) forget(kept []string) error {
+	if len(kept) == 0 {
+		return nil
+	}
+	m, err := manifest.Load(s.tx, s.projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	for _, rel := range kept {
+		m.RemoveFile(rel)
+	}
+	if err := manifest.RemoveBases(s.tx, s.projectPath, kept); err != nil {
+		return fmt.Errorf("failed to update the copies of the generated files: %w", err)
+	}
+	if err := m.Write(s.tx, s.projectPath); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
 	}
 
-	// Mark generation as successful - prevents cleanup
-	success = true
+	forgotten := make(map[string]bool, len(kept))
+	for _, rel := range kept {
+		forgotten[rel] = true
+	}
+	files := s.Result.Files[:0]
+	for _, rel := range s.Result.Files {
+		if !forgotten[rel] {
+			files = append(files, rel)
+		}
+	}
+	s.Result.Files = files
 	return nil
 }
 
+// Discard removes the staged project without touching the target
+func (s *Staged) Discard() {
+	_ = s.tx.Rollback()
+	s.stop()
+}
+
+// writeManifest records the config, dependency versions, a hash of each
+// generated file and what each option brought in (see optionOwners) in
+// .frontforge/manifest.json, keeps a copy of each file in
+// .frontforge/base.tar.gz, then adds the manifest to result.Files
+func writeManifest(fsys vfs.FS, projectPath string, config models.Config, result *Result) error {
+	m := manifest.New(config)
+	bases := make(map[string][]byte, len(result.Files))
+	for _, rel := range result.Files {
+		data, err := fsys.ReadFile(filepath.Join(projectPath, filepath.FromSlash(rel)))
+		if err != nil {
+			return fmt.Errorf("failed to read %s for the manifest: %w", rel, err)
+		}
+		m.AddFile(rel, data)
+		bases[rel] = data
+		if rel == "package.json" {
+			if err := m.SetPackageJSON(data); err != nil {
+				return err
+			}
+		}
+	}
+	if !models.IsMetaFramework(config.Framework) {
+		owners, err := optionOwners(config, projectPath, result.Files)
+		if err != nil {
+			return err
+		}
+		m.Options = owners
+	}
+	if err := manifest.WriteBases(fsys, projectPath, bases); err != nil {
+		return fmt.Errorf("failed to keep copies of the generated files: %w", err)
+	}
+	if err := m.Write(fsys, projectPath); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	result.Files = append(result.Files, vfs.StateDir+"/"+manifest.FileName)
+	return nil
+}
+
+// isStateFile reports whether path is part of frontforge's own state in the
+// project, which is replaced on every generation
+func isStateFile(projectPath, path string) bool {
+	rel, err := filepath.Rel(projectPath, path)
+	return err == nil && strings.HasPrefix(filepath.ToSlash(rel), vfs.StateDir+"/")
+}
+
 // Helper functions
-func writeFile(path, content string) error {
-	return os.WriteFile(path, []byte(content), 0644)
+func writeFile(fsys vfs.FS, path, content string) error {
+	return vfs.WriteString(fsys, path, content)
 }
 
-func writeJSON(path string, data interface{}) error {
+func writeJSON(fsys vfs.FS, path string, data interface{}) error {
 	bytes, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, bytes, 0644)
+	return fsys.WriteFile(path, bytes, 0644)
+}
+
+// resolveProjectPath returns the directory to generate into
+func resolveProjectPath(config models.Config) (string, error) {
+	// Use the project path from config (set by CLI flags)
+	if config.ProjectPath != "" {
+		return config.ProjectPath, nil
+	}
+	// Fallback to current directory if not set (shouldn't happen with new flag system)
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+	return projectPath, nil
+}
+
+// listFiles returns the files under root, relative to it, skipping
+// installed dependencies, version control and frontforge's state
+func listFiles(root string) []string {
+	var files []string
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if name := d.Name(); name == "node_modules" || name == ".git" || name == vfs.StateDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if rel, err := filepath.Rel(root, path); err == nil {
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return files
+}
+
+// printResolutions summarizes where -resolve-latest found each version
+func printResolutions(w io.Writer, r *versions.Resolver) {
+	var fetched, cached, fallbacks int
+	var firstErr error
+	for _, res := range r.Resolutions() {
+		switch res.Source {
+		case versions.SourceRegistry:
+			fetched++
+		case versions.SourceCache:
+			cached++
+		default:
+			fallbacks++
+			if firstErr == nil {
+				firstErr = res.Err
+			}
+		}
+	}
+
+	if fetched+cached > 0 {
+		fmt.Fprintf(w, "Resolved %d package versions from the registry (%d cached)\n", fetched+cached, cached)
+	}
+	if fallbacks > 0 {
+		fmt.Fprintf(w, "Warning: using built-in versions for %d packages: %v\n", fallbacks, firstErr)
+	}
 }
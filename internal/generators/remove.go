@@ -0,0 +1,381 @@
+package generators
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// RemovePlan takes options back out of a project: it deletes the files they
+// brought in that are still as generated, reverts their wiring in the build
+// config and entry point, and drops their dependencies and scripts from
+// package.json. Nothing is written until Commit.
+type RemovePlan struct {
+	From models.Config // Options the project has
+	To   models.Config // Options after the change
+
+	Files           []UpgradeFile
+	Dependencies    []string // Removed from package.json
+	DevDependencies []string
+	Scripts         []string
+
+	projectPath string
+	rendered    *vfs.Memory // Generation without the options; its state replaces the manifest
+}
+
+// PlanRemove works out how to take options out of the project in
+// projectPath, setting each back to the option that adds nothing. targets
+// maps registry keys to the option value being removed, or to "" for
+// whichever the project uses. What each option brought in comes from the
+// ownership recorded in the project's manifest: its files are removed unless
+// they were edited, and its dependencies and scripts leave package.json
+// unless the remaining options still need them.
+func PlanRemove(fsys vfs.FS, projectPath string, targets map[string]string) (*RemovePlan, error) {
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	m, err := manifest.Load(fsys, projectPath)
+	if err != nil {
+		return nil, err
+	}
+	if models.IsMetaFramework(m.Config.Framework) {
+		return nil, fmt.Errorf("removing options from %s projects is not supported: their files come from the upstream CLI", m.Config.Framework)
+	}
+	if m.Options == nil {
+		return nil, fmt.Errorf("the manifest was written by frontforge %s, which didn't record what each option added; run 'frontforge upgrade' first", m.Tool)
+	}
+
+	p := &RemovePlan{From: m.Config, projectPath: projectPath}
+	p.From.ProjectPath = projectPath
+	p.To = p.From
+	owned := make(map[string]bool)
+	deps, devDeps, scripts := make(map[string]bool), make(map[string]bool), make(map[string]bool)
+	for key, value := range targets {
+		g, ok := models.LookupGroup(key)
+		if !ok {
+			return nil, fmt.Errorf("unknown option '%s'", key)
+		}
+		if fixedGroups[g.Key] {
+			return nil, fmt.Errorf("the %s is chosen when a project is created and can't be removed", g.Name)
+		}
+		neutral := neutralOption(g, p.From.Framework)
+		current := *g.Field(&p.From)
+		if current == neutral {
+			return nil, fmt.Errorf("the project has no %s to remove", g.Name)
+		}
+		if value != "" && value != current {
+			return nil, fmt.Errorf("the project uses %s for %s, not %s", current, g.Name, value)
+		}
+		*g.Field(&p.To) = neutral
+		if o := m.Options[g.Key]; o != nil {
+			addAll(owned, o.Files)
+			addAll(deps, o.Dependencies)
+			addAll(devDeps, o.DevDependencies)
+			addAll(scripts, o.Scripts)
+		}
+	}
+	if violations := models.CheckCompatibility(p.To); len(violations) > 0 {
+		return nil, &models.CompatibilityError{Violations: violations}
+	}
+
+	// Versions come from the catalog
+	p.From.ResolveLatest, p.To.ResolveLatest = false, false
+	before, after := vfs.NewMemory(), vfs.NewMemory()
+	if _, err := GenerateFS(before, p.From, io.Discard); err != nil {
+		return nil, fmt.Errorf("failed to render the project's current options: %w", err)
+	}
+	result, err := GenerateFS(after, p.To, io.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the remaining options: %w", err)
+	}
+	p.rendered = after
+
+	for _, rel := range result.Files {
+		if strings.HasPrefix(rel, vfs.StateDir+"/") || rel == "package.json" {
+			continue
+		}
+		delete(owned, rel) // Still generated without the options
+		path := filepath.Join(projectPath, filepath.FromSlash(rel))
+		data, err := after.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		old, err := before.ReadFile(path)
+		if err == nil && bytes.Equal(old, data) {
+			continue
+		}
+		f, err := p.planRemainingFile(fsys, m, path, rel, old, data)
+		if err != nil {
+			return nil, err
+		}
+		p.Files = append(p.Files, f)
+	}
+
+	for _, rel := range sortedKeys(owned) {
+		current, err := fsys.ReadFile(filepath.Join(projectPath, filepath.FromSlash(rel)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			continue
+		case err != nil:
+			return nil, err
+		case m.Owned(rel, current):
+			p.Files = append(p.Files, UpgradeFile{Path: rel, Status: UpgradeRemoved})
+		default:
+			p.Files = append(p.Files, UpgradeFile{Path: rel, Status: UpgradeEdited})
+		}
+	}
+
+	if err := p.planPackageJSON(fsys, deps, devDeps, scripts); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// planRemainingFile decides what to do with a file the remaining options
+// generate differently. The wiring files are merged; other files (example
+// components, the README) are replaced only if the user hasn't edited them.
+func (p *RemovePlan) planRemainingFile(fsys vfs.FS, m *manifest.Manifest, path, rel string, old, generated []byte) (UpgradeFile, error) {
+	if old == nil {
+		return planAddFile(fsys, path, rel, nil, generated)
+	}
+	f := UpgradeFile{Path: rel}
+	current, err := fsys.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		f.Status = UpgradeDeleted
+		return f, nil
+	case err != nil:
+		return f, err
+	case isWiringFile(rel):
+		base, err := manifest.ReadBase(fsys, p.projectPath, rel)
+		if err != nil {
+			base = old
+		}
+		return planMerge(f, base, current, generated, "frontforge remove"), nil
+	case bytes.Equal(current, generated):
+		f.Status = UpgradeUnchanged
+	case m.Owned(rel, current):
+		f.Status, f.data = UpgradeUpdated, generated
+	default:
+		f.Status = UpgradeEdited // May still use the option
+	}
+	return f, nil
+}
+
+// planPackageJSON picks the owned dependencies and scripts package.json
+// still has that the remaining options don't need. Scripts the user changed
+// stay.
+func (p *RemovePlan) planPackageJSON(fsys vfs.FS, deps, devDeps, scripts map[string]bool) error {
+	var current PackageJSON
+	data, err := fsys.ReadFile(filepath.Join(p.projectPath, "package.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read package.json: %w", err)
+	}
+	if err := json.Unmarshal(data, &current); err != nil {
+		return fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	old, remaining := GeneratePackageJSON(p.From), GeneratePackageJSON(p.To)
+	for _, name := range sortedKeys(deps) {
+		if _, ok := current.Dependencies[name]; ok && remaining.Dependencies[name] == "" {
+			p.Dependencies = append(p.Dependencies, name)
+		}
+	}
+	for _, name := range sortedKeys(devDeps) {
+		if _, ok := current.DevDependencies[name]; ok && remaining.DevDependencies[name] == "" {
+			p.DevDependencies = append(p.DevDependencies, name)
+		}
+	}
+	for _, name := range sortedKeys(scripts) {
+		if value, ok := current.Scripts[name]; ok && value == old.Scripts[name] && remaining.Scripts[name] == "" {
+			p.Scripts = append(p.Scripts, name)
+		}
+	}
+	return nil
+}
+
+// Changed returns the number of files written or removed, counting package.json
+func (p *RemovePlan) Changed() int {
+	n := countWrites(p.Files)
+	if len(p.Dependencies)+len(p.DevDependencies)+len(p.Scripts) > 0 {
+		n++
+	}
+	return n
+}
+
+// Conflicts returns the number of files merged with conflict markers
+func (p *RemovePlan) Conflicts() int {
+	return countConflicts(p.Files)
+}
+
+// Edited returns the owned files left in place because the user changed them
+func (p *RemovePlan) Edited() []string {
+	var edited []string
+	for _, f := range p.Files {
+		if f.Status == UpgradeEdited {
+			edited = append(edited, f.Path)
+		}
+	}
+	return edited
+}
+
+// Apply removes and writes the planned files and package.json entries
+// through fsys, and updates the manifest
+func (p *RemovePlan) Apply(fsys vfs.FS) error {
+	if err := writePlanned(fsys, p.projectPath, p.Files); err != nil {
+		return err
+	}
+	if p.Changed() > countWrites(p.Files) {
+		path := filepath.Join(p.projectPath, "package.json")
+		data, err := fsys.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read package.json: %w", err)
+		}
+		pruned, err := prunePackageJSON(data, map[string][]string{
+			"dependencies":    p.Dependencies,
+			"devDependencies": p.DevDependencies,
+			"scripts":         p.Scripts,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update package.json: %w", err)
+		}
+		if err := fsys.WriteFile(path, pruned, 0644); err != nil {
+			return fmt.Errorf("failed to write package.json: %w", err)
+		}
+	}
+	return writeState(fsys, p.projectPath, p.rendered)
+}
+
+// Commit applies the plan to the project on disk in one transaction
+func (p *RemovePlan) Commit() error {
+	return commitPlan(p.projectPath, p.Apply)
+}
+
+// prunePackageJSON deletes names from the sections of a package.json,
+// keeping the order of everything else
+func prunePackageJSON(data []byte, sections map[string][]string) ([]byte, error) {
+	value, err := decodeOrdered(data)
+	if err != nil {
+		return nil, err
+	}
+	pkg, ok := value.(*orderedObject)
+	if !ok {
+		return nil, fmt.Errorf("package.json is not an object")
+	}
+	for section, names := range sections {
+		obj, ok := pkg.values[section].(*orderedObject)
+		if !ok {
+			continue
+		}
+		for _, name := range names {
+			obj.remove(name)
+		}
+	}
+
+	var compact bytes.Buffer
+	if err := encodeOrdered(&compact, pkg); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	if bytes.HasSuffix(data, []byte("\n")) {
+		out.WriteByte('\n')
+	}
+	return out.Bytes(), nil
+}
+
+// remove deletes a key from the object
+func (o *orderedObject) remove(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// optionOwners works out what each selected option of config brings into
+// the project: the generated files (of files, relative to projectPath),
+// dependencies and scripts that disappear when the option is set back to the
+// one that adds nothing
+func optionOwners(config models.Config, projectPath string, files []string) (map[string]*manifest.Ownership, error) {
+	owners := make(map[string]*manifest.Ownership)
+	pkg := GeneratePackageJSON(config)
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		if fixedGroups[g.Key] || !g.AppliesTo(config.Framework) {
+			continue
+		}
+		without := config
+		*g.Field(&without) = neutralOption(g, config.Framework)
+		if *g.Field(&without) == *g.Field(&config) {
+			continue
+		}
+
+		rendered := vfs.NewMemory()
+		if err := rendered.MkdirAll(projectPath); err != nil {
+			return nil, err
+		}
+		if err := writeProjectFiles(rendered, projectPath, without); err != nil {
+			return nil, fmt.Errorf("failed to render the project without its %s: %w", g.Name, err)
+		}
+		o := &manifest.Ownership{}
+		for _, rel := range files {
+			if !strings.HasPrefix(rel, vfs.StateDir+"/") && !vfs.Exists(rendered, filepath.Join(projectPath, filepath.FromSlash(rel))) {
+				o.Files = append(o.Files, rel)
+			}
+		}
+		pkgWithout := GeneratePackageJSON(without)
+		o.Dependencies = addedKeys(pkg.Dependencies, pkgWithout.Dependencies)
+		o.DevDependencies = addedKeys(pkg.DevDependencies, pkgWithout.DevDependencies)
+		o.Scripts = addedKeys(pkg.Scripts, pkgWithout.Scripts)
+		if len(o.Files)+len(o.Dependencies)+len(o.DevDependencies)+len(o.Scripts) > 0 {
+			sort.Strings(o.Files)
+			owners[g.Key] = o
+		}
+	}
+	return owners, nil
+}
+
+// addedKeys returns the sorted keys of with that without lacks
+func addedKeys(with, without map[string]string) []string {
+	var added []string
+	for key := range with {
+		if _, ok := without[key]; !ok {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	return added
+}
+
+func addAll(set map[string]bool, names []string) {
+	for _, name := range names {
+		set[name] = true
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
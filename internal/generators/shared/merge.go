@@ -3,16 +3,16 @@ package shared
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	"frontforge/internal/vfs"
 	"path/filepath"
 )
 
 // MergePackageJSON reads existing package.json at dir, merges new deps/devDeps/scripts
 // without overwriting existing entries. Writes back.
-func MergePackageJSON(dir string, deps, devDeps, scripts map[string]string) error {
+func MergePackageJSON(fsys vfs.FS, dir string, deps, devDeps, scripts map[string]string) error {
 	pkgPath := filepath.Join(dir, "package.json")
 
-	data, err := os.ReadFile(pkgPath)
+	data, err := fsys.ReadFile(pkgPath)
 	if err != nil {
 		return fmt.Errorf("failed to read package.json: %w", err)
 	}
@@ -60,12 +60,12 @@ func MergePackageJSON(dir string, deps, devDeps, scripts map[string]string) erro
 		return fmt.Errorf("failed to marshal package.json: %w", err)
 	}
 
-	return os.WriteFile(pkgPath, out, 0644)
+	return fsys.WriteFile(pkgPath, out, 0644)
 }
 
 // AddNpmScripts adds scripts to existing package.json (won't overwrite existing keys).
-func AddNpmScripts(dir string, scripts map[string]string) error {
-	return MergePackageJSON(dir, nil, nil, scripts)
+func AddNpmScripts(fsys vfs.FS, dir string, scripts map[string]string) error {
+	return MergePackageJSON(fsys, dir, nil, nil, scripts)
 }
 
 func getOrCreateMap(pkg map[string]interface{}, key string) map[string]interface{} {
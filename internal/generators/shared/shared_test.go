@@ -2,6 +2,7 @@ package shared
 
 import (
 	"encoding/json"
+	"frontforge/internal/vfs"
 	"os"
 	"path/filepath"
 	"testing"
@@ -138,7 +139,7 @@ func TestMergePackageJSON(t *testing.T) {
 			dir := t.TempDir()
 			writePackageJSON(t, dir, tt.existing)
 
-			err := MergePackageJSON(dir, tt.deps, tt.devDeps, tt.scripts)
+			err := MergePackageJSON(vfs.Disk{}, dir, tt.deps, tt.devDeps, tt.scripts)
 			if err != nil {
 				t.Fatalf("MergePackageJSON returned error: %v", err)
 			}
@@ -177,7 +178,7 @@ func TestMergePackageJSON(t *testing.T) {
 
 func TestMergePackageJSON_MissingFile(t *testing.T) {
 	dir := t.TempDir()
-	err := MergePackageJSON(dir, map[string]string{"react": "^19.0.0"}, nil, nil)
+	err := MergePackageJSON(vfs.Disk{}, dir, map[string]string{"react": "^19.0.0"}, nil, nil)
 	if err == nil {
 		t.Fatal("expected error for missing package.json, got nil")
 	}
@@ -188,7 +189,7 @@ func TestMergePackageJSON_InvalidJSON(t *testing.T) {
 	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte("{invalid"), 0644); err != nil {
 		t.Fatal(err)
 	}
-	err := MergePackageJSON(dir, map[string]string{"react": "^19.0.0"}, nil, nil)
+	err := MergePackageJSON(vfs.Disk{}, dir, map[string]string{"react": "^19.0.0"}, nil, nil)
 	if err == nil {
 		t.Fatal("expected error for invalid JSON, got nil")
 	}
@@ -229,7 +230,7 @@ func TestAddNpmScripts(t *testing.T) {
 			dir := t.TempDir()
 			writePackageJSON(t, dir, tt.existing)
 
-			err := AddNpmScripts(dir, tt.scripts)
+			err := AddNpmScripts(vfs.Disk{}, dir, tt.scripts)
 			if err != nil {
 				t.Fatalf("AddNpmScripts returned error: %v", err)
 			}
@@ -293,7 +294,7 @@ func TestScaffoldVitest(t *testing.T) {
 				"name": "test-" + tt.framework,
 			})
 
-			err := ScaffoldVitest(dir, tt.framework)
+			err := ScaffoldVitest(vfs.Disk{}, dir, tt.framework)
 			if err != nil {
 				t.Fatalf("ScaffoldVitest returned error: %v", err)
 			}
@@ -382,7 +383,7 @@ func TestScaffoldFeatureStructure(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			dir := t.TempDir()
 
-			err := ScaffoldFeatureStructure(dir, tt.framework)
+			err := ScaffoldFeatureStructure(vfs.Disk{}, dir, tt.framework)
 			if err != nil {
 				t.Fatalf("ScaffoldFeatureStructure returned error: %v", err)
 			}
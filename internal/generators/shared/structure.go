@@ -1,13 +1,13 @@
 package shared
 
 import (
-	"os"
+	"frontforge/internal/vfs"
 	"path/filepath"
 )
 
 // ScaffoldFeatureStructure creates feature-based directory layout.
 // Adapts to framework (e.g., Next.js uses app/, Astro uses src/pages/).
-func ScaffoldFeatureStructure(dir string, framework string) error {
+func ScaffoldFeatureStructure(fsys vfs.FS, dir string, framework string) error {
 	var dirs []string
 
 	switch framework {
@@ -33,7 +33,7 @@ func ScaffoldFeatureStructure(dir string, framework string) error {
 	}
 
 	for _, d := range dirs {
-		if err := os.MkdirAll(d, 0755); err != nil {
+		if err := fsys.MkdirAll(d); err != nil {
 			return err
 		}
 	}
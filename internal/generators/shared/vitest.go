@@ -2,38 +2,39 @@ package shared
 
 import (
 	"fmt"
-	"os"
+	"frontforge/internal/versions"
+	"frontforge/internal/vfs"
 	"path/filepath"
 )
 
 // ScaffoldVitest creates vitest.config.ts and test setup files.
 // framework should be "nextjs", "sveltekit", or "astro".
-func ScaffoldVitest(dir string, framework string) error {
+func ScaffoldVitest(fsys vfs.FS, dir string, framework string) error {
 	ext := "ts"
 
 	// Create vitest config
 	config := generateVitestConfig(framework)
-	if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("vitest.config.%s", ext)), []byte(config), 0644); err != nil {
+	if err := vfs.WriteString(fsys, filepath.Join(dir, fmt.Sprintf("vitest.config.%s", ext)), config); err != nil {
 		return fmt.Errorf("failed to write vitest config: %w", err)
 	}
 
 	// Create test directory
 	testDir := filepath.Join(dir, "src", "test")
-	if err := os.MkdirAll(testDir, 0755); err != nil {
+	if err := fsys.MkdirAll(testDir); err != nil {
 		return fmt.Errorf("failed to create test directory: %w", err)
 	}
 
 	// Create setup file
 	setup := generateVitestSetup()
-	if err := os.WriteFile(filepath.Join(testDir, fmt.Sprintf("setup.%s", ext)), []byte(setup), 0644); err != nil {
+	if err := vfs.WriteString(fsys, filepath.Join(testDir, fmt.Sprintf("setup.%s", ext)), setup); err != nil {
 		return fmt.Errorf("failed to write vitest setup: %w", err)
 	}
 
 	// Add test deps to package.json
 	devDeps := map[string]string{
-		"vitest":                    "^4.0.18",
-		"@testing-library/jest-dom": "^6.9.1",
-		"jsdom":                     "^28.1.0",
+		"vitest":                    versions.Version("vitest"),
+		"@testing-library/jest-dom": versions.Version("@testing-library/jest-dom"),
+		"jsdom":                     versions.Version("jsdom"),
 	}
 
 	scripts := map[string]string{
@@ -42,13 +43,13 @@ func ScaffoldVitest(dir string, framework string) error {
 
 	switch framework {
 	case "nextjs":
-		devDeps["@testing-library/react"] = "^16.3.2"
-		devDeps["@vitejs/plugin-react"] = "^5.1.4"
+		devDeps["@testing-library/react"] = versions.Version("@testing-library/react")
+		devDeps["@vitejs/plugin-react"] = versions.Version("@vitejs/plugin-react")
 	case "sveltekit":
-		devDeps["@testing-library/svelte"] = "^5.3.1"
+		devDeps["@testing-library/svelte"] = versions.Version("@testing-library/svelte")
 	}
 
-	return MergePackageJSON(dir, nil, devDeps, scripts)
+	return MergePackageJSON(fsys, dir, nil, devDeps, scripts)
 }
 
 func generateVitestConfig(framework string) string {
@@ -1,9 +1,12 @@
 package sveltekit
 
 import (
+	"context"
 	"frontforge/internal/generators/meta"
 	"frontforge/internal/generators/shared"
 	"frontforge/internal/models"
+	"frontforge/internal/versions"
+	"frontforge/internal/vfs"
 )
 
 func init() {
@@ -13,10 +16,10 @@ func init() {
 // Generator implements meta.MetaGenerator for SvelteKit.
 type Generator struct{}
 
-func (g *Generator) Scaffold(cfg models.Config) error {
+func (g *Generator) Scaffold(ctx context.Context, cfg models.Config) error {
 	// Step 1: Create project with sv create
 	args := buildCreateArgs(cfg)
-	if err := meta.ExecScaffold(models.FrameworkSvelteKit, cfg.DryRun, "npx", args...); err != nil {
+	if err := meta.ExecScaffold(ctx, models.FrameworkSvelteKit, cfg.DryRun, "npx", args...); err != nil {
 		return err
 	}
 
@@ -25,7 +28,7 @@ func (g *Generator) Scaffold(cfg models.Config) error {
 	if len(addOns) > 0 {
 		svArgs := []string{"sv", "add"}
 		svArgs = append(svArgs, addOns...)
-		if err := meta.ExecInDir(cfg.ProjectPath, models.FrameworkSvelteKit, cfg.DryRun, "npx", svArgs...); err != nil {
+		if err := meta.ExecInDir(ctx, cfg.ProjectPath, models.FrameworkSvelteKit, cfg.DryRun, "npx", svArgs...); err != nil {
 			return err
 		}
 	}
@@ -33,7 +36,7 @@ func (g *Generator) Scaffold(cfg models.Config) error {
 	return nil
 }
 
-func (g *Generator) PostScaffold(cfg models.Config) error {
+func (g *Generator) PostScaffold(ctx context.Context, fsys vfs.FS, cfg models.Config) error {
 	dir := cfg.ProjectPath
 
 	deps := make(map[string]string)
@@ -41,44 +44,46 @@ func (g *Generator) PostScaffold(cfg models.Config) error {
 	scripts := make(map[string]string)
 
 	// ESLint
-	devDeps["eslint"] = "^9.39.1"
-	devDeps["@eslint/js"] = "^9.39.1"
-	devDeps["globals"] = "^15.15.0"
-	devDeps["typescript-eslint"] = "^8.56.1"
+	devDeps["eslint"] = versions.Version("eslint")
+	devDeps["@eslint/js"] = versions.Version("@eslint/js")
+	devDeps["globals"] = versions.Version("globals")
+	devDeps["typescript-eslint"] = versions.Version("typescript-eslint")
 	scripts["lint"] = "eslint ."
 
-	// State management
-	if cfg.StateManagement == models.StateSvelteStores {
-		// Built-in, no extra deps needed
-	}
-
-	// Data fetching
-	switch cfg.DataFetching {
-	case models.DataTanStackQuery:
-		deps["@tanstack/svelte-query"] = "^6.0.18"
-	case models.DataAxios:
-		deps["axios"] = "^1.13.5"
+	// State management and data fetching libraries come from the option
+	// registry; Svelte stores are built in
+	for _, p := range models.SelectedPackages(cfg, "stateManagement", "dataFetching") {
+		if p.Dev {
+			devDeps[p.Name] = p.Version()
+		} else {
+			deps[p.Name] = p.Version()
+		}
 	}
 
 	if len(deps) > 0 || len(devDeps) > 0 || len(scripts) > 0 {
-		if err := shared.MergePackageJSON(dir, deps, devDeps, scripts); err != nil {
+		if err := shared.MergePackageJSON(fsys, dir, deps, devDeps, scripts); err != nil {
 			return err
 		}
 	}
 
 	// Feature-based structure
 	if cfg.Structure == models.StructureFeatureBased {
-		if err := shared.ScaffoldFeatureStructure(dir, "sveltekit"); err != nil {
+		if err := shared.ScaffoldFeatureStructure(fsys, dir, "sveltekit"); err != nil {
 			return err
 		}
 	}
 
-	// Install dependencies
+	// Install dependencies where the files actually are; there's nothing to
+	// install into when fsys isn't on disk
+	installDir, onDisk := vfs.HostPath(fsys, dir)
+	if !onDisk && !cfg.DryRun {
+		return nil
+	}
 	installCmd := cfg.PackageManager
 	if installCmd == "" {
 		installCmd = "npm"
 	}
-	return meta.ExecInDir(dir, models.FrameworkSvelteKit, cfg.DryRun, installCmd, "install")
+	return meta.ExecInDir(ctx, installDir, models.FrameworkSvelteKit, cfg.DryRun, installCmd, "install")
 }
 
 func (g *Generator) SupportedOptions() meta.OptionMatrix {
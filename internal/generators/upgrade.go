@@ -0,0 +1,349 @@
+package generators
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/textdiff"
+	"frontforge/internal/vfs"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Upgrade statuses of a file
+const (
+	UpgradeAdded     = "added"     // New in the current templates
+	UpgradeUpdated   = "updated"   // Not edited since generation; replaced
+	UpgradeMerged    = "merged"    // Edited; template changes merged in cleanly
+	UpgradeConflict  = "conflict"  // Edited; merged with conflict markers
+	UpgradeUnchanged = "unchanged" // Nothing to do
+	UpgradeDeleted   = "deleted"   // Deleted since generation; left deleted
+	UpgradeObsolete  = "obsolete"  // No longer generated; left in place
+	UpgradeKept      = "kept"      // Already exists with other content; left alone
+	UpgradeRemoved   = "removed"   // Brought in by a removed option; deleted
+	UpgradeEdited    = "edited"    // Edited; left in place by remove
+)
+
+// UpgradeFile is what an upgrade does to one file
+type UpgradeFile struct {
+	Path      string `json:"path"` // Relative to the project root
+	Status    string `json:"status"`
+	Conflicts int    `json:"conflicts,omitempty"` // Regions between conflict markers
+
+	data []byte // Content to write; nil leaves the file alone
+}
+
+// UpgradePlan is a project re-rendered with the current templates and merged
+// with the files on disk. Nothing is written until Commit.
+type UpgradePlan struct {
+	From  string // frontforge version the project was generated with
+	To    string // Running frontforge version
+	Files []UpgradeFile
+
+	projectPath string
+	rendered    *vfs.Memory // New generation, including the manifest and base copies
+}
+
+// PlanUpgrade regenerates the project in projectPath from the config in its
+// manifest and works out how to carry the changes into the current files.
+// Files the user hasn't edited are replaced, edited files are three-way
+// merged against the content they were generated with, and package.json
+// dependency ranges the user hasn't changed are bumped to the catalog's.
+func PlanUpgrade(fsys vfs.FS, projectPath string) (*UpgradePlan, error) {
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	m, err := manifest.Load(fsys, projectPath)
+	if err != nil {
+		return nil, err
+	}
+	if models.IsMetaFramework(m.Config.Framework) {
+		return nil, fmt.Errorf("upgrading %s projects is not supported: their files come from the upstream CLI", m.Config.Framework)
+	}
+
+	config := m.Config
+	config.ProjectPath = projectPath
+	config.ResolveLatest = false // Ranges come from the catalog
+	rendered := vfs.NewMemory()
+	result, err := GenerateFS(rendered, config, io.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to regenerate the project: %w", err)
+	}
+
+	p := &UpgradePlan{From: m.Tool, To: manifest.Tool(), projectPath: projectPath, rendered: rendered}
+	generated := make(map[string]bool)
+	for _, rel := range result.Files {
+		if strings.HasPrefix(rel, vfs.StateDir+"/") {
+			continue
+		}
+		generated[rel] = true
+		data, err := rendered.ReadFile(filepath.Join(projectPath, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, err
+		}
+		f, err := p.planFile(fsys, m, rel, data)
+		if err != nil {
+			return nil, err
+		}
+		p.Files = append(p.Files, f)
+	}
+
+	// Files the templates no longer produce stay; they may be in use
+	var obsolete []string
+	for rel := range m.Files {
+		if !generated[rel] && vfs.Exists(fsys, filepath.Join(projectPath, filepath.FromSlash(rel))) {
+			obsolete = append(obsolete, rel)
+		}
+	}
+	sort.Strings(obsolete)
+	for _, rel := range obsolete {
+		p.Files = append(p.Files, UpgradeFile{Path: rel, Status: UpgradeObsolete})
+	}
+	return p, nil
+}
+
+// planFile decides what to do with one regenerated file
+func (p *UpgradePlan) planFile(fsys vfs.FS, m *manifest.Manifest, rel string, generated []byte) (UpgradeFile, error) {
+	f := UpgradeFile{Path: rel}
+	current, err := fsys.ReadFile(filepath.Join(p.projectPath, filepath.FromSlash(rel)))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		if _, ok := m.Files[rel]; ok {
+			f.Status = UpgradeDeleted
+		} else {
+			f.Status, f.data = UpgradeAdded, generated
+		}
+		return f, nil
+	case err != nil:
+		return f, err
+	}
+
+	// The merge base is the content the file was generated with; files the
+	// last generation didn't write have none
+	base, err := manifest.ReadBase(fsys, p.projectPath, rel)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return f, err
+	}
+	return planMerge(f, base, current, generated, "frontforge "+p.To), nil
+}
+
+// planMerge decides how to carry the change from base to generated into the
+// current content of a file. Files the user hasn't edited take the generated
+// content; edited ones are merged, with theirs labelling the generated side
+// of conflicts.
+func planMerge(f UpgradeFile, base, current, generated []byte, theirs string) UpgradeFile {
+	switch {
+	case bytes.Equal(current, generated):
+		f.Status = UpgradeUnchanged
+		return f
+	case base != nil && bytes.Equal(base, generated):
+		f.Status = UpgradeUnchanged // Only the user changed it
+		return f
+	case base != nil && bytes.Equal(base, current):
+		f.Status, f.data = UpgradeUpdated, generated
+		return f
+	}
+
+	merged, conflicts := mergeContent(f.Path, base, current, generated, theirs)
+	switch {
+	case bytes.Equal(merged, current):
+		f.Status = UpgradeUnchanged
+	case conflicts > 0:
+		f.Status, f.data, f.Conflicts = UpgradeConflict, merged, conflicts
+	default:
+		f.Status, f.data = UpgradeMerged, merged
+	}
+	return f
+}
+
+// mergeContent combines the user's edits with the template changes. JSON
+// files merge by key, so dependency ranges the user left alone take the new
+// version; everything else merges by line. Without a base every difference
+// is a conflict.
+func mergeContent(rel string, base, current, generated []byte, theirs string) ([]byte, int) {
+	if CanMerge(rel) && filepath.Base(rel) != ".gitignore" {
+		if merged, err := merge3JSON(base, current, generated); err == nil {
+			return merged, 0
+		}
+	}
+	merged, conflicts := textdiff.Merge3(string(base), string(current), string(generated), "current", theirs)
+	return []byte(merged), conflicts
+}
+
+// merge3JSON merges generated into current key by key. Values the user
+// hasn't changed from base take the generated value, keys new to the
+// templates are appended, and keys the user removed stay removed.
+func merge3JSON(base, current, generated []byte) ([]byte, error) {
+	var b interface{}
+	if base != nil {
+		var err error
+		if b, err = decodeOrdered(base); err != nil {
+			return nil, err
+		}
+	}
+	c, err := decodeOrdered(current)
+	if err != nil {
+		return nil, err
+	}
+	g, err := decodeOrdered(generated)
+	if err != nil {
+		return nil, err
+	}
+
+	var compact bytes.Buffer
+	if err := encodeOrdered(&compact, merge3Values(b, c, g)); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	if bytes.HasSuffix(current, []byte("\n")) {
+		out.WriteByte('\n')
+	}
+	return out.Bytes(), nil
+}
+
+func merge3Values(base, current, generated interface{}) interface{} {
+	c, currentIsObject := current.(*orderedObject)
+	g, generatedIsObject := generated.(*orderedObject)
+	if currentIsObject && generatedIsObject {
+		b, _ := base.(*orderedObject)
+		for _, key := range g.keys {
+			var baseValue interface{}
+			inBase := false
+			if b != nil {
+				baseValue, inBase = b.values[key]
+			}
+			if value, ok := c.values[key]; ok {
+				c.values[key] = merge3Values(baseValue, value, g.values[key])
+			} else if !inBase {
+				c.keys = append(c.keys, key)
+				c.values[key] = g.values[key]
+			}
+		}
+		return c
+	}
+	if base != nil && reflect.DeepEqual(base, current) {
+		return generated
+	}
+	return current
+}
+
+// Changed returns the number of files the upgrade writes
+func (p *UpgradePlan) Changed() int {
+	return countWrites(p.Files)
+}
+
+// Conflicts returns the number of files merged with conflict markers
+func (p *UpgradePlan) Conflicts() int {
+	return countConflicts(p.Files)
+}
+
+// Apply writes the upgraded files and the new manifest and base copies
+// through fsys
+func (p *UpgradePlan) Apply(fsys vfs.FS) error {
+	if err := writePlanned(fsys, p.projectPath, p.Files); err != nil {
+		return err
+	}
+	return writeState(fsys, p.projectPath, p.rendered)
+}
+
+// Commit applies the upgrade to the project on disk in one transaction
+func (p *UpgradePlan) Commit() error {
+	return commitPlan(p.projectPath, p.Apply)
+}
+
+// countWrites returns the number of planned files with content to write or
+// to remove
+func countWrites(files []UpgradeFile) int {
+	n := 0
+	for _, f := range files {
+		if f.data != nil || f.Status == UpgradeRemoved {
+			n++
+		}
+	}
+	return n
+}
+
+// countConflicts returns the number of planned files with conflict markers
+func countConflicts(files []UpgradeFile) int {
+	n := 0
+	for _, f := range files {
+		if f.Status == UpgradeConflict {
+			n++
+		}
+	}
+	return n
+}
+
+// writePlanned writes the planned files that have content and removes the
+// ones marked removed
+func writePlanned(fsys vfs.FS, projectPath string, files []UpgradeFile) error {
+	for _, f := range files {
+		path := filepath.Join(projectPath, filepath.FromSlash(f.Path))
+		if f.Status == UpgradeRemoved {
+			if err := vfs.Remove(fsys, path); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", f.Path, err)
+			}
+			continue
+		}
+		if f.data == nil {
+			continue
+		}
+		if err := fsys.MkdirAll(filepath.Dir(path)); err != nil {
+			return err
+		}
+		if err := fsys.WriteFile(path, f.data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.Path, err)
+		}
+	}
+	return nil
+}
+
+// writeState copies the manifest and base copies of a generation rendered
+// in memory, so they describe it from now on
+func writeState(fsys vfs.FS, projectPath string, rendered *vfs.Memory) error {
+	stateDir := filepath.Join(projectPath, vfs.StateDir)
+	for _, path := range rendered.Files() {
+		if !strings.HasPrefix(path, stateDir+string(filepath.Separator)) {
+			continue
+		}
+		data, err := rendered.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := fsys.MkdirAll(filepath.Dir(path)); err != nil {
+			return err
+		}
+		if err := fsys.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to update the manifest: %w", err)
+		}
+	}
+	return nil
+}
+
+// commitPlan runs apply in a transaction on the project, so the files change
+// all at once or not at all
+func commitPlan(projectPath string, apply func(vfs.FS) error) error {
+	tx, err := vfs.Begin(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to stage changes: %w", err)
+	}
+	if err := apply(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to move the changed files into place: %w", err)
+	}
+	return nil
+}
@@ -4,49 +4,72 @@ import (
 	"encoding/json"
 	"fmt"
 	"frontforge/internal/models"
-	"os"
+	"frontforge/internal/vfs"
 	"path/filepath"
 	"strings"
 )
 
+// Check IDs group validation results and doctor checks. They are stable
+// across releases, so scripts can match on them.
+const (
+	CheckCoreFiles     = "core-files"
+	CheckPackageJSON   = "package-json"
+	CheckScripts       = "scripts"
+	CheckIndexHTML     = "index-html"
+	CheckViteConfig    = "vite-config"
+	CheckTSConfig      = "tsconfig"
+	CheckESLintPlugins = "eslint-plugins"
+	CheckImports       = "imports"
+	CheckManifestDrift = "manifest-drift"
+)
+
 // ValidationResult represents the result of a single validation check
 type ValidationResult struct {
-	Check   string // Name of the check
-	Passed  bool
-	Message string // Details about the failure
+	ID      string `json:"id"`    // One of the Check* IDs; several results can share one
+	Check   string `json:"check"` // Name of the check
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"` // Details about the failure
+	Output  string `json:"output,omitempty"`  // Trimmed command output, for -verify steps
 }
 
-// ValidateProject performs post-generation validation checks
+// ValidateProject performs post-generation validation checks on disk
 func ValidateProject(projectPath string, config models.Config) []ValidationResult {
-	var results []ValidationResult
-
 	// Skip validation in dry-run mode
 	if config.DryRun {
-		return results
+		return nil
 	}
+	return ValidateFS(vfs.Disk{}, projectPath, config)
+}
+
+// ValidateFS performs post-generation validation checks against fsys
+func ValidateFS(fsys vfs.FS, projectPath string, config models.Config) []ValidationResult {
+	var results []ValidationResult
 
 	// 1. Check all expected core files exist and are non-empty
-	results = append(results, validateCoreFiles(projectPath, config)...)
+	results = append(results, validateCoreFiles(fsys, projectPath, config)...)
 
 	// 2. Validate package.json structure
-	results = append(results, validatePackageJSON(projectPath, config)...)
+	results = append(results, validatePackageJSON(fsys, projectPath, config)...)
 
 	// 3. Validate index.html references correct main file
-	results = append(results, validateIndexHTML(projectPath, config)...)
+	results = append(results, validateIndexHTML(fsys, projectPath, config)...)
 
 	// 4. Validate Vite config exists
-	results = append(results, validateViteConfig(projectPath, config)...)
+	results = append(results, validateViteConfig(fsys, projectPath, config)...)
 
 	// 5. Validate TypeScript configs when language is TypeScript
 	if config.Language == models.LangTypeScript {
-		results = append(results, validateTypeScriptConfigs(projectPath)...)
+		results = append(results, validateTypeScriptConfigs(fsys, projectPath)...)
 	}
 
+	// 6. Validate that imports resolve to dependencies and generated files
+	results = append(results, validateImports(fsys, projectPath)...)
+
 	return results
 }
 
 // validateCoreFiles checks that all expected files exist and are non-empty
-func validateCoreFiles(projectPath string, config models.Config) []ValidationResult {
+func validateCoreFiles(fsys vfs.FS, projectPath string, config models.Config) []ValidationResult {
 	var results []ValidationResult
 
 	expectedFiles := getCoreFiles(config)
@@ -55,9 +78,10 @@ func validateCoreFiles(projectPath string, config models.Config) []ValidationRes
 		fullPath := filepath.Join(projectPath, relPath)
 		check := fmt.Sprintf("File exists: %s", relPath)
 
-		info, err := os.Stat(fullPath)
+		info, err := fsys.Stat(fullPath)
 		if err != nil {
 			results = append(results, ValidationResult{
+				ID:      CheckCoreFiles,
 				Check:   check,
 				Passed:  false,
 				Message: "file does not exist",
@@ -67,6 +91,7 @@ func validateCoreFiles(projectPath string, config models.Config) []ValidationRes
 
 		if info.IsDir() {
 			results = append(results, ValidationResult{
+				ID:      CheckCoreFiles,
 				Check:   check,
 				Passed:  false,
 				Message: "expected file, found directory",
@@ -76,6 +101,7 @@ func validateCoreFiles(projectPath string, config models.Config) []ValidationRes
 
 		if info.Size() == 0 {
 			results = append(results, ValidationResult{
+				ID:      CheckCoreFiles,
 				Check:   check,
 				Passed:  false,
 				Message: "file is empty",
@@ -84,6 +110,7 @@ func validateCoreFiles(projectPath string, config models.Config) []ValidationRes
 		}
 
 		results = append(results, ValidationResult{
+			ID:     CheckCoreFiles,
 			Check:  check,
 			Passed: true,
 		})
@@ -93,14 +120,15 @@ func validateCoreFiles(projectPath string, config models.Config) []ValidationRes
 }
 
 // validatePackageJSON checks that package.json is valid and has required fields
-func validatePackageJSON(projectPath string, config models.Config) []ValidationResult {
+func validatePackageJSON(fsys vfs.FS, projectPath string, config models.Config) []ValidationResult {
 	var results []ValidationResult
 	packageJSONPath := filepath.Join(projectPath, "package.json")
 
 	// Read and parse package.json
-	data, err := os.ReadFile(packageJSONPath)
+	data, err := fsys.ReadFile(packageJSONPath)
 	if err != nil {
 		results = append(results, ValidationResult{
+			ID:      CheckPackageJSON,
 			Check:   "package.json: readable",
 			Passed:  false,
 			Message: err.Error(),
@@ -111,6 +139,7 @@ func validatePackageJSON(projectPath string, config models.Config) []ValidationR
 	var pkg map[string]interface{}
 	if err := json.Unmarshal(data, &pkg); err != nil {
 		results = append(results, ValidationResult{
+			ID:      CheckPackageJSON,
 			Check:   "package.json: valid JSON",
 			Passed:  false,
 			Message: err.Error(),
@@ -119,6 +148,7 @@ func validatePackageJSON(projectPath string, config models.Config) []ValidationR
 	}
 
 	results = append(results, ValidationResult{
+		ID:     CheckPackageJSON,
 		Check:  "package.json: valid JSON",
 		Passed: true,
 	})
@@ -129,12 +159,14 @@ func validatePackageJSON(projectPath string, config models.Config) []ValidationR
 		check := fmt.Sprintf("package.json: has '%s' field", field)
 		if _, exists := pkg[field]; !exists {
 			results = append(results, ValidationResult{
+				ID:      CheckPackageJSON,
 				Check:   check,
 				Passed:  false,
 				Message: fmt.Sprintf("missing required field: %s", field),
 			})
 		} else {
 			results = append(results, ValidationResult{
+				ID:     CheckPackageJSON,
 				Check:  check,
 				Passed: true,
 			})
@@ -145,12 +177,14 @@ func validatePackageJSON(projectPath string, config models.Config) []ValidationR
 	if scripts, ok := pkg["scripts"].(map[string]interface{}); ok {
 		if _, hasDevScript := scripts["dev"]; !hasDevScript {
 			results = append(results, ValidationResult{
+				ID:      CheckScripts,
 				Check:   "package.json: has 'dev' script",
 				Passed:  false,
 				Message: "missing 'dev' script in package.json",
 			})
 		} else {
 			results = append(results, ValidationResult{
+				ID:     CheckScripts,
 				Check:  "package.json: has 'dev' script",
 				Passed: true,
 			})
@@ -161,13 +195,14 @@ func validatePackageJSON(projectPath string, config models.Config) []ValidationR
 }
 
 // validateIndexHTML checks that index.html references the correct main file
-func validateIndexHTML(projectPath string, config models.Config) []ValidationResult {
+func validateIndexHTML(fsys vfs.FS, projectPath string, config models.Config) []ValidationResult {
 	var results []ValidationResult
 	indexHTMLPath := filepath.Join(projectPath, "index.html")
 
-	data, err := os.ReadFile(indexHTMLPath)
+	data, err := fsys.ReadFile(indexHTMLPath)
 	if err != nil {
 		results = append(results, ValidationResult{
+			ID:      CheckIndexHTML,
 			Check:   "index.html: readable",
 			Passed:  false,
 			Message: err.Error(),
@@ -183,12 +218,14 @@ func validateIndexHTML(projectPath string, config models.Config) []ValidationRes
 
 	if !strings.Contains(content, expectedScript) {
 		results = append(results, ValidationResult{
+			ID:      CheckIndexHTML,
 			Check:   "index.html: references main file",
 			Passed:  false,
 			Message: fmt.Sprintf("does not reference %s", expectedScript),
 		})
 	} else {
 		results = append(results, ValidationResult{
+			ID:     CheckIndexHTML,
 			Check:  "index.html: references main file",
 			Passed: true,
 		})
@@ -196,14 +233,16 @@ func validateIndexHTML(projectPath string, config models.Config) []ValidationRes
 
 	// Check that the referenced main file actually exists
 	mainFilePath := filepath.Join(projectPath, "src", fmt.Sprintf("main.%s", ext))
-	if _, err := os.Stat(mainFilePath); err != nil {
+	if _, err := fsys.Stat(mainFilePath); err != nil {
 		results = append(results, ValidationResult{
+			ID:      CheckIndexHTML,
 			Check:   "Main file exists",
 			Passed:  false,
 			Message: fmt.Sprintf("main.%s does not exist", ext),
 		})
 	} else {
 		results = append(results, ValidationResult{
+			ID:     CheckIndexHTML,
 			Check:  "Main file exists",
 			Passed: true,
 		})
@@ -213,7 +252,7 @@ func validateIndexHTML(projectPath string, config models.Config) []ValidationRes
 }
 
 // validateViteConfig checks that vite.config exists
-func validateViteConfig(projectPath string, config models.Config) []ValidationResult {
+func validateViteConfig(fsys vfs.FS, projectPath string, config models.Config) []ValidationResult {
 	var results []ValidationResult
 
 	ext := "js"
@@ -223,14 +262,16 @@ func validateViteConfig(projectPath string, config models.Config) []ValidationRe
 
 	viteConfigPath := filepath.Join(projectPath, fmt.Sprintf("vite.config.%s", ext))
 
-	if _, err := os.Stat(viteConfigPath); err != nil {
+	if _, err := fsys.Stat(viteConfigPath); err != nil {
 		results = append(results, ValidationResult{
+			ID:      CheckViteConfig,
 			Check:   "vite.config exists",
 			Passed:  false,
 			Message: fmt.Sprintf("vite.config.%s not found", ext),
 		})
 	} else {
 		results = append(results, ValidationResult{
+			ID:     CheckViteConfig,
 			Check:  "vite.config exists",
 			Passed: true,
 		})
@@ -240,7 +281,7 @@ func validateViteConfig(projectPath string, config models.Config) []ValidationRe
 }
 
 // validateTypeScriptConfigs checks that all TypeScript configs exist
-func validateTypeScriptConfigs(projectPath string) []ValidationResult {
+func validateTypeScriptConfigs(fsys vfs.FS, projectPath string) []ValidationResult {
 	var results []ValidationResult
 
 	configs := []string{"tsconfig.json", "tsconfig.app.json", "tsconfig.node.json"}
@@ -249,14 +290,16 @@ func validateTypeScriptConfigs(projectPath string) []ValidationResult {
 		configPath := filepath.Join(projectPath, configFile)
 		check := fmt.Sprintf("TypeScript: %s exists", configFile)
 
-		if _, err := os.Stat(configPath); err != nil {
+		if _, err := fsys.Stat(configPath); err != nil {
 			results = append(results, ValidationResult{
+				ID:      CheckTSConfig,
 				Check:   check,
 				Passed:  false,
 				Message: fmt.Sprintf("%s not found", configFile),
 			})
 		} else {
 			results = append(results, ValidationResult{
+				ID:     CheckTSConfig,
 				Check:  check,
 				Passed: true,
 			})
@@ -282,34 +325,16 @@ func getCoreFiles(config models.Config) []string {
 	ext := getMainFileExtension(config)
 	files = append(files, filepath.Join("src", fmt.Sprintf("main.%s", ext)))
 
-	// Add App file
-	appExt := getAppFileExtension(config)
+	// Add App file, named as SetupProject writes it
 	if config.Framework == models.FrameworkAngular {
-		files = append(files, filepath.Join("src", "app", fmt.Sprintf("app.component.%s", appExt)))
+		files = append(files, filepath.Join("src", "app", "app.component.ts"))
 	} else {
-		files = append(files, filepath.Join("src", fmt.Sprintf("App.%s", appExt)))
+		files = append(files, filepath.Join("src", fmt.Sprintf("App.%s", getFileExtension(config))))
 	}
 
 	return files
 }
 
-// getAppFileExtension returns the file extension for App file based on config
-func getAppFileExtension(config models.Config) string {
-	switch config.Framework {
-	case models.FrameworkVue:
-		return "vue"
-	case models.FrameworkSvelte:
-		return "svelte"
-	case models.FrameworkAngular:
-		return "ts"
-	default:
-		if config.Language == models.LangTypeScript {
-			return "tsx"
-		}
-		return "js"
-	}
-}
-
 // isViteFramework checks if the framework uses Vite as the direct build tool
 func isViteFramework(framework string) bool {
 	return true
@@ -0,0 +1,149 @@
+package generators
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"frontforge/internal/models"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Check IDs of the -verify steps, one per package.json script
+const (
+	CheckTypecheck = "typecheck"
+	CheckLint      = "lint"
+	CheckTest      = "test"
+	CheckBuild     = "build"
+)
+
+// VerifyStep is a package.json script run by Verify
+type VerifyStep struct {
+	ID      string        // Check ID, also the script name
+	Name    string        // Shown in progress and results
+	Timeout time.Duration // Default time limit
+}
+
+// VerifySteps are the steps Verify runs, in order
+var VerifySteps = []VerifyStep{
+	{ID: CheckTypecheck, Name: "Type check", Timeout: 2 * time.Minute},
+	{ID: CheckLint, Name: "Lint", Timeout: 2 * time.Minute},
+	{ID: CheckTest, Name: "Tests", Timeout: 5 * time.Minute},
+	{ID: CheckBuild, Name: "Build", Timeout: 5 * time.Minute},
+}
+
+// Limits on the output kept in a verification result
+const (
+	maxVerifyLines = 40
+	maxVerifyBytes = 4096
+)
+
+// ansiEscape matches terminal color and cursor sequences
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// Verify runs the project's typecheck, lint, test and build scripts with the
+// package manager, once dependencies are installed. Steps without a script
+// are skipped. Each step that runs becomes a ValidationResult whose Output
+// is the tail of what it printed. timeout overrides the steps' defaults when
+// positive; progress, if not nil, is called before each step.
+func Verify(ctx context.Context, projectPath string, config models.Config, timeout time.Duration, progress func(VerifyStep)) []ValidationResult {
+	var pkg PackageJSON
+	data, err := os.ReadFile(filepath.Join(projectPath, "package.json"))
+	if err == nil {
+		err = json.Unmarshal(data, &pkg)
+	}
+	if err != nil {
+		return []ValidationResult{{
+			ID:      CheckPackageJSON,
+			Check:   "Verification",
+			Passed:  false,
+			Message: fmt.Sprintf("cannot read package.json: %v", err),
+		}}
+	}
+
+	var results []ValidationResult
+	for _, step := range VerifySteps {
+		script, ok := pkg.Scripts[step.ID]
+		if !ok {
+			continue
+		}
+		if progress != nil {
+			progress(step)
+		}
+		limit := step.Timeout
+		if timeout > 0 {
+			limit = timeout
+		}
+		results = append(results, runVerifyStep(ctx, projectPath, config.PackageManager, step, script, limit))
+	}
+	return results
+}
+
+// runVerifyStep runs one script and captures its combined output
+func runVerifyStep(ctx context.Context, projectPath, pm string, step VerifyStep, script string, limit time.Duration) ValidationResult {
+	result := ValidationResult{ID: step.ID, Check: step.Name}
+
+	// Test runners exit non-zero without tests; a fresh project has none
+	var extra []string
+	if runner := strings.Fields(script); len(runner) > 0 && (runner[0] == "vitest" || runner[0] == "jest") {
+		extra = append(extra, "--passWithNoTests")
+	}
+	args := runScriptArgs(pm, step.ID, extra)
+
+	ctx, cancel := context.WithTimeout(ctx, limit)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = projectPath
+	// CI makes Vitest run once instead of watching, and turns off prompts and colors
+	cmd.Env = append(os.Environ(), "CI=true", "FORCE_COLOR=0", "NO_COLOR=1")
+	cmd.WaitDelay = 5 * time.Second // Don't wait forever on children holding the pipes
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	err := cmd.Run()
+	result.Output = trimOutput(out.String())
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.Message = fmt.Sprintf("%s timed out after %s", strings.Join(args, " "), limit)
+	case err != nil:
+		result.Message = fmt.Sprintf("%s failed: %v", strings.Join(args, " "), err)
+	default:
+		result.Passed = true
+	}
+	return result
+}
+
+// runScriptArgs returns the command line that runs a package.json script.
+// npm needs "--" before arguments meant for the script.
+func runScriptArgs(pm, script string, extra []string) []string {
+	if pm == "" {
+		pm = models.PackageManagerNpm
+	}
+	args := []string{pm, "run", script}
+	if len(extra) > 0 && pm == models.PackageManagerNpm {
+		args = append(args, "--")
+	}
+	return append(args, extra...)
+}
+
+// trimOutput strips colors and keeps the last lines of a command's output,
+// where errors and summaries are
+func trimOutput(output string) string {
+	output = strings.TrimSpace(ansiEscape.ReplaceAllString(output, ""))
+	lines := strings.Split(output, "\n")
+	if len(lines) > maxVerifyLines {
+		lines = append([]string{fmt.Sprintf("... (%d lines omitted)", len(lines)-maxVerifyLines)}, lines[len(lines)-maxVerifyLines:]...)
+		output = strings.Join(lines, "\n")
+	}
+	if len(output) > maxVerifyBytes {
+		output = "..." + output[len(output)-maxVerifyBytes:]
+	}
+	return output
+}
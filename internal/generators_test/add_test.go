@@ -0,0 +1,162 @@
+package generators_test
+
+import (
+	"frontforge/internal/generators"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"frontforge/internal/vfs"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// generatePlain generates a React project without styling, testing or state
+// management, returning its directory
+func generatePlain(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(testutil.TempDir(t), "app")
+	config := models.QuickPreset()
+	config.ProjectName = "app"
+	config.ProjectPath = dir
+	config.Styling = models.StylingVanilla
+	config.UILibrary = models.UILibraryNone
+	config.Testing = models.TestingNone
+	config.StateManagement = models.StateNone
+	_, err := generators.Generate(config, io.Discard)
+	testutil.AssertNoError(t, err)
+	return dir
+}
+
+func TestDetectConfig(t *testing.T) {
+	dir := generatePlain(t)
+	testutil.CreateTempFile(t, dir, "pnpm-lock.yaml", "")
+
+	config, err := generators.DetectConfig(vfs.Disk{}, dir)
+	testutil.AssertNoError(t, err)
+
+	want := models.QuickPreset()
+	testutil.AssertEqual(t, config.ProjectName, "app")
+	testutil.AssertEqual(t, config.Framework, models.FrameworkReact)
+	testutil.AssertEqual(t, config.Language, models.LangTypeScript)
+	testutil.AssertEqual(t, config.PackageManager, models.PackageManagerPnpm)
+	testutil.AssertEqual(t, config.Structure, want.Structure)
+	testutil.AssertEqual(t, config.Styling, models.StylingVanilla)
+	testutil.AssertEqual(t, config.Testing, models.TestingNone)
+	testutil.AssertEqual(t, config.Routing, want.Routing)
+	testutil.AssertEqual(t, config.DataFetching, want.DataFetching)
+	testutil.AssertEqual(t, config.FormManagement, want.FormManagement)
+	testutil.AssertEqual(t, config.Icons, want.Icons)
+
+	if _, err := generators.DetectConfig(vfs.Disk{}, testutil.TempDir(t)); err == nil {
+		t.Error("expected an error without package.json")
+	}
+}
+
+func TestAdd(t *testing.T) {
+	dir := generatePlain(t)
+	mainPath := filepath.Join(dir, "src", "main.tsx")
+	testutil.CreateTempFile(t, filepath.Dir(mainPath), "main.tsx", mustRead(t, mainPath)+"// mine\n")
+
+	plan, err := generators.PlanAdd(vfs.Disk{}, dir, map[string]string{
+		"testing": models.TestingVitest,
+		"styling": models.StylingTailwind,
+	})
+	testutil.AssertNoError(t, err)
+	if plan.Detected {
+		t.Error("options should come from the manifest")
+	}
+	statuses := make(map[string]string)
+	for _, f := range plan.Files {
+		statuses[f.Path] = f.Status
+	}
+	for rel, want := range map[string]string{
+		"vite.config.ts":    generators.UpgradeUpdated,
+		"src/main.tsx":      generators.UpgradeMerged,
+		"src/index.css":     generators.UpgradeAdded,
+		"vitest.config.ts":  generators.UpgradeAdded,
+		"src/test/setup.ts": generators.UpgradeAdded,
+	} {
+		if statuses[rel] != want {
+			t.Errorf("%s: status %q, want %q", rel, statuses[rel], want)
+		}
+	}
+	if _, ok := statuses["src/App.tsx"]; ok {
+		t.Error("the App component should be left alone")
+	}
+	if plan.DevDependencies["vitest"] == "" || plan.DevDependencies["tailwindcss"] == "" || plan.Scripts["test"] != "vitest" {
+		t.Errorf("missing package.json additions: %v %v", plan.DevDependencies, plan.Scripts)
+	}
+
+	testutil.AssertNoError(t, plan.Commit())
+	testutil.AssertFileContains(t, filepath.Join(dir, "vite.config.ts"), "tailwindcss()")
+	testutil.AssertFileContains(t, mainPath, "import './index.css'")
+	testutil.AssertFileContains(t, mainPath, "// mine")
+	testutil.AssertFileExists(t, filepath.Join(dir, "vitest.config.ts"))
+	testutil.AssertFileContains(t, filepath.Join(dir, "package.json"), `"vitest"`)
+	testutil.AssertFileContains(t, filepath.Join(dir, "package.json"), `"react"`)
+
+	m, err := manifest.Load(vfs.Disk{}, dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, m.Config.Testing, models.TestingVitest)
+	testutil.AssertEqual(t, m.Config.Styling, models.StylingTailwind)
+}
+
+func TestAddKeepsManifestInSync(t *testing.T) {
+	dir := generatePlain(t)
+	plan, err := generators.PlanAdd(vfs.Disk{}, dir, map[string]string{
+		"testing": models.TestingVitest,
+		"styling": models.StylingTailwind,
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, plan.Commit())
+
+	d, err := generators.Diagnose(vfs.Disk{}, dir)
+	testutil.AssertNoError(t, err)
+	for _, c := range d.Checks {
+		if c.ID == generators.CheckManifestDrift && c.Status != generators.DoctorPass {
+			t.Errorf("an untouched project should have no drift after add: %s %v", c.Message, c.Details)
+		}
+	}
+
+	// Files add left alone keep the base they were generated with
+	base, err := manifest.ReadBase(vfs.Disk{}, dir, "src/App.tsx")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, string(base), mustRead(t, filepath.Join(dir, "src", "App.tsx")))
+}
+
+func TestAddDetectsOptions(t *testing.T) {
+	dir := generatePlain(t)
+	testutil.AssertNoError(t, os.RemoveAll(filepath.Join(dir, ".frontforge")))
+
+	plan, err := generators.PlanAdd(vfs.Disk{}, dir, map[string]string{"stateManagement": models.StateZustand})
+	testutil.AssertNoError(t, err)
+	if !plan.Detected {
+		t.Error("options should be detected without a manifest")
+	}
+	testutil.AssertEqual(t, plan.Dependencies["zustand"] != "", true)
+	testutil.AssertNoError(t, plan.Commit())
+	testutil.AssertFileContains(t, filepath.Join(dir, "package.json"), `"zustand"`)
+	testutil.AssertFileNotExists(t, filepath.Join(dir, ".frontforge", "manifest.json"))
+}
+
+func TestAddRejects(t *testing.T) {
+	dir := generatePlain(t)
+	tests := []struct {
+		changes map[string]string
+		wantErr string
+	}{
+		{map[string]string{"routing": models.RoutingTanStackRouter}, "already uses React Router"},
+		{map[string]string{"language": models.LangJavaScript}, "chosen when a project is created"},
+		{map[string]string{"stateManagement": models.StatePinia}, "not available for React"},
+		{map[string]string{"uiLibrary": models.UILibraryShadcn}, "Tailwind"},
+	}
+	for _, tt := range tests {
+		_, err := generators.PlanAdd(vfs.Disk{}, dir, tt.changes)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%v: error %v, want %q", tt.changes, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package generators_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"frontforge/internal/generators"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"frontforge/internal/vfs"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateArchive(t *testing.T) {
+	for _, fw := range viteFrameworks {
+		t.Run(fw, func(t *testing.T) {
+			dir := filepath.Join(testutil.TempDir(t), "app")
+			config := models.QuickPreset()
+			config.ProjectName = "app"
+			config.ProjectPath = dir
+			config.Framework = fw
+			models.FixCompatibility(&config, nil)
+
+			var buf bytes.Buffer
+			result, err := generators.GenerateArchive(context.Background(), &buf, vfs.FormatZip, config, io.Discard)
+			testutil.AssertNoError(t, err)
+			testutil.AssertFileNotExists(t, dir)
+
+			r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			testutil.AssertNoError(t, err)
+			entries := make(map[string]*zip.File)
+			for _, f := range r.File {
+				if !strings.HasPrefix(f.Name, "app/") {
+					t.Errorf("%s is outside the top-level directory", f.Name)
+				}
+				entries[f.Name] = f
+			}
+			for _, name := range result.Files {
+				f, ok := entries["app/"+name]
+				if !ok {
+					t.Errorf("%s is missing from the archive", name)
+					continue
+				}
+				if f.Mode() != 0644 {
+					t.Errorf("%s: mode = %v, want 0644", name, f.Mode())
+				}
+			}
+			if f := entries["app/src/"]; f == nil || f.Mode() != fs.ModeDir|0755 {
+				t.Error("src/ should be archived as a directory")
+			}
+			for _, check := range result.Validation {
+				if !check.Passed {
+					t.Errorf("validation failed: %s - %s", check.Check, check.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateArchiveMatchesDisk(t *testing.T) {
+	dir := generatePlain(t)
+	config := models.QuickPreset()
+	config.ProjectName = "app"
+	config.ProjectPath = dir
+	config.Styling = models.StylingVanilla
+	config.UILibrary = models.UILibraryNone
+	config.Testing = models.TestingNone
+	config.StateManagement = models.StateNone
+
+	// The directory anchors paths but isn't read or written
+	var buf bytes.Buffer
+	result, err := generators.GenerateArchive(context.Background(), &buf, vfs.FormatTarGz, config, io.Discard)
+	testutil.AssertNoError(t, err)
+
+	gz, err := gzip.NewReader(&buf)
+	testutil.AssertNoError(t, err)
+	tr := tar.NewReader(gz)
+	files := 0
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		testutil.AssertNoError(t, err)
+		rel := strings.TrimPrefix(h.Name, "app/")
+		if h.Typeflag != tar.TypeReg || rel == vfs.StateDir+"/"+manifest.BaseFile {
+			continue
+		}
+		files++
+		if rel == vfs.StateDir+"/manifest.json" {
+			continue // Has its own generation time
+		}
+		data, _ := io.ReadAll(tr)
+		onDisk, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil || !bytes.Equal(data, onDisk) {
+			t.Errorf("%s differs from the project generated on disk", rel)
+		}
+	}
+	testutil.AssertEqual(t, files, len(result.Files))
+}
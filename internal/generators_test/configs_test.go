@@ -4,7 +4,7 @@ import (
 	"frontforge/internal/generators"
 	"frontforge/internal/models"
 	"frontforge/internal/testutil"
-	"os"
+	"frontforge/internal/vfs"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -220,15 +220,7 @@ func TestGenerateProjectStructure(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tempDir := testutil.TempDir(t)
 
-			// Helper functions for actual file operations in tests
-			mkdirFunc := func(path string) error {
-				return os.MkdirAll(path, 0755)
-			}
-			writeFunc := func(path, content string) error {
-				return os.WriteFile(path, []byte(content), 0644)
-			}
-
-			err := generators.GenerateProjectStructure(tempDir, tt.config, mkdirFunc, writeFunc)
+			err := generators.GenerateProjectStructure(vfs.Disk{}, tempDir, tt.config)
 			testutil.AssertNoError(t, err)
 
 			// Verify directories exist
@@ -276,15 +268,7 @@ func TestGenerateProjectStructure_UtilsFunction(t *testing.T) {
 				Structure: models.StructureLayerBased,
 			}
 
-			// Helper functions for actual file operations in tests
-			mkdirFunc := func(path string) error {
-				return os.MkdirAll(path, 0755)
-			}
-			writeFunc := func(path, content string) error {
-				return os.WriteFile(path, []byte(content), 0644)
-			}
-
-			err := generators.GenerateProjectStructure(tempDir, config, mkdirFunc, writeFunc)
+			err := generators.GenerateProjectStructure(vfs.Disk{}, tempDir, config)
 			testutil.AssertNoError(t, err)
 
 			utilsPath := filepath.Join(tempDir, tt.wantFile)
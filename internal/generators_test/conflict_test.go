@@ -0,0 +1,283 @@
+package generators_test
+
+import (
+	"context"
+	"frontforge/internal/generators"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"frontforge/internal/vfs"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergePackageJSON(t *testing.T) {
+	existing := `{
+  "name": "legacy",
+  "scripts": {
+    "build": "tsc && webpack"
+  },
+  "dependencies": {
+    "react": "^18.0.0",
+    "lodash": "^4.17.21"
+  },
+  "keywords": ["app"]
+}
+`
+	generated := `{"name":"app","type":"module","scripts":{"dev":"vite","build":"vite build"},"dependencies":{"react":"^19.1.0","zustand":"^5.0.0"},"keywords":["app","vite"]}`
+
+	merged, ok := generators.MergeFile("/p/package.json", []byte(existing), []byte(generated))
+	if !ok {
+		t.Fatal("expected package.json to merge")
+	}
+	want := `{
+  "name": "legacy",
+  "scripts": {
+    "build": "tsc && webpack",
+    "dev": "vite"
+  },
+  "dependencies": {
+    "react": "^18.0.0",
+    "lodash": "^4.17.21",
+    "zustand": "^5.0.0"
+  },
+  "keywords": [
+    "app",
+    "vite"
+  ],
+  "type": "module"
+}
+`
+	testutil.AssertEqual(t, string(merged), want)
+}
+
+func TestMergeTSConfig(t *testing.T) {
+	existing := `{"compilerOptions":{"strict":false,"paths":{"~/*":["./src/*"]}}}`
+	generated := `{"compilerOptions":{"strict":true,"target":"ES2022","paths":{"@/*":["./src/*"]}}}`
+
+	merged, ok := generators.MergeFile("/p/tsconfig.app.json", []byte(existing), []byte(generated))
+	if !ok {
+		t.Fatal("expected tsconfig.app.json to merge")
+	}
+	testutil.AssertEqual(t, string(merged), `{
+  "compilerOptions": {
+    "strict": false,
+    "paths": {
+      "~/*": [
+        "./src/*"
+      ],
+      "@/*": [
+        "./src/*"
+      ]
+    },
+    "target": "ES2022"
+  }
+}`)
+
+	// Comments aren't JSON; the file is kept rather than losing them
+	if _, ok := generators.MergeFile("/p/tsconfig.json", []byte("{ // mine\n}"), []byte(generated)); ok {
+		t.Error("expected a tsconfig with comments not to merge")
+	}
+}
+
+func TestMergeGitignore(t *testing.T) {
+	existing := "# build\ndist\n.env\n"
+	generated := "# Logs\nlogs\n\nnode_modules\ndist\n"
+
+	merged, ok := generators.MergeFile("/p/.gitignore", []byte(existing), []byte(generated))
+	if !ok {
+		t.Fatal("expected .gitignore to merge")
+	}
+	testutil.AssertEqual(t, string(merged), "# build\ndist\n.env\n\nlogs\nnode_modules\n")
+
+	// Nothing missing: unchanged
+	merged, _ = generators.MergeFile("/p/.gitignore", []byte("dist\n"), []byte("dist\n"))
+	testutil.AssertEqual(t, string(merged), "dist\n")
+
+	if generators.CanMerge("/p/README.md") {
+		t.Error("README.md should not be mergeable")
+	}
+}
+
+func TestGenerateOnConflict(t *testing.T) {
+	tests := []struct {
+		policy      string
+		wantReadme  string
+		wantBackup  bool
+		wantActions map[string]string
+	}{
+		{models.OnConflictSkip, "mine", false, map[string]string{"README.md": "skip", "package.json": "skip", ".gitignore": "skip"}},
+		{models.OnConflictOverwrite, "# app", false, map[string]string{"README.md": "overwrite", "package.json": "overwrite", ".gitignore": "overwrite"}},
+		{models.OnConflictBackup, "# app", true, map[string]string{"README.md": "backup", "package.json": "backup", ".gitignore": "backup"}},
+		{models.OnConflictMerge, "mine", false, map[string]string{"README.md": "skip", "package.json": "merge", ".gitignore": "merge"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			dir := testutil.TempDir(t)
+			testutil.CreateTempFile(t, dir, "README.md", "mine")
+			testutil.CreateTempFile(t, dir, "package.json", `{"name":"legacy","private":true}`)
+			testutil.CreateTempFile(t, dir, ".gitignore", "secrets/\n")
+
+			config := models.QuickPreset()
+			config.ProjectName = "app"
+			config.ProjectPath = dir
+			config.OnConflict = tt.policy
+			result, err := generators.Generate(config, io.Discard)
+			testutil.AssertNoError(t, err)
+
+			actions := make(map[string]string)
+			for _, c := range result.Conflicts {
+				actions[c.Path] = c.Action
+			}
+			if !reflect.DeepEqual(actions, tt.wantActions) {
+				t.Errorf("conflicts = %v, want %v", actions, tt.wantActions)
+			}
+
+			testutil.AssertFileContains(t, filepath.Join(dir, "README.md"), tt.wantReadme)
+			if tt.wantBackup {
+				testutil.AssertFileContains(t, filepath.Join(dir, "README.md.bak"), "mine")
+			} else {
+				testutil.AssertFileNotExists(t, filepath.Join(dir, "README.md.bak"))
+			}
+			testutil.AssertFileExists(t, filepath.Join(dir, "src", "main.tsx"))
+			testutil.AssertFileExists(t, filepath.Join(dir, ".frontforge", "manifest.json"))
+
+			if tt.policy == models.OnConflictMerge {
+				testutil.AssertFileContains(t, filepath.Join(dir, "package.json"), `"name": "legacy"`)
+				testutil.AssertFileContains(t, filepath.Join(dir, "package.json"), `"react"`)
+				testutil.AssertFileContains(t, filepath.Join(dir, ".gitignore"), "secrets/\n\nlogs\n")
+				testutil.AssertFileContains(t, filepath.Join(dir, ".gitignore"), "node_modules")
+			}
+		})
+	}
+}
+
+func TestGenerateIntoOnConflictManifest(t *testing.T) {
+	dir := filepath.Join(string(filepath.Separator), "app")
+	config := models.QuickPreset()
+	config.ProjectName = "app"
+	config.ProjectPath = dir
+	config.OnConflict = models.OnConflictMerge
+
+	clean := vfs.NewMemory()
+	_, err := generators.GenerateFS(clean, config, io.Discard)
+	testutil.AssertNoError(t, err)
+	generated, err := clean.ReadFile(filepath.Join(dir, "package.json"))
+	testutil.AssertNoError(t, err)
+
+	mem := vfs.NewMemory()
+	testutil.AssertNoError(t, mem.MkdirAll(dir))
+	testutil.AssertNoError(t, mem.WriteFile(filepath.Join(dir, "README.md"), []byte("mine"), 0644))
+	testutil.AssertNoError(t, mem.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name":"legacy","private":true}`), 0644))
+	result, err := generators.GenerateInto(context.Background(), mem, config, io.Discard)
+	testutil.AssertNoError(t, err)
+
+	// The README the user kept isn't taken for a generated file
+	for _, rel := range result.Files {
+		if rel == "README.md" {
+			t.Error("a kept file should not be listed as generated")
+		}
+	}
+	m, err := manifest.Load(mem, dir)
+	testutil.AssertNoError(t, err)
+	if _, ok := m.Files["README.md"]; ok {
+		t.Error("a kept file should not be in the manifest")
+	}
+
+	// The merged package.json is recorded with what was generated as its base
+	testutil.AssertEqual(t, m.Files["package.json"], manifest.Hash(generated))
+	base, err := manifest.ReadBase(mem, dir, "package.json")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, string(base), string(generated))
+	merged, err := mem.ReadFile(filepath.Join(dir, "package.json"))
+	testutil.AssertNoError(t, err)
+	if !strings.Contains(string(merged), `"name": "legacy"`) {
+		t.Errorf("package.json should be merged:\n%s", merged)
+	}
+}
+
+func TestGenerateOnConflictManifest(t *testing.T) {
+	tests := []struct {
+		policy string
+		kept   []string // Left as they were, so not in the manifest
+		merged []string // Recorded with the generated content
+	}{
+		{models.OnConflictSkip, []string{"README.md", "package.json", ".gitignore"}, nil},
+		{models.OnConflictMerge, []string{"README.md"}, []string{"package.json", ".gitignore"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			dir := testutil.TempDir(t)
+			testutil.CreateTempFile(t, dir, "README.md", "mine")
+			testutil.CreateTempFile(t, dir, "package.json", `{"name":"legacy","private":true}`)
+			testutil.CreateTempFile(t, dir, ".gitignore", "secrets/\n")
+
+			config := models.QuickPreset()
+			config.ProjectName = "app"
+			config.ProjectPath = dir
+			config.OnConflict = tt.policy
+			clean := vfs.NewMemory()
+			_, err := generators.GenerateFS(clean, config, io.Discard)
+			testutil.AssertNoError(t, err)
+
+			result, err := generators.Generate(config, io.Discard)
+			testutil.AssertNoError(t, err)
+			m, err := manifest.Load(vfs.Disk{}, dir)
+			testutil.AssertNoError(t, err)
+			bases, err := manifest.ReadBases(vfs.Disk{}, dir)
+			testutil.AssertNoError(t, err)
+
+			for _, rel := range tt.kept {
+				if _, ok := m.Files[rel]; ok {
+					t.Errorf("kept %s should not be in the manifest", rel)
+				}
+				if _, ok := bases[rel]; ok {
+					t.Errorf("kept %s should have no base copy", rel)
+				}
+				for _, f := range result.Files {
+					if f == rel {
+						t.Errorf("kept %s should not be listed as generated", rel)
+					}
+				}
+			}
+			for _, rel := range tt.merged {
+				generated, err := clean.ReadFile(filepath.Join(dir, rel))
+				testutil.AssertNoError(t, err)
+				testutil.AssertEqual(t, m.Files[rel], manifest.Hash(generated))
+				testutil.AssertEqual(t, string(bases[rel]), string(generated))
+			}
+			if _, ok := m.Files["src/main.tsx"]; !ok {
+				t.Error("generated files should stay in the manifest")
+			}
+		})
+	}
+}
+
+func TestStageLeavesTargetUntilCommit(t *testing.T) {
+	dir := testutil.TempDir(t)
+	testutil.CreateTempFile(t, dir, "README.md", "mine")
+
+	config := models.QuickPreset()
+	config.ProjectName = "app"
+	config.ProjectPath = dir
+	config.OnConflict = models.OnConflictPrompt
+
+	staged, err := generators.Stage(config, io.Discard)
+	testutil.AssertNoError(t, err)
+	if len(staged.Conflicts) != 1 || filepath.Base(staged.Conflicts[0].Path) != "README.md" {
+		t.Fatalf("expected README.md to conflict, got %v", staged.Conflicts)
+	}
+	testutil.AssertFileNotExists(t, filepath.Join(dir, "package.json"))
+
+	// A choice overrides the prompt policy
+	result, err := staged.Commit(map[string]string{staged.Conflicts[0].Path: models.OnConflictOverwrite})
+	testutil.AssertNoError(t, err)
+	testutil.AssertFileContains(t, filepath.Join(dir, "README.md"), "# app")
+	testutil.AssertFileExists(t, filepath.Join(dir, "package.json"))
+	testutil.AssertEqual(t, len(result.Conflicts), 1)
+}
@@ -0,0 +1,98 @@
+package generators_test
+
+import (
+	"frontforge/internal/generators"
+	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"frontforge/internal/vfs"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// checksByID indexes a diagnosis by check ID
+func checksByID(d *generators.Diagnosis) map[string]generators.DoctorCheck {
+	checks := make(map[string]generators.DoctorCheck)
+	for _, c := range d.Checks {
+		checks[c.ID] = c
+	}
+	return checks
+}
+
+func TestDiagnoseGeneratedProjects(t *testing.T) {
+	for _, framework := range []string{models.FrameworkReact, models.FrameworkVue, models.FrameworkAngular, models.FrameworkSvelte, models.FrameworkSolid, models.FrameworkVanilla} {
+		for _, lang := range []string{models.LangTypeScript, models.LangJavaScript} {
+			t.Run(framework+"/"+lang, func(t *testing.T) {
+				config := models.QuickPreset()
+				config.ProjectName = "app"
+				config.ProjectPath = filepath.Join(testutil.TempDir(t), "app")
+				config.Framework = framework
+				config.Language = lang
+				config.Testing = models.TestingVitest
+				models.FixCompatibility(&config, nil)
+				_, err := generators.Generate(config, io.Discard)
+				testutil.AssertNoError(t, err)
+
+				d, err := generators.Diagnose(vfs.Disk{}, config.ProjectPath)
+				testutil.AssertNoError(t, err)
+				for _, c := range d.Checks {
+					if c.Status != generators.DoctorPass && c.Status != generators.DoctorSkip {
+						t.Errorf("%s: %s %s %v", c.ID, c.Status, c.Message, c.Details)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestDiagnoseFindsProblems(t *testing.T) {
+	dir := generatePlain(t)
+	pkgPath := filepath.Join(dir, "package.json")
+	pkg := mustRead(t, pkgPath)
+	pkg = strings.Replace(pkg, `"eslint-plugin-react-refresh"`, `"unrelated"`, 1)
+	pkg = strings.Replace(pkg, `"lint": "eslint .",`, "", 1)
+	testutil.CreateTempFile(t, dir, "package.json", pkg)
+	testutil.CreateTempFile(t, dir, "tsconfig.json", `{
+  // Comments are allowed
+  "files": [],
+  "references": [{ "path": "./tsconfig.app.json" }, { "path": "./tsconfig.gone.json" },],
+}`)
+	testutil.AssertNoError(t, os.Remove(filepath.Join(dir, "index.html")))
+
+	d, err := generators.Diagnose(vfs.Disk{}, dir)
+	testutil.AssertNoError(t, err)
+	if !d.Failed() {
+		t.Error("expected the diagnosis to fail")
+	}
+	checks := checksByID(d)
+	for id, want := range map[string]string{
+		generators.CheckCoreFiles:     generators.DoctorFail,
+		generators.CheckIndexHTML:     generators.DoctorFail,
+		generators.CheckScripts:       generators.DoctorWarn,
+		generators.CheckTSConfig:      generators.DoctorFail,
+		generators.CheckESLintPlugins: generators.DoctorFail,
+		generators.CheckManifestDrift: generators.DoctorWarn,
+		generators.CheckPackageJSON:   generators.DoctorPass,
+	} {
+		if got := checks[id].Status; got != want {
+			t.Errorf("%s = %s, want %s (%v)", id, got, want, checks[id].Details)
+		}
+	}
+	if details := strings.Join(checks[generators.CheckESLintPlugins].Details, "\n"); !strings.Contains(details, "eslint-plugin-react-refresh") {
+		t.Errorf("ESLint details should name the plugin: %s", details)
+	}
+	if details := strings.Join(checks[generators.CheckTSConfig].Details, "\n"); !strings.Contains(details, "tsconfig.gone.json") {
+		t.Errorf("tsconfig details should name the missing reference: %s", details)
+	}
+
+	// Without a manifest the options are detected and drift is skipped
+	testutil.AssertNoError(t, os.RemoveAll(filepath.Join(dir, vfs.StateDir)))
+	d, err = generators.Diagnose(vfs.Disk{}, dir)
+	testutil.AssertNoError(t, err)
+	if !d.Detected {
+		t.Error("options should be detected")
+	}
+	testutil.AssertEqual(t, checksByID(d)[generators.CheckManifestDrift].Status, generators.DoctorSkip)
+}
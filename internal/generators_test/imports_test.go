@@ -0,0 +1,194 @@
+package generators_test
+
+import (
+	"frontforge/internal/generators"
+	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"frontforge/internal/vfs"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// viteFrameworks are the frameworks generated without an upstream CLI
+var viteFrameworks = []string{
+	models.FrameworkReact,
+	models.FrameworkVue,
+	models.FrameworkAngular,
+	models.FrameworkSvelte,
+	models.FrameworkSolid,
+	models.FrameworkVanilla,
+}
+
+// TestImportGraph generates, for each framework and language, a set of
+// configs that covers every pair of option values (pairwise, since the full
+// product runs into millions of projects) and checks that every import
+// resolves. Pairs the compatibility rules forbid are left out.
+func TestImportGraph(t *testing.T) {
+	for _, fw := range viteFrameworks {
+		for _, lang := range []string{models.LangTypeScript, models.LangJavaScript} {
+			fw, lang := fw, lang
+			t.Run(fw+"/"+lang, func(t *testing.T) {
+				t.Parallel()
+				for _, config := range pairwiseConfigs(fw, lang) {
+					config.ProjectPath = filepath.Join(string(filepath.Separator), "app")
+					result, err := generators.GenerateFS(vfs.NewMemory(), config, io.Discard)
+					if err != nil {
+						t.Errorf("%s: %v", describeConfig(config), err)
+						continue
+					}
+					for _, check := range result.Validation {
+						if check.ID == generators.CheckImports && !check.Passed {
+							t.Errorf("%s: %s: %s", describeConfig(config), check.Check, check.Message)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+// optionPair is two option values that should appear in one config
+type optionPair struct {
+	keyA, valueA, keyB, valueB string
+}
+
+// pairwiseConfigs returns compatible configs for fw and lang in which every
+// pair of values of two different groups appears at least once, unless the
+// compatibility rules rule the pair out. Each config starts from the first
+// pair not covered yet and fills the other groups greedily.
+func pairwiseConfigs(fw, lang string) []models.Config {
+	var groups []*models.OptionGroup
+	values := make(map[string][]string)
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		if g.Key == "framework" || g.Key == "language" || !g.AppliesTo(fw) {
+			continue
+		}
+		groups = append(groups, g)
+		for _, o := range g.OptionsFor(fw, true) {
+			values[g.Key] = append(values[g.Key], o.Value)
+		}
+	}
+
+	var order []optionPair
+	uncovered := make(map[optionPair]bool)
+	for i, a := range groups {
+		for _, b := range groups[i+1:] {
+			for _, va := range values[a.Key] {
+				for _, vb := range values[b.Key] {
+					p := optionPair{a.Key, va, b.Key, vb}
+					order = append(order, p)
+					uncovered[p] = true
+				}
+			}
+		}
+	}
+	// pairsOf lists the pairs a (partial) config covers
+	pairsOf := func(chosen map[string]string) []optionPair {
+		var pairs []optionPair
+		for i, a := range groups {
+			for _, b := range groups[i+1:] {
+				va, okA := chosen[a.Key]
+				vb, okB := chosen[b.Key]
+				if okA && okB {
+					pairs = append(pairs, optionPair{a.Key, va, b.Key, vb})
+				}
+			}
+		}
+		return pairs
+	}
+
+	var configs []models.Config
+	for _, seed := range order {
+		if !uncovered[seed] {
+			continue
+		}
+		chosen := map[string]string{seed.keyA: seed.valueA, seed.keyB: seed.valueB}
+		for _, g := range groups {
+			if _, ok := chosen[g.Key]; ok {
+				continue
+			}
+			best, bestCount := values[g.Key][0], -1
+			for _, v := range values[g.Key] {
+				chosen[g.Key] = v
+				count := 0
+				for _, p := range pairsOf(chosen) {
+					if uncovered[p] {
+						count++
+					}
+				}
+				if count > bestCount {
+					best, bestCount = v, count
+				}
+			}
+			chosen[g.Key] = best
+		}
+
+		config := models.QuickPreset()
+		config.Framework = fw
+		config.Language = lang
+		for _, g := range groups {
+			*g.Field(&config) = chosen[g.Key]
+		}
+		models.FixCompatibility(&config, func(key string) bool { return key != seed.keyA && key != seed.keyB })
+		delete(uncovered, seed) // Covered now, or ruled out by the rules
+		if len(models.CheckCompatibility(config)) > 0 {
+			continue
+		}
+		for _, g := range groups {
+			chosen[g.Key] = *g.Field(&config)
+		}
+		for _, p := range pairsOf(chosen) {
+			delete(uncovered, p)
+		}
+		configs = append(configs, config)
+	}
+	return configs
+}
+
+// describeConfig names the options of a config for test failures
+func describeConfig(config models.Config) string {
+	var parts []string
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		if g.AppliesTo(config.Framework) {
+			parts = append(parts, g.Key+"="+*g.Field(&config))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestImportGraphFindsProblems(t *testing.T) {
+	dir := generatePlain(t)
+	main := mustRead(t, filepath.Join(dir, "src", "main.tsx"))
+	main += `
+import leftPad from 'left-pad'
+import { helper } from './missing'
+import { cn } from '@/lib/utils'
+import fs from 'node:fs'
+import path from 'path'
+// import gone from 'commented-out'
+const lazy = () => import('./App')
+`
+	testutil.CreateTempFile(t, filepath.Join(dir, "src"), "main.tsx", main)
+
+	d, err := generators.Diagnose(vfs.Disk{}, dir)
+	testutil.AssertNoError(t, err)
+	check := checksByID(d)[generators.CheckImports]
+	if check.Status != generators.DoctorFail {
+		t.Fatalf("imports = %s, want %s", check.Status, generators.DoctorFail)
+	}
+	details := strings.Join(check.Details, "\n")
+	for _, want := range []string{"'left-pad' is not in package.json", "./missing does not resolve", "@/lib/utils uses the @ alias"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("details should mention %q, got:\n%s", want, details)
+		}
+	}
+	for _, unwanted := range []string{"node:fs", "'path'", "commented-out", "./App"} {
+		if strings.Contains(details, unwanted) {
+			t.Errorf("details should not mention %q, got:\n%s", unwanted, details)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package generators_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"frontforge/internal/generators"
+	"frontforge/internal/generators/meta"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"frontforge/internal/vfs"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateInMemory(t *testing.T) {
+	frameworks := []string{
+		models.FrameworkReact,
+		models.FrameworkVue,
+		models.FrameworkAngular,
+		models.FrameworkSvelte,
+		models.FrameworkSolid,
+		models.FrameworkVanilla,
+	}
+
+	for _, fw := range frameworks {
+		for _, lang := range []string{models.LangTypeScript, models.LangJavaScript} {
+			t.Run(fw+"/"+lang, func(t *testing.T) {
+				config := models.QuickPreset()
+				config.Framework = fw
+				config.Language = lang
+				config.ProjectPath = filepath.Join(testutil.TempDir(t), "app")
+				models.FixCompatibility(&config, nil)
+
+				mem := vfs.NewMemory()
+				result, err := generators.GenerateFS(mem, config, io.Discard)
+				testutil.AssertNoError(t, err)
+
+				for _, check := range result.Validation {
+					if !check.Passed {
+						t.Errorf("%s: %s", check.Check, check.Message)
+					}
+				}
+				// The base copies of the generated files aren't listed
+				files := 0
+				for _, path := range mem.Files() {
+					if path != manifest.BasePath(config.ProjectPath) {
+						files++
+					}
+				}
+				if len(result.Files) != files {
+					t.Errorf("result lists %d files, memory holds %d", len(result.Files), files)
+				}
+				testutil.AssertFileNotExists(t, config.ProjectPath)
+			})
+		}
+	}
+}
+
+func TestGenerateDryRunWritesNothing(t *testing.T) {
+	config := models.QuickPreset()
+	config.DryRun = true
+	config.ProjectName = "app"
+	config.ProjectPath = filepath.Join(testutil.TempDir(t), "app")
+
+	var out bytes.Buffer
+	result, err := generators.Generate(config, &out)
+	testutil.AssertNoError(t, err)
+
+	if !strings.Contains(out.String(), "files would be created") {
+		t.Errorf("expected a dry-run manifest, got:\n%s", out.String())
+	}
+	if len(result.Files) == 0 || len(result.Validation) != 0 {
+		t.Errorf("dry run should list files without validating: %+v", result)
+	}
+	testutil.AssertFileNotExists(t, config.ProjectPath)
+}
+
+func TestGenerateDryRunDiff(t *testing.T) {
+	dir := generatePlain(t)
+	config := models.QuickPreset()
+	config.ProjectName = "app"
+	config.ProjectPath = dir
+	config.Styling = models.StylingVanilla
+	config.UILibrary = models.UILibraryNone
+	config.Testing = models.TestingNone
+	config.StateManagement = models.StateNone
+	config.DryRun = true
+	config.DryRunFormat = models.DryRunDiff
+
+	// Regenerating the same options changes nothing
+	var out bytes.Buffer
+	result, err := generators.Generate(config, &out)
+	testutil.AssertNoError(t, err)
+	if result.DryRun.Changed() {
+		t.Errorf("an unchanged project should not drift:\n%s", out.String())
+	}
+
+	testutil.CreateTempFile(t, dir, "README.md", "mine\n")
+	testutil.CreateTempFile(t, dir, "notes.txt", "keep\n")
+	testutil.AssertNoError(t, os.Remove(filepath.Join(dir, "index.html")))
+
+	out.Reset()
+	result, err = generators.Generate(config, &out)
+	testutil.AssertNoError(t, err)
+	if !result.DryRun.Changed() {
+		t.Error("edits should be reported as changes")
+	}
+	statuses := make(map[string]string)
+	for _, e := range result.DryRun.Entries {
+		statuses[filepath.ToSlash(e.Path)] = e.Status
+	}
+	for path, want := range map[string]string{
+		"README.md":    generators.DryRunModified,
+		"index.html":   generators.DryRunNew,
+		"notes.txt":    generators.DryRunUntouched,
+		"package.json": generators.DryRunIdentical,
+	} {
+		if got := statuses[path]; got != want {
+			t.Errorf("%s: status = %q, want %q", path, got, want)
+		}
+	}
+	if _, ok := statuses[".frontforge/manifest.json"]; ok {
+		t.Error("frontforge's state directory should not be compared")
+	}
+	for _, want := range []string{"--- a/README.md", "+++ b/README.md", "-mine", "+++ b/index.html", "1 new, 1 modified"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("diff should contain %q:\n%s", want, out.String())
+		}
+	}
+	testutil.AssertFileNotExists(t, filepath.Join(dir, "index.html"))
+}
+
+func TestDryRunManifestFormats(t *testing.T) {
+	m := generators.NewDryRunManifest("/app", "app")
+	m.AddDir("/app/src")
+	m.AddFile("/app/src/main.ts", "console.log(1)")
+	m.AddFile("/app/.gitignore", "")
+	m.AddFile("/app/README.md", "# app\n")
+
+	files := m.Files(false)
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+		if f.Content != nil {
+			t.Errorf("%s: content should be left out", f.Path)
+		}
+	}
+	testutil.AssertEqual(t, strings.Join(paths, " "), ".gitignore README.md src src/main.ts")
+	testutil.AssertEqual(t, files[2].Type, "dir")
+	testutil.AssertEqual(t, files[2].SHA256, "")
+	testutil.AssertEqual(t, files[3].Size, 14)
+	// printf "console.log(1)" | sha256sum
+	testutil.AssertEqual(t, files[3].SHA256, "0a286891c11c056e1ab5bfc25bf5d6b2f5b06d38eac10944f678fd8a2e70c393")
+
+	var out bytes.Buffer
+	testutil.AssertNoError(t, m.FprintJSON(&out, true))
+	var doc struct {
+		Entries []generators.DryRunFile `json:"entries"`
+	}
+	testutil.AssertNoError(t, json.Unmarshal(out.Bytes(), &doc))
+	if c := doc.Entries[0].Content; c == nil || *c != "" {
+		t.Errorf("an empty file should still carry its (empty) content:\n%s", out.String())
+	}
+
+	out.Reset()
+	m.FprintFiles(&out)
+	testutil.AssertEqual(t, out.String(), "==> .gitignore <==\n\n==> README.md <==\n# app\n\n==> src/main.ts <==\nconsole.log(1)\n")
+}
+
+func TestPostScaffoldInMemory(t *testing.T) {
+	tests := []struct {
+		framework string
+		wantFiles []string
+	}{
+		{models.FrameworkNextJS, []string{"vitest.config.ts", "src/test/setup.ts", "app/features"}},
+		{models.FrameworkAstro, []string{"vitest.config.ts", "src/styles/global.css", "astro.config.mjs"}},
+		{models.FrameworkSvelteKit, []string{"src/lib/features"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			dir := filepath.Join(testutil.TempDir(t), "app")
+			mem := vfs.NewMemory()
+			testutil.AssertNoError(t, mem.MkdirAll(dir))
+			testutil.AssertNoError(t, vfs.WriteString(mem, filepath.Join(dir, "package.json"), `{"name": "app"}`))
+
+			cfg := models.Config{
+				Framework:   tt.framework,
+				Language:    models.LangTypeScript,
+				Styling:     models.StylingTailwind,
+				Testing:     models.TestingVitest,
+				Structure:   models.StructureFeatureBased,
+				ProjectPath: dir,
+			}
+			gen, _ := meta.Get(tt.framework)
+			testutil.AssertNoError(t, gen.PostScaffold(context.Background(), mem, cfg))
+
+			for _, name := range tt.wantFiles {
+				if !vfs.Exists(mem, filepath.Join(dir, name)) {
+					t.Errorf("missing %s", name)
+				}
+			}
+
+			data, err := mem.ReadFile(filepath.Join(dir, "package.json"))
+			testutil.AssertNoError(t, err)
+			var pkg struct {
+				Name    string            `json:"name"`
+				Scripts map[string]string `json:"scripts"`
+			}
+			testutil.AssertNoError(t, json.Unmarshal(data, &pkg))
+			if pkg.Name != "app" || pkg.Scripts["lint"] == "" {
+				t.Errorf("package.json not merged: %s", data)
+			}
+			testutil.AssertFileNotExists(t, dir)
+		})
+	}
+}
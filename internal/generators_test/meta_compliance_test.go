@@ -1,8 +1,14 @@
 package generators_test
 
 import (
+	"context"
+	"encoding/json"
 	"frontforge/internal/generators/meta"
 	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"frontforge/internal/vfs"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	// Trigger init() registration.
@@ -58,3 +64,117 @@ func TestMetaGeneratorInterfaceCompliance(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistryMatchesMetaOptionMatrix(t *testing.T) {
+	t.Parallel()
+
+	for _, fw := range []string{models.FrameworkNextJS, models.FrameworkAstro, models.FrameworkSvelteKit} {
+		t.Run(fw, func(t *testing.T) {
+			gen, ok := meta.Get(fw)
+			if !ok {
+				t.Fatalf("framework %q not registered", fw)
+			}
+			opts := gen.SupportedOptions()
+
+			matrix := map[string][]string{
+				"styling":         opts.Styling,
+				"testing":         opts.Testing,
+				"stateManagement": opts.StateManagement,
+				"dataFetching":    opts.DataFetching,
+			}
+			for key, want := range matrix {
+				g, _ := models.LookupGroup(key)
+				var got []string
+				for _, o := range g.OptionsFor(fw, false) {
+					got = append(got, o.Value)
+				}
+				if !sameSet(got, want) {
+					t.Errorf("registry %s for %s = %v, generator supports %v", key, fw, got, want)
+				}
+			}
+
+			// PostScaffold installs exactly the registry's packages for
+			// each library option, in the framework's variant
+			for _, key := range []string{"stateManagement", "dataFetching"} {
+				for _, value := range matrix[key] {
+					cfg := models.Config{
+						Framework: fw, Language: models.LangTypeScript, Styling: models.StylingVanilla,
+						Testing: models.TestingNone, StateManagement: models.StateNone, DataFetching: models.DataNone,
+					}
+					g, _ := models.LookupGroup(key)
+					*g.Field(&cfg) = value
+
+					var want []string
+					for _, p := range models.SelectedPackages(cfg, key) {
+						want = append(want, p.Name)
+						if fw != models.FrameworkNextJS && strings.Contains(p.Name, "react") {
+							t.Errorf("%s %s for %s selects the React package %s", key, value, fw, p.Name)
+						}
+					}
+					if got := postScaffoldPackages(t, gen, cfg, key); !sameSet(got, want) {
+						t.Errorf("%s %s for %s installs %v, registry lists %v", key, value, fw, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+// postScaffoldPackages runs PostScaffold in memory and returns the packages
+// it added beyond those it adds when the option in group key is "None"
+func postScaffoldPackages(t *testing.T, gen meta.MetaGenerator, cfg models.Config, key string) []string {
+	t.Helper()
+	installed := func(cfg models.Config) map[string]bool {
+		dir := filepath.Join(string(filepath.Separator), "app")
+		mem := vfs.NewMemory()
+		testutil.AssertNoError(t, mem.MkdirAll(dir))
+		testutil.AssertNoError(t, vfs.WriteString(mem, filepath.Join(dir, "package.json"), `{"name": "app"}`))
+		cfg.ProjectPath = dir
+		testutil.AssertNoError(t, gen.PostScaffold(context.Background(), mem, cfg))
+
+		data, err := mem.ReadFile(filepath.Join(dir, "package.json"))
+		testutil.AssertNoError(t, err)
+		var pkg struct {
+			Dependencies    map[string]string `json:"dependencies"`
+			DevDependencies map[string]string `json:"devDependencies"`
+		}
+		testutil.AssertNoError(t, json.Unmarshal(data, &pkg))
+		names := make(map[string]bool)
+		for name := range pkg.Dependencies {
+			names[name] = true
+		}
+		for name := range pkg.DevDependencies {
+			names[name] = true
+		}
+		return names
+	}
+
+	none := cfg
+	g, _ := models.LookupGroup(key)
+	*g.Field(&none) = "None"
+	base := installed(none)
+	var added []string
+	for name := range installed(cfg) {
+		if !base[name] {
+			added = append(added, name)
+		}
+	}
+	return added
+}
+
+// sameSet reports whether a and b hold the same values, ignoring order
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]bool, len(a))
+	for _, v := range a {
+		seen[v] = true
+	}
+	for _, v := range b {
+		if !seen[v] {
+			return false
+		}
+	}
+	return true
+}
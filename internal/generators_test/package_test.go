@@ -81,3 +81,33 @@ func TestGeneratePackageJSON_Scripts(t *testing.T) {
 		}
 	}
 }
+
+func TestGeneratePackageJSON_TypecheckScript(t *testing.T) {
+	tests := []struct {
+		framework string
+		language  string
+		want      string // "" means no typecheck script
+		checker   string // Dev dependency the script needs
+	}{
+		{models.FrameworkReact, models.LangTypeScript, "tsc -b", "typescript"},
+		{models.FrameworkVue, models.LangTypeScript, "vue-tsc -b", "vue-tsc"},
+		{models.FrameworkSvelte, models.LangTypeScript, "svelte-check --tsconfig ./tsconfig.app.json", "svelte-check"},
+		{models.FrameworkReact, models.LangJavaScript, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.framework+"/"+tt.language, func(t *testing.T) {
+			pkg := generators.GeneratePackageJSON(models.Config{
+				ProjectName: "test-project",
+				Framework:   tt.framework,
+				Language:    tt.language,
+			})
+			if got := pkg.Scripts["typecheck"]; got != tt.want {
+				t.Errorf("typecheck script = %q, want %q", got, tt.want)
+			}
+			if _, ok := pkg.DevDependencies[tt.checker]; tt.checker != "" && !ok {
+				t.Errorf("%s should be a dev dependency", tt.checker)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package generators_test
+
+import (
+	"encoding/json"
+	"frontforge/internal/generators"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"frontforge/internal/vfs"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// generateWithTesting generates a React project with Vitest and Styled
+// Components, returning its directory
+func generateWithTesting(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(testutil.TempDir(t), "app")
+	config := models.QuickPreset()
+	config.ProjectName = "app"
+	config.ProjectPath = dir
+	config.Styling = models.StylingStyled
+	config.UILibrary = models.UILibraryNone
+	config.Testing = models.TestingVitest
+	_, err := generators.Generate(config, io.Discard)
+	testutil.AssertNoError(t, err)
+	return dir
+}
+
+func TestManifestRecordsOwnership(t *testing.T) {
+	dir := generateWithTesting(t)
+	m, err := manifest.Load(vfs.Disk{}, dir)
+	testutil.AssertNoError(t, err)
+
+	owner := m.Options["testing"]
+	if owner == nil {
+		t.Fatal("no ownership recorded for testing")
+	}
+	if want := []string{"src/test/setup.ts", "vitest.config.ts"}; !reflect.DeepEqual(owner.Files, want) {
+		t.Errorf("testing files = %v, want %v", owner.Files, want)
+	}
+	if !reflect.DeepEqual(owner.Scripts, []string{"test"}) {
+		t.Errorf("testing scripts = %v, want [test]", owner.Scripts)
+	}
+	if !strings.Contains(strings.Join(owner.DevDependencies, " "), "vitest") {
+		t.Errorf("testing devDependencies = %v, want vitest", owner.DevDependencies)
+	}
+	if styling := m.Options["styling"]; styling == nil || !reflect.DeepEqual(styling.Dependencies, []string{"styled-components"}) {
+		t.Errorf("styling ownership = %+v, want styled-components", styling)
+	}
+	if _, ok := m.Options["uiLibrary"]; ok {
+		t.Error("options that add nothing should have no ownership")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	dir := generateWithTesting(t)
+	setup := filepath.Join(dir, "src", "test", "setup.ts")
+	testutil.CreateTempFile(t, filepath.Dir(setup), "setup.ts", mustRead(t, setup)+"// mine\n")
+
+	plan, err := generators.PlanRemove(vfs.Disk{}, dir, map[string]string{
+		"testing": models.TestingVitest,
+		"styling": "",
+	})
+	testutil.AssertNoError(t, err)
+	statuses := make(map[string]string)
+	for _, f := range plan.Files {
+		statuses[f.Path] = f.Status
+	}
+	testutil.AssertEqual(t, statuses["vitest.config.ts"], generators.UpgradeRemoved)
+	testutil.AssertEqual(t, statuses["src/test/setup.ts"], generators.UpgradeEdited)
+	if !reflect.DeepEqual(plan.Dependencies, []string{"styled-components"}) {
+		t.Errorf("Dependencies = %v", plan.Dependencies)
+	}
+	if !reflect.DeepEqual(plan.Scripts, []string{"test"}) {
+		t.Errorf("Scripts = %v", plan.Scripts)
+	}
+	testutil.AssertNoError(t, plan.Commit())
+
+	testutil.AssertFileNotExists(t, filepath.Join(dir, "vitest.config.ts"))
+	testutil.AssertFileContains(t, setup, "// mine")
+	var pkg generators.PackageJSON
+	testutil.AssertNoError(t, json.Unmarshal([]byte(mustRead(t, filepath.Join(dir, "package.json"))), &pkg))
+	for _, name := range []string{"vitest", "jsdom"} {
+		if _, ok := pkg.DevDependencies[name]; ok {
+			t.Errorf("%s should be removed from devDependencies", name)
+		}
+	}
+	if _, ok := pkg.Scripts["test"]; ok {
+		t.Error("the test script should be removed")
+	}
+	if _, ok := pkg.Dependencies["react"]; !ok {
+		t.Error("react should stay")
+	}
+
+	m, err := manifest.Load(vfs.Disk{}, dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, m.Config.Testing, models.TestingNone)
+	testutil.AssertEqual(t, m.Config.Styling, models.StylingVanilla)
+
+	// Nothing left to remove
+	if _, err := generators.PlanRemove(vfs.Disk{}, dir, map[string]string{"testing": ""}); err == nil {
+		t.Error("expected an error removing testing twice")
+	}
+}
+
+func TestRemoveRejects(t *testing.T) {
+	dir := generateWithTesting(t)
+	for name, targets := range map[string]map[string]string{
+		"other value":    {"testing": models.TestingJest},
+		"fixed option":   {"framework": ""},
+		"nothing to do":  {"uiLibrary": ""},
+		"unknown option": {"bogus": ""},
+	} {
+		if _, err := generators.PlanRemove(vfs.Disk{}, dir, targets); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	// Manifests from before ownership was tracked
+	path := manifest.Path(dir)
+	var raw map[string]interface{}
+	testutil.AssertNoError(t, json.Unmarshal([]byte(mustRead(t, path)), &raw))
+	delete(raw, "options")
+	data, _ := json.Marshal(raw)
+	testutil.CreateTempFile(t, filepath.Dir(path), manifest.FileName, string(data))
+	if _, err := generators.PlanRemove(vfs.Disk{}, dir, map[string]string{"testing": ""}); err == nil || !strings.Contains(err.Error(), "upgrade") {
+		t.Errorf("expected a hint to upgrade, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package generators_test
+
+import (
+	"frontforge/internal/generators"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"frontforge/internal/vfs"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerateIntoExistingDirectory(t *testing.T) {
+	dir := testutil.TempDir(t)
+	testutil.CreateTempFile(t, dir, "notes.txt", "keep")
+
+	config := models.QuickPreset()
+	config.ProjectName = "app"
+	config.ProjectPath = dir
+	_, err := generators.Generate(config, io.Discard)
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertFileContains(t, filepath.Join(dir, "notes.txt"), "keep")
+	testutil.AssertFileExists(t, filepath.Join(dir, "package.json"))
+
+	// Only the manifest and base copies are left in .frontforge; staging is cleaned up
+	entries, _ := os.ReadDir(filepath.Join(dir, ".frontforge"))
+	if len(entries) != 2 || entries[0].Name() != manifest.BaseFile || entries[1].Name() != "manifest.json" {
+		t.Errorf("expected only %s and manifest.json in .frontforge, found %v", manifest.BaseFile, entries)
+	}
+}
+
+func TestGenerateFailureLeavesExistingDirectoryUntouched(t *testing.T) {
+	// Astro writes its Tailwind files before merging package.json, which
+	// fails here because there is none
+	dir := testutil.TempDir(t)
+	testutil.CreateTempFile(t, dir, "README.md", "mine")
+
+	config := models.Config{
+		ProjectName:    "app",
+		ProjectPath:    dir,
+		Framework:      models.FrameworkAstro,
+		Language:       models.LangTypeScript,
+		PackageManager: models.PackageManagerNpm,
+		Styling:        models.StylingTailwind,
+		Testing:        models.TestingNone,
+		NoScaffold:     true,
+	}
+	if _, err := generators.Generate(config, io.Discard); err == nil {
+		t.Fatal("expected generation to fail without package.json")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected only README.md to remain, found %d entries", len(entries))
+	}
+	testutil.AssertFileContains(t, filepath.Join(dir, "README.md"), "mine")
+}
+
+func TestGenerateFailureLeavesNoNewDirectory(t *testing.T) {
+	parent := testutil.TempDir(t)
+	config := models.Config{
+		ProjectName:    "app",
+		ProjectPath:    filepath.Join(parent, "app"),
+		Framework:      models.FrameworkAstro,
+		Language:       models.LangTypeScript,
+		PackageManager: models.PackageManagerNpm,
+		Styling:        models.StylingTailwind,
+		Testing:        models.TestingNone,
+		NoScaffold:     true,
+	}
+	if _, err := generators.Generate(config, io.Discard); err == nil {
+		t.Fatal("expected generation to fail without package.json")
+	}
+
+	entries, _ := os.ReadDir(parent)
+	if len(entries) != 0 {
+		t.Errorf("expected no leftovers in %s, found %d entries", parent, len(entries))
+	}
+}
+
+func TestGenerateWritesManifest(t *testing.T) {
+	dir := filepath.Join(testutil.TempDir(t), "app")
+	config := models.QuickPreset()
+	config.ProjectName = "app"
+	config.ProjectPath = dir
+	result, err := generators.Generate(config, io.Discard)
+	testutil.AssertNoError(t, err)
+
+	m, err := manifest.Load(vfs.Disk{}, dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, m.Config.ProjectName, "app")
+	testutil.AssertEqual(t, m.Config.ProjectPath, "")
+	if m.Dependencies["react"] == "" || m.DevDependencies["vite"] == "" {
+		t.Errorf("manifest should record package.json versions, got %v %v", m.Dependencies, m.DevDependencies)
+	}
+
+	// Every generated file is hashed and copied; the manifest itself is listed but not hashed
+	testutil.AssertEqual(t, len(m.Files), len(result.Files)-1)
+	for _, rel := range result.Files {
+		if rel == ".frontforge/manifest.json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		testutil.AssertNoError(t, err)
+		if !m.Owned(rel, data) {
+			t.Errorf("%s should match its manifest hash", rel)
+		}
+		base, err := manifest.ReadBase(vfs.Disk{}, dir, rel)
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, string(base), string(data))
+	}
+
+	// Regenerating replaces the manifest without reporting it as a conflict
+	config.ProjectName = "renamed"
+	config.OnConflict = models.OnConflictSkip
+	result, err = generators.Generate(config, io.Discard)
+	testutil.AssertNoError(t, err)
+	for _, c := range result.Conflicts {
+		if c.Path == ".frontforge/manifest.json" {
+			t.Error("the manifest should not be reported as a conflict")
+		}
+	}
+	m, err = manifest.Load(vfs.Disk{}, dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, m.Config.ProjectName, "renamed")
+}
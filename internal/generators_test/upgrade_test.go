@@ -0,0 +1,115 @@
+package generators_test
+
+import (
+	"frontforge/internal/generators"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"frontforge/internal/versions"
+	"frontforge/internal/vfs"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// replaceIn rewrites a file in the project and its base copy, as if an
+// older frontforge had generated old instead of new
+func replaceIn(t *testing.T, dir, rel, old, new string, base bool) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(rel))
+	data, err := os.ReadFile(path)
+	testutil.AssertNoError(t, err)
+	if !strings.Contains(string(data), old) {
+		t.Fatalf("%s does not contain %q", path, old)
+	}
+	testutil.AssertNoError(t, os.WriteFile(path, []byte(strings.Replace(string(data), old, new, 1)), 0644))
+	if !base {
+		return
+	}
+	data, err = manifest.ReadBase(vfs.Disk{}, dir, rel)
+	testutil.AssertNoError(t, err)
+	if !strings.Contains(string(data), old) {
+		t.Fatalf("the base copy of %s does not contain %q", rel, old)
+	}
+	testutil.AssertNoError(t, manifest.WriteBases(vfs.Disk{}, dir, map[string][]byte{rel: []byte(strings.Replace(string(data), old, new, 1))}))
+}
+
+func TestUpgrade(t *testing.T) {
+	dir := filepath.Join(testutil.TempDir(t), "app")
+	config := models.QuickPreset()
+	config.ProjectName = "app"
+	config.ProjectPath = dir
+	_, err := generators.Generate(config, io.Discard)
+	testutil.AssertNoError(t, err)
+
+	react := versions.Version("react")
+	zustand := versions.Version("zustand")
+
+	// An older template with an older react; the user pinned zustand
+	replaceIn(t, dir, "package.json", `"react": "`+react+`"`, `"react": "^18.0.0"`, true)
+	replaceIn(t, dir, "package.json", `"zustand": "`+zustand+`"`, `"zustand": "4.5.0"`, false)
+	// An older template the user didn't edit
+	replaceIn(t, dir, "index.html", "<title>app</title>", "<title>old</title>", true)
+	// An older template the user edited elsewhere
+	replaceIn(t, dir, "README.md", "# app", "# old", true)
+	testutil.CreateTempFile(t, dir, "README.md", mustRead(t, filepath.Join(dir, "README.md"))+"\nMy notes\n")
+	// An older template the user edited in the same place
+	replaceIn(t, dir, "eslint.config.js", "import js from", "import oldJs from", true)
+	replaceIn(t, dir, "eslint.config.js", "import oldJs from", "import myJs from", false)
+	// Deleted by the user
+	testutil.AssertNoError(t, os.Remove(filepath.Join(dir, "src", "App.tsx")))
+
+	plan, err := generators.PlanUpgrade(vfs.Disk{}, dir)
+	testutil.AssertNoError(t, err)
+	statuses := make(map[string]string)
+	for _, f := range plan.Files {
+		statuses[f.Path] = f.Status
+	}
+	for rel, want := range map[string]string{
+		"package.json":     generators.UpgradeMerged,
+		"index.html":       generators.UpgradeUpdated,
+		"README.md":        generators.UpgradeMerged,
+		"eslint.config.js": generators.UpgradeConflict,
+		"src/App.tsx":      generators.UpgradeDeleted,
+		"vite.config.ts":   generators.UpgradeUnchanged,
+	} {
+		if statuses[rel] != want {
+			t.Errorf("%s: status %q, want %q", rel, statuses[rel], want)
+		}
+	}
+	testutil.AssertEqual(t, plan.Conflicts(), 1)
+
+	testutil.AssertNoError(t, plan.Commit())
+	testutil.AssertFileContains(t, filepath.Join(dir, "package.json"), `"react": "`+react+`"`)
+	testutil.AssertFileContains(t, filepath.Join(dir, "package.json"), `"zustand": "4.5.0"`)
+	testutil.AssertFileContains(t, filepath.Join(dir, "index.html"), "<title>app</title>")
+	testutil.AssertFileContains(t, filepath.Join(dir, "README.md"), "# app")
+	testutil.AssertFileContains(t, filepath.Join(dir, "README.md"), "My notes")
+	testutil.AssertFileContains(t, filepath.Join(dir, "eslint.config.js"), "<<<<<<< current\nimport myJs from")
+	testutil.AssertFileNotExists(t, filepath.Join(dir, "src", "App.tsx"))
+
+	// The base copies now hold the current templates
+	base, err := manifest.ReadBase(vfs.Disk{}, dir, "package.json")
+	testutil.AssertNoError(t, err)
+	if !strings.Contains(string(base), `"zustand": "`+zustand+`"`) {
+		t.Errorf("base package.json should be the regenerated one:\n%s", base)
+	}
+	plan, err = generators.PlanUpgrade(vfs.Disk{}, dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, plan.Changed(), 0)
+}
+
+func TestUpgradeRequiresManifest(t *testing.T) {
+	if _, err := generators.PlanUpgrade(vfs.Disk{}, testutil.TempDir(t)); err != manifest.ErrNotFound {
+		t.Errorf("PlanUpgrade without a manifest = %v, want ErrNotFound", err)
+	}
+}
+
+func mustRead(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	testutil.AssertNoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,79 @@
+package generators_test
+
+import (
+	"context"
+	"frontforge/internal/generators"
+	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeNPM puts an npm on PATH that passes lint, echoes its arguments for
+// test, fails build and hangs on typecheck
+func fakeNPM(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake npm is a shell script")
+	}
+	bin := testutil.TempDir(t)
+	script := `#!/bin/sh
+case "$2" in
+lint) echo "lint ok" ;;
+test) echo "args: $*" ;;
+build) printf '\033[31mline 1\033[0m\nerror: build broke\n' >&2; exit 1 ;;
+typecheck) exec sleep 10 ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(bin, "npm"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestVerify(t *testing.T) {
+	fakeNPM(t)
+	dir := testutil.TempDir(t)
+	testutil.CreateTempFile(t, dir, "package.json", `{
+  "scripts": {"typecheck": "tsc -b", "lint": "eslint .", "test": "vitest", "build": "vite build", "dev": "vite"}
+}`)
+
+	var ran []string
+	config := models.Config{PackageManager: models.PackageManagerNpm}
+	results := generators.Verify(context.Background(), dir, config, time.Second, func(step generators.VerifyStep) {
+		ran = append(ran, step.ID)
+	})
+	testutil.AssertEqual(t, strings.Join(ran, ","), "typecheck,lint,test,build")
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4", len(results))
+	}
+
+	typecheck, lint, test, build := results[0], results[1], results[2], results[3]
+	if typecheck.Passed || !strings.Contains(typecheck.Message, "timed out after 1s") {
+		t.Errorf("typecheck should time out, got %+v", typecheck)
+	}
+	if !lint.Passed || lint.Output != "lint ok" {
+		t.Errorf("lint should pass with its output, got %+v", lint)
+	}
+	if !test.Passed || !strings.Contains(test.Output, "run test -- --passWithNoTests") {
+		t.Errorf("vitest should run with --passWithNoTests, got %+v", test)
+	}
+	if build.Passed || build.ID != generators.CheckBuild || build.Output != "line 1\nerror: build broke" {
+		t.Errorf("build should fail with its output minus colors, got %+v", build)
+	}
+}
+
+func TestVerifySkipsMissingScripts(t *testing.T) {
+	fakeNPM(t)
+	dir := testutil.TempDir(t)
+	testutil.CreateTempFile(t, dir, "package.json", `{"scripts": {"lint": "eslint ."}}`)
+
+	results := generators.Verify(context.Background(), dir, models.Config{}, 0, nil)
+	if len(results) != 1 || results[0].ID != generators.CheckLint || !results[0].Passed {
+		t.Errorf("only lint should run, got %+v", results)
+	}
+}
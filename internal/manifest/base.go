@@ -0,0 +1,135 @@
+package manifest
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"frontforge/internal/vfs"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+)
+
+// BaseFile keeps the generated content of every file inside vfs.StateDir.
+// It is one gzipped tarball rather than a tree of copies so linters, test
+// runners, TypeScript includes and Tailwind's class scanner never mistake
+// the copies for the project's sources.
+const BaseFile = "base.tar.gz"
+
+// BasePath returns where the base copies of the project in dir are kept
+func BasePath(dir string) string {
+	return filepath.Join(dir, vfs.StateDir, BaseFile)
+}
+
+// ReadBases returns every base copy of the project in dir, by slash-separated
+// path relative to it. Projects without BaseFile return an empty map.
+func ReadBases(fsys vfs.FS, dir string) (map[string][]byte, error) {
+	bases := make(map[string][]byte)
+	data, err := fsys.ReadFile(BasePath(dir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return bases, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("corrupt %s: %w", BasePath(dir), err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return bases, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("corrupt %s: %w", BasePath(dir), err)
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("corrupt %s: %w", BasePath(dir), err)
+		}
+		bases[h.Name] = content
+	}
+}
+
+// ReadBase returns the content a file was generated with, by its path
+// relative to the project in dir. Files that weren't generated report
+// fs.ErrNotExist.
+func ReadBase(fsys vfs.FS, dir, rel string) ([]byte, error) {
+	bases, err := ReadBases(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := bases[filepath.ToSlash(rel)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: BasePath(dir) + ":" + filepath.ToSlash(rel), Err: fs.ErrNotExist}
+	}
+	return data, nil
+}
+
+// WriteBases keeps the generated content of files for later merges, by
+// slash-separated path relative to the project in dir. Copies of other
+// files are kept.
+func WriteBases(fsys vfs.FS, dir string, files map[string][]byte) error {
+	bases, err := ReadBases(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for rel, data := range files {
+		bases[filepath.ToSlash(rel)] = data
+	}
+	return writeBases(fsys, dir, bases)
+}
+
+// RemoveBases drops the copies of files, by slash-separated path relative to
+// the project in dir
+func RemoveBases(fsys vfs.FS, dir string, files []string) error {
+	bases, err := ReadBases(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for _, rel := range files {
+		delete(bases, filepath.ToSlash(rel))
+	}
+	return writeBases(fsys, dir, bases)
+}
+
+// writeBases replaces BaseFile with exactly the given copies
+func writeBases(fsys vfs.FS, dir string, bases map[string][]byte) error {
+	names := make([]string, 0, len(bases))
+	for rel := range bases {
+		names = append(names, rel)
+	}
+	sort.Strings(names)
+
+	// No timestamps, so the same copies always make the same file
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, rel := range names {
+		h := &tar.Header{Name: rel, Mode: 0644, Size: int64(len(bases[rel])), Typeflag: tar.TypeReg, Format: tar.FormatPAX}
+		if err := tw.WriteHeader(h); err != nil {
+			return err
+		}
+		if _, err := tw.Write(bases[rel]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	if err := fsys.MkdirAll(filepath.Join(dir, vfs.StateDir)); err != nil {
+		return err
+	}
+	return fsys.WriteFile(BasePath(dir), buf.Bytes(), 0644)
+}
@@ -0,0 +1,197 @@
+// Package manifest records how a project was generated.
+//
+// Generation writes .frontforge/manifest.json with the frontforge version,
+// the configuration, the dependency versions that went into package.json and
+// a hash of every generated file, plus a copy of each file in
+// .frontforge/base.tar.gz. It also records which files, dependencies and
+// scripts each selected option brought in. Later commands read them back to
+// regenerate the project, to tell files frontforge still owns from files the
+// user has edited, to take an option back out, and as the common ancestor
+// when merging newer templates into edited files.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+	"io/fs"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+)
+
+// CurrentVersion is the manifest format version written by this build
+const CurrentVersion = 1
+
+// FileName is the manifest's name inside vfs.StateDir
+const FileName = "manifest.json"
+
+// ToolVersion is the frontforge version recorded in manifests. Release
+// builds set it with -ldflags "-X frontforge/internal/manifest.ToolVersion=1.2.3".
+var ToolVersion = "dev"
+
+// ErrNotFound is returned by Load when a project has no manifest
+var ErrNotFound = errors.New("no frontforge manifest (was the project generated by frontforge?)")
+
+// Manifest describes one generation of a project
+type Manifest struct {
+	Version         int               `json:"version"`
+	Tool            string            `json:"tool"` // frontforge version
+	GeneratedAt     time.Time         `json:"generatedAt"`
+	Config          models.Config     `json:"config"`
+	Dependencies    map[string]string `json:"dependencies"`    // From the generated package.json
+	DevDependencies map[string]string `json:"devDependencies"` // From the generated package.json
+	Files           map[string]string `json:"files"`           // Slash-separated path relative to the project -> Hash
+
+	// Options maps a registry key (see models.Registry) to what its selected
+	// option brought in. Options that add nothing have no entry. It is nil
+	// for meta-frameworks and in manifests written before ownership was
+	// tracked.
+	Options map[string]*Ownership `json:"options"`
+}
+
+// Ownership is what one option added to a project: the files, package.json
+// entries and scripts that are only there because it was selected
+type Ownership struct {
+	Files           []string `json:"files,omitempty"` // Slash-separated, relative to the project
+	Dependencies    []string `json:"dependencies,omitempty"`
+	DevDependencies []string `json:"devDependencies,omitempty"`
+	Scripts         []string `json:"scripts,omitempty"`
+}
+
+// New creates an empty manifest for config. Settings that only apply to one
+// run (the output path, dry run, verification and conflict policy) are not
+// recorded.
+func New(config models.Config) *Manifest {
+	config.ProjectPath = ""
+	config.DryRun = false
+	config.DryRunFormat = ""
+	config.Verify = false
+	config.OnConflict = ""
+	return &Manifest{
+		Version:         CurrentVersion,
+		Tool:            Tool(),
+		GeneratedAt:     time.Now().UTC().Truncate(time.Second),
+		Config:          config,
+		Dependencies:    map[string]string{},
+		DevDependencies: map[string]string{},
+		Files:           map[string]string{},
+	}
+}
+
+// Tool returns the running frontforge version: ToolVersion, or the module
+// version for builds installed with go install
+func Tool() string {
+	if ToolVersion != "dev" {
+		return ToolVersion
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return ToolVersion
+}
+
+// Path returns where the manifest of the project in dir is stored
+func Path(dir string) string {
+	return filepath.Join(dir, vfs.StateDir, FileName)
+}
+
+// Hash returns the content hash recorded for a file
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// AddFile records the generated content of a file, by its path relative to
+// the project
+func (m *Manifest) AddFile(rel string, data []byte) {
+	m.Files[filepath.ToSlash(rel)] = Hash(data)
+}
+
+// RemoveFile forgets a file, by its path relative to the project, and drops
+// it from the options that brought it in
+func (m *Manifest) RemoveFile(rel string) {
+	rel = filepath.ToSlash(rel)
+	delete(m.Files, rel)
+	for key, o := range m.Options {
+		for i, f := range o.Files {
+			if f == rel {
+				o.Files = append(o.Files[:i], o.Files[i+1:]...)
+				break
+			}
+		}
+		if len(o.Files)+len(o.Dependencies)+len(o.DevDependencies)+len(o.Scripts) == 0 {
+			delete(m.Options, key)
+		}
+	}
+}
+
+// SetPackageJSON records the dependency versions in a generated package.json
+func (m *Manifest) SetPackageJSON(data []byte) error {
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return fmt.Errorf("failed to read package.json: %w", err)
+	}
+	for name, version := range pkg.Dependencies {
+		m.Dependencies[name] = version
+	}
+	for name, version := range pkg.DevDependencies {
+		m.DevDependencies[name] = version
+	}
+	return nil
+}
+
+// Owned reports whether a file is still as frontforge generated it, given
+// its current content. Files that weren't generated are never owned.
+func (m *Manifest) Owned(rel string, current []byte) bool {
+	hash, ok := m.Files[filepath.ToSlash(rel)]
+	return ok && hash == Hash(current)
+}
+
+// Write stores the manifest in the project in dir
+func (m *Manifest) Write(fsys vfs.FS, dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := fsys.MkdirAll(filepath.Join(dir, vfs.StateDir)); err != nil {
+		return err
+	}
+	return fsys.WriteFile(Path(dir), append(data, '\n'), 0644)
+}
+
+// Load reads the manifest of the project in dir. It returns ErrNotFound if
+// there is none.
+func Load(fsys vfs.FS, dir string) (*Manifest, error) {
+	data, err := fsys.ReadFile(Path(dir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("corrupt manifest %s: %w", Path(dir), err)
+	}
+	if m.Version > CurrentVersion {
+		return nil, fmt.Errorf("manifest %s is version %d; this frontforge understands up to %d", Path(dir), m.Version, CurrentVersion)
+	}
+	if m.Dependencies == nil {
+		m.Dependencies = map[string]string{}
+	}
+	if m.DevDependencies == nil {
+		m.DevDependencies = map[string]string{}
+	}
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+	return &m, nil
+}
@@ -0,0 +1,84 @@
+package manifest_test
+
+import (
+	"errors"
+	"frontforge/internal/manifest"
+	"frontforge/internal/models"
+	"frontforge/internal/testutil"
+	"frontforge/internal/vfs"
+	"io/fs"
+	"path/filepath"
+	"testing"
+)
+
+func TestManifestRoundTrip(t *testing.T) {
+	dir := filepath.Join(string(filepath.Separator), "work", "app")
+	mem := vfs.NewMemory()
+	testutil.AssertNoError(t, mem.MkdirAll(dir))
+
+	if _, err := manifest.Load(mem, dir); !errors.Is(err, manifest.ErrNotFound) {
+		t.Fatalf("Load without a manifest = %v, want ErrNotFound", err)
+	}
+
+	config := models.QuickPreset()
+	config.ProjectName = "app"
+	config.ProjectPath = dir
+	config.OnConflict = models.OnConflictMerge
+
+	m := manifest.New(config)
+	m.AddFile("src/main.tsx", []byte("render()"))
+	testutil.AssertNoError(t, m.SetPackageJSON([]byte(`{"dependencies":{"react":"^19.1.0"},"devDependencies":{"vite":"^7.0.0"}}`)))
+	testutil.AssertNoError(t, m.Write(mem, dir))
+
+	loaded, err := manifest.Load(mem, dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, loaded.Version, manifest.CurrentVersion)
+	testutil.AssertEqual(t, loaded.Tool, manifest.Tool())
+	testutil.AssertEqual(t, loaded.Config.Framework, models.FrameworkReact)
+	testutil.AssertEqual(t, loaded.Config.ProjectPath, "") // Per-run settings aren't recorded
+	testutil.AssertEqual(t, loaded.Config.OnConflict, "")
+	testutil.AssertEqual(t, loaded.Dependencies["react"], "^19.1.0")
+	testutil.AssertEqual(t, loaded.DevDependencies["vite"], "^7.0.0")
+
+	if !loaded.Owned("src/main.tsx", []byte("render()")) {
+		t.Error("unchanged file should be owned")
+	}
+	if loaded.Owned("src/main.tsx", []byte("render(edited)")) {
+		t.Error("edited file should not be owned")
+	}
+	if loaded.Owned("src/extra.ts", []byte("")) {
+		t.Error("file that wasn't generated should not be owned")
+	}
+}
+
+func TestManifestRejectsNewerVersion(t *testing.T) {
+	dir := testutil.TempDir(t)
+	testutil.CreateTempFile(t, filepath.Join(dir, ".frontforge"), "manifest.json", `{"version": 99}`)
+	if _, err := manifest.Load(vfs.Disk{}, dir); err == nil {
+		t.Error("expected an error for a newer manifest version")
+	}
+}
+
+func TestBases(t *testing.T) {
+	dir := filepath.Join(string(filepath.Separator), "app")
+	fsys := vfs.NewMemory()
+	testutil.AssertNoError(t, manifest.WriteBases(fsys, dir, map[string][]byte{
+		"src/App.tsx":  []byte("app"),
+		"package.json": []byte("{}"),
+	}))
+	testutil.AssertNoError(t, manifest.WriteBases(fsys, dir, map[string][]byte{"src/App.tsx": []byte("app v2")}))
+
+	data, err := manifest.ReadBase(fsys, dir, "src/App.tsx")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, string(data), "app v2")
+	data, err = manifest.ReadBase(fsys, dir, "package.json")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, string(data), "{}")
+	if _, err := manifest.ReadBase(fsys, dir, "README.md"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing copy: err = %v, want fs.ErrNotExist", err)
+	}
+
+	// One archive, so tools scanning the project never see the copies as sources
+	testutil.AssertEqual(t, len(fsys.Files()), 1)
+	testutil.AssertEqual(t, fsys.Files()[0], manifest.BasePath(dir))
+}
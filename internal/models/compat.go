@@ -0,0 +1,200 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule requires an option to be combined with one of a set of values of
+// another option. Framework support is declared on each Option in the
+// registry; rules cover constraints between options.
+type Rule struct {
+	Key        string   // Registry key of the restricted option
+	Value      string   // Restricted option value
+	Requires   string   // Registry key the option depends on
+	OneOf      []string // Values of Requires the option works with
+	Suggestion string   // Replacement for Value when the rule is broken
+}
+
+// Rules lists every compatibility constraint between options
+var Rules = []Rule{
+	{
+		Key: "uiLibrary", Value: UILibraryShadcn,
+		Requires: "styling", OneOf: []string{StylingTailwind},
+		Suggestion: UILibraryNone, // Shadcn/ui components are styled with Tailwind
+	},
+}
+
+// Violation is one incompatible option in a config
+type Violation struct {
+	Key        string `json:"key"`                  // Registry key, e.g. "icons"
+	Value      string `json:"value"`                // Incompatible value
+	Suggestion string `json:"suggestion,omitempty"` // Compatible replacement for Value
+	Message    string `json:"message"`
+}
+
+// CompatibilityError reports every violation found in a config
+type CompatibilityError struct {
+	Violations []Violation
+}
+
+func (e *CompatibilityError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+		if v.Suggestion != "" {
+			messages[i] += fmt.Sprintf(" (use '%s')", v.Suggestion)
+		}
+	}
+	if len(messages) == 1 {
+		return messages[0]
+	}
+	return fmt.Sprintf("%d incompatible options: %s", len(messages), strings.Join(messages, "; "))
+}
+
+// CheckCompatibility returns every violation in config, in registry order.
+// Empty values are treated as unset and never violate anything, so partial
+// configs (e.g. a config file without a framework) can be checked too.
+func CheckCompatibility(config Config) []Violation {
+	var violations []Violation
+	for i := range Registry {
+		g := &Registry[i]
+		if v, ok := g.check(config); ok {
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// FixCompatibility replaces incompatible values with their suggestions and
+// returns the violations it fixed. Only keys for which fix returns true are
+// changed (nil fixes every key), so explicit user choices can be kept and
+// reported while conflicting defaults are replaced.
+func FixCompatibility(config *Config, fix func(key string) bool) []Violation {
+	var fixed []Violation
+	// A replacement can break a rule of a later option, so repeat until stable
+	for pass := 0; pass < len(Registry); pass++ {
+		changed := false
+		for _, v := range CheckCompatibility(*config) {
+			if (fix != nil && !fix(v.Key)) || v.Suggestion == "" {
+				continue
+			}
+			g, _ := LookupGroup(v.Key)
+			*g.Field(config) = v.Suggestion
+			fixed = append(fixed, v)
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+	return fixed
+}
+
+// RuleDependencies returns the registry keys whose values restrict key's options
+func RuleDependencies(key string) []string {
+	var deps []string
+	for _, r := range Rules {
+		if r.Key == key && !containsString(deps, r.Requires) {
+			deps = append(deps, r.Requires)
+		}
+	}
+	return deps
+}
+
+// Available reports whether option o can be selected together with config
+func (g *OptionGroup) Available(o Option, config Config) bool {
+	if config.Framework != "" && !o.Supports(config.Framework) {
+		return false
+	}
+	_, broken := brokenRule(g.Key, o.Value, config)
+	return !broken
+}
+
+// AvailableOptions returns the options that can be selected together with
+// config, in registry order. Hidden options are included only when
+// includeHidden is true.
+func (g *OptionGroup) AvailableOptions(config Config, includeHidden bool) []Option {
+	if config.Framework != "" && !g.AppliesTo(config.Framework) {
+		return nil
+	}
+	var options []Option
+	for _, o := range g.Options {
+		if (includeHidden || !o.Hidden) && g.Available(o, config) {
+			options = append(options, o)
+		}
+	}
+	return options
+}
+
+// check returns the violation for g's value in config, if any
+func (g *OptionGroup) check(config Config) (Violation, bool) {
+	value := *g.Field(&config)
+	o, ok := g.Find(value)
+	if !ok || (config.Framework != "" && !g.AppliesTo(config.Framework)) {
+		return Violation{}, false
+	}
+
+	if config.Framework != "" && !o.Supports(config.Framework) {
+		return Violation{
+			Key:        g.Key,
+			Value:      value,
+			Suggestion: g.suggest(config),
+			Message:    fmt.Sprintf("%s '%s' is not compatible with %s", g.Name, value, config.Framework),
+		}, true
+	}
+
+	if r, broken := brokenRule(g.Key, value, config); broken {
+		requires, _ := LookupGroup(r.Requires)
+		suggestion := r.Suggestion
+		if suggestion == "" {
+			suggestion = g.suggest(config)
+		}
+		return Violation{
+			Key:        g.Key,
+			Value:      value,
+			Suggestion: suggestion,
+			Message:    fmt.Sprintf("%s '%s' requires %s %s", g.Name, value, requires.Name, quoteJoin(r.OneOf)),
+		}, true
+	}
+
+	return Violation{}, false
+}
+
+// suggest returns the first option that can replace g's value in config
+func (g *OptionGroup) suggest(config Config) string {
+	for _, includeHidden := range []bool{false, true} {
+		if options := g.AvailableOptions(config, includeHidden); len(options) > 0 {
+			return options[0].Value
+		}
+	}
+	return ""
+}
+
+// brokenRule returns the first rule that value of key breaks in config.
+// Rules whose required option is unset are not evaluated.
+func brokenRule(key, value string, config Config) (Rule, bool) {
+	for _, r := range Rules {
+		if r.Key != key || r.Value != value {
+			continue
+		}
+		requires, ok := LookupGroup(r.Requires)
+		if !ok {
+			continue
+		}
+		current := *requires.Field(&config)
+		if current != "" && !containsString(r.OneOf, current) {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// quoteJoin formats values as 'a' or 'b'
+func quoteJoin(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + v + "'"
+	}
+	return strings.Join(quoted, " or ")
+}
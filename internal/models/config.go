@@ -12,27 +12,31 @@ package models
 
 // Config holds all project configuration
 type Config struct {
-	ProjectName     string
-	ProjectPath     string // Absolute path where project will be created
-	Language        string
-	Framework       string
-	PackageManager  string
-	Styling         string
-	UILibrary       string
-	Routing         string
-	Testing         string
-	StateManagement string
-	FormManagement  string
-	DataFetching    string
-	Animation       string
-	Icons           string
-	DataViz         string
-	Utilities       string
-	I18n            string
-	Structure       string
-	DryRun          bool // Preview mode - show what would be generated without writing files
-	AutoInstall     bool // Automatically run package manager install after generation
-	NoScaffold      bool // Skip upstream CLI scaffold (meta-frameworks only, for debugging)
+	ProjectName     string `json:"projectName"`
+	ProjectPath     string `json:"projectPath"` // Absolute path where project will be created
+	Language        string `json:"language"`
+	Framework       string `json:"framework"`
+	PackageManager  string `json:"packageManager"`
+	Styling         string `json:"styling"`
+	UILibrary       string `json:"uiLibrary"`
+	Routing         string `json:"routing"`
+	Testing         string `json:"testing"`
+	StateManagement string `json:"stateManagement"`
+	FormManagement  string `json:"formManagement"`
+	DataFetching    string `json:"dataFetching"`
+	Animation       string `json:"animation"`
+	Icons           string `json:"icons"`
+	DataViz         string `json:"dataViz"`
+	Utilities       string `json:"utilities"`
+	I18n            string `json:"i18n"`
+	Structure       string `json:"structure"`
+	DryRun          bool   `json:"dryRun"`        // Preview mode - show what would be generated without writing files
+	DryRunFormat    string `json:"dryRunFormat"`  // How a dry run is shown (DryRun*); empty means a tree
+	AutoInstall     bool   `json:"autoInstall"`   // Automatically run package manager install after generation
+	Verify          bool   `json:"verify"`        // Run the typecheck, lint, test and build scripts after install
+	NoScaffold      bool   `json:"noScaffold"`    // Skip upstream CLI scaffold (meta-frameworks only, for debugging)
+	ResolveLatest   bool   `json:"resolveLatest"` // Use the latest published versions instead of the embedded catalog
+	OnConflict      string `json:"onConflict"`    // How to handle files that already exist (OnConflict*); empty means overwrite
 }
 
 // SetupMode defines quick or custom setup
@@ -73,6 +77,40 @@ func IsMetaFramework(framework string) bool {
 	return false
 }
 
+// ValidProjectName reports whether name is a non-empty project name made of
+// letters, digits, hyphens and underscores
+func ValidProjectName(name string) bool {
+	for _, r := range name {
+		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// Conflict policies for generated files that already exist with different content
+const (
+	OnConflictSkip      = "skip"      // Keep the existing file
+	OnConflictOverwrite = "overwrite" // Replace it
+	OnConflictBackup    = "backup"    // Replace it, keeping a copy as <name>.bak
+	OnConflictMerge     = "merge"     // Merge package.json, tsconfig and .gitignore; keep other files
+	OnConflictPrompt    = "prompt"    // Ask for each file
+)
+
+// OnConflictPolicies lists the accepted conflict policies
+var OnConflictPolicies = []string{OnConflictSkip, OnConflictOverwrite, OnConflictBackup, OnConflictMerge, OnConflictPrompt}
+
+// Dry-run formats
+const (
+	DryRunTree  = "tree"  // The files that would be written, as a tree
+	DryRunDiff  = "diff"  // Unified diffs against the files on disk
+	DryRunJSON  = "json"  // Every entry with its size and SHA-256, as JSON
+	DryRunFiles = "files" // The content of every file, each under a header
+)
+
+// DryRunFormats lists the accepted dry-run formats
+var DryRunFormats = []string{DryRunTree, DryRunDiff, DryRunJSON, DryRunFiles}
+
 // Package managers
 const (
 	PackageManagerNpm  = "npm"
@@ -0,0 +1,656 @@
+package models
+
+import (
+	"fmt"
+	"frontforge/internal/versions"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Package is an npm dependency brought in by an option.
+// Its version comes from the embedded catalog (see Version).
+type Package struct {
+	Name       string
+	Dev        bool     // devDependencies instead of dependencies
+	Frameworks []string // Only installed for these frameworks (nil = any)
+}
+
+// Version returns the package's version range from the version catalog
+func (p Package) Version() string {
+	return versions.Version(p.Name)
+}
+
+// Option is one selectable value of an OptionGroup
+type Option struct {
+	ID         string    // Short CLI value, e.g. "tailwind"
+	Value      string    // Config constant, e.g. StylingTailwind
+	Label      string    // TUI label
+	Aliases    []string  // Other accepted CLI spellings
+	Frameworks []string  // Frameworks that support this option (nil = all the group applies to)
+	Hidden     bool      // Accepted from flags and config files but not offered in the TUI
+	Packages   []Package // Dependencies added when selected
+}
+
+// OptionGroup describes one Config field and every value it accepts.
+// The registry is the single source for CLI flags, TUI questions,
+// config file keys, compatibility and dependency selection.
+type OptionGroup struct {
+	Key         string   // Config file key, e.g. "stateManagement"
+	Flag        string   // CLI flag name without the dash ("" = no flag)
+	Name        string   // Noun used in messages, e.g. "state management"
+	Title       string   // TUI question
+	Description string   // Config file schema description
+	Frameworks  []string // Frameworks this question applies to (nil = all)
+	Field       func(*Config) *string
+	Options     []Option
+}
+
+// Framework groupings used by the registry
+var (
+	// AllFrameworks lists every framework in display order
+	AllFrameworks = []string{
+		FrameworkReact, FrameworkVue, FrameworkAngular, FrameworkSvelte, FrameworkSolid, FrameworkVanilla,
+		FrameworkNextJS, FrameworkAstro, FrameworkSvelteKit,
+	}
+
+	// ViteFrameworks lists the frameworks generated by FrontForge's own Vite templates
+	ViteFrameworks = []string{
+		FrameworkReact, FrameworkVue, FrameworkAngular, FrameworkSvelte, FrameworkSolid, FrameworkVanilla,
+	}
+
+	onlyReact    = []string{FrameworkReact}
+	onlyVue      = []string{FrameworkVue}
+	onlyAngular  = []string{FrameworkAngular}
+	reactAndNext = []string{FrameworkReact, FrameworkNextJS}
+	reactAndVue  = []string{FrameworkReact, FrameworkVue}
+	viteAndNext  = append(append([]string{}, ViteFrameworks...), FrameworkNextJS)
+	notAstro     = []string{
+		FrameworkReact, FrameworkVue, FrameworkAngular, FrameworkSvelte, FrameworkSolid, FrameworkVanilla,
+		FrameworkNextJS, FrameworkSvelteKit,
+	}
+)
+
+// testingLibraries are the framework-specific testing libraries shared by Vitest and Jest
+var testingLibraries = []Package{
+	{Name: "@testing-library/jest-dom", Dev: true},
+	{Name: "@testing-library/react", Dev: true, Frameworks: onlyReact},
+	{Name: "@vue/test-utils", Dev: true, Frameworks: onlyVue},
+	{Name: "@testing-library/svelte", Dev: true, Frameworks: []string{FrameworkSvelte}},
+	// Vanilla JS/TS and the remaining frameworks use @testing-library/dom
+	{Name: "@testing-library/dom", Dev: true, Frameworks: []string{
+		FrameworkAngular, FrameworkSolid, FrameworkVanilla, FrameworkNextJS, FrameworkAstro, FrameworkSvelteKit}},
+}
+
+// Registry lists every option group in question order
+var Registry = []OptionGroup{
+	{
+		Key: "language", Flag: "lang", Name: "language",
+		Title: "Select your language", Description: "Programming language",
+		Field: func(c *Config) *string { return &c.Language },
+		Options: []Option{
+			{ID: "ts", Value: LangTypeScript, Label: "TypeScript (recommended)", Aliases: []string{"typescript"},
+				Packages: []Package{
+					{Name: "typescript", Dev: true},
+					// Type checkers for single-file components, run by the typecheck script
+					{Name: "vue-tsc", Dev: true, Frameworks: onlyVue},
+					{Name: "svelte-check", Dev: true, Frameworks: []string{FrameworkSvelte}},
+				}},
+			{ID: "js", Value: LangJavaScript, Label: "JavaScript", Aliases: []string{"javascript"}},
+		},
+	},
+	{
+		Key: "framework", Flag: "framework", Name: "framework",
+		Title: "Choose a framework", Description: "Frontend framework",
+		Field: func(c *Config) *string { return &c.Framework },
+		Options: []Option{
+			{ID: "react", Value: FrameworkReact, Label: "React", Packages: []Package{
+				{Name: "react"},
+				{Name: "react-dom"},
+				{Name: "@vitejs/plugin-react", Dev: true},
+				{Name: "@types/react", Dev: true},
+				{Name: "@types/react-dom", Dev: true},
+				{Name: "eslint-plugin-react-hooks", Dev: true},
+				{Name: "eslint-plugin-react-refresh", Dev: true},
+			}},
+			{ID: "vue", Value: FrameworkVue, Label: "Vue", Packages: []Package{
+				{Name: "vue"},
+				{Name: "@vitejs/plugin-vue", Dev: true},
+				{Name: "eslint-plugin-vue", Dev: true},
+			}},
+			{ID: "angular", Value: FrameworkAngular, Label: "Angular (standalone)", Packages: []Package{
+				// Angular 21 core packages
+				{Name: "@angular/core"},
+				{Name: "@angular/common"},
+				{Name: "@angular/platform-browser"},
+				{Name: "@angular/platform-browser-dynamic"},
+				{Name: "@angular/compiler"},
+				{Name: "rxjs"},
+				{Name: "zone.js"},
+				{Name: "tslib"},
+				// Vite plugin for Angular (AnalogJS)
+				{Name: "@analogjs/vite-plugin-angular", Dev: true},
+			}},
+			{ID: "svelte", Value: FrameworkSvelte, Label: "Svelte", Packages: []Package{
+				{Name: "svelte"},
+				{Name: "@sveltejs/vite-plugin-svelte", Dev: true},
+			}},
+			{ID: "solid", Value: FrameworkSolid, Label: "Solid", Packages: []Package{
+				{Name: "solid-js"},
+				{Name: "vite-plugin-solid", Dev: true},
+			}},
+			{ID: "vanilla", Value: FrameworkVanilla, Label: "Vanilla (no framework)"},
+			// Meta-frameworks (shell out to upstream CLIs, which install their own packages)
+			{ID: "nextjs", Value: FrameworkNextJS, Label: "Next.js (React)", Aliases: []string{"next", "next.js"}},
+			{ID: "astro", Value: FrameworkAstro, Label: "Astro (content-focused)"},
+			{ID: "sveltekit", Value: FrameworkSvelteKit, Label: "SvelteKit (Svelte)", Aliases: []string{"svelte-kit"}},
+		},
+	},
+	{
+		Key: "packageManager", Flag: "pm", Name: "package manager",
+		Title: "Package manager", Description: "Package manager",
+		Field: func(c *Config) *string { return &c.PackageManager },
+		Options: []Option{
+			{ID: "npm", Value: PackageManagerNpm, Label: "npm"},
+			{ID: "yarn", Value: PackageManagerYarn, Label: "yarn"},
+			{ID: "pnpm", Value: PackageManagerPnpm, Label: "pnpm"},
+			{ID: "bun", Value: PackageManagerBun, Label: "bun"},
+		},
+	},
+	{
+		Key: "styling", Flag: "styling", Name: "styling",
+		Title: "How would you like to style your app?", Description: "Styling solution",
+		Field: func(c *Config) *string { return &c.Styling },
+		Options: []Option{
+			{ID: "tailwind", Value: StylingTailwind, Label: "Tailwind CSS", Aliases: []string{"tailwindcss"}, Packages: []Package{
+				{Name: "tailwindcss", Dev: true},
+				{Name: "@tailwindcss/vite", Dev: true},
+			}},
+			{ID: "bootstrap", Value: StylingBootstrap, Label: "Bootstrap", Frameworks: ViteFrameworks, Packages: []Package{
+				{Name: "bootstrap"},
+			}},
+			{ID: "css-modules", Value: StylingCSSModules, Label: "CSS Modules", Aliases: []string{"cssmodules"}},
+			{ID: "sass", Value: StylingSass, Label: "Sass/SCSS", Aliases: []string{"scss"}, Packages: []Package{
+				{Name: "sass", Dev: true},
+			}},
+			{ID: "styled", Value: StylingStyled, Label: "Styled Components", Aliases: []string{"styled-components"},
+				Frameworks: onlyReact, Packages: []Package{
+					{Name: "styled-components"},
+				}},
+			{ID: "vanilla", Value: StylingVanilla, Label: "Vanilla CSS", Aliases: []string{"css"}},
+		},
+	},
+	{
+		Key: "uiLibrary", Flag: "ui", Name: "UI library",
+		Title: "UI Component Library", Description: "UI component library",
+		Frameworks: ViteFrameworks,
+		Field:      func(c *Config) *string { return &c.UILibrary },
+		Options: []Option{
+			// React
+			{ID: "shadcn", Value: UILibraryShadcn, Label: "Shadcn/ui (recommended)", Frameworks: onlyReact, Packages: []Package{
+				// Shadcn requires manual setup, add base dependencies
+				{Name: "class-variance-authority"},
+				{Name: "clsx"},
+				{Name: "tailwind-merge"},
+				{Name: "@radix-ui/react-slot"},
+			}},
+			{ID: "mui", Value: UILibraryMUI, Label: "Material-UI (MUI)", Aliases: []string{"material-ui"}, Frameworks: onlyReact, Packages: []Package{
+				{Name: "@mui/material"},
+				{Name: "@emotion/react"},
+				{Name: "@emotion/styled"},
+			}},
+			{ID: "chakra", Value: UILibraryChakra, Label: "Chakra UI", Aliases: []string{"chakra-ui"}, Frameworks: onlyReact, Packages: []Package{
+				{Name: "@chakra-ui/react"},
+				{Name: "@emotion/react"},
+				{Name: "@emotion/styled"},
+			}},
+			{ID: "antd", Value: UILibraryAntD, Label: "Ant Design", Aliases: []string{"ant-design"}, Frameworks: onlyReact, Packages: []Package{
+				{Name: "antd"},
+			}},
+			{ID: "headless", Value: UILibraryHeadless, Label: "Headless UI", Aliases: []string{"headless-ui"}, Frameworks: onlyReact, Packages: []Package{
+				{Name: "@headlessui/react"},
+			}},
+			// Vue
+			{ID: "vuetify", Value: UILibraryVuetify, Label: "Vuetify", Frameworks: onlyVue, Packages: []Package{
+				{Name: "vuetify"},
+			}},
+			{ID: "primevue", Value: UILibraryPrimeVue, Label: "PrimeVue", Frameworks: onlyVue, Packages: []Package{
+				{Name: "primevue"},
+			}},
+			{ID: "element-plus", Value: UILibraryElementUI, Label: "Element Plus", Aliases: []string{"element"}, Frameworks: onlyVue, Packages: []Package{
+				{Name: "element-plus"},
+			}},
+			{ID: "naive-ui", Value: UILibraryNaiveUI, Label: "Naive UI", Aliases: []string{"naive"}, Frameworks: onlyVue, Packages: []Package{
+				{Name: "naive-ui"},
+			}},
+			// Angular
+			{ID: "angular-material", Value: UILibraryAngularMaterial, Label: "Angular Material", Aliases: []string{"material"}, Frameworks: onlyAngular, Packages: []Package{
+				{Name: "@angular/material"},
+			}},
+			{ID: "primeng", Value: UILibraryPrimeNG, Label: "PrimeNG", Frameworks: onlyAngular, Packages: []Package{
+				{Name: "primeng"},
+			}},
+			{ID: "ng-zorro", Value: UILibraryNGZorro, Label: "NG-ZORRO", Frameworks: onlyAngular, Packages: []Package{
+				{Name: "ng-zorro-antd"},
+			}},
+			{ID: "none", Value: UILibraryNone, Label: "None"},
+		},
+	},
+	{
+		Key: "routing", Flag: "routing", Name: "routing",
+		Title: "Routing solution", Description: "Routing solution",
+		Field: func(c *Config) *string { return &c.Routing },
+		Options: []Option{
+			{ID: "react-router", Value: RoutingReactRouter, Label: "React Router", Frameworks: onlyReact, Packages: []Package{
+				{Name: "react-router"},
+			}},
+			{ID: "tanstack-router", Value: RoutingTanStackRouter, Label: "TanStack Router", Frameworks: onlyReact, Packages: []Package{
+				{Name: "@tanstack/react-router"},
+			}},
+			{ID: "file-based", Value: RoutingFileBased, Label: "File-based routing", Frameworks: onlyReact},
+			{ID: "vue-router", Value: RoutingVueRouter, Label: "Vue Router", Frameworks: onlyVue, Packages: []Package{
+				{Name: "vue-router"},
+			}},
+			{ID: "angular-router", Value: RoutingAngularRouter, Label: "Angular Router", Frameworks: onlyAngular},
+			{ID: "sveltekit", Value: RoutingSvelteKit, Label: "SvelteKit (built-in)",
+				Frameworks: []string{FrameworkSvelte, FrameworkSvelteKit}},
+			{ID: "solid-router", Value: RoutingSolidRouter, Label: "Solid Router", Frameworks: []string{FrameworkSolid}},
+			{ID: "app-router", Value: RoutingNextJSAppRouter, Label: "Next.js App Router", Frameworks: []string{FrameworkNextJS}},
+			{ID: "astro-pages", Value: RoutingAstroPages, Label: "Astro Pages", Frameworks: []string{FrameworkAstro}},
+			{ID: "none", Value: RoutingNone, Label: "None (single page)", Frameworks: []string{
+				FrameworkReact, FrameworkVue, FrameworkSvelte, FrameworkSolid, FrameworkVanilla}},
+		},
+	},
+	{
+		Key: "testing", Flag: "testing", Name: "testing",
+		Title: "Testing framework", Description: "Testing framework",
+		Field: func(c *Config) *string { return &c.Testing },
+		Options: []Option{
+			{ID: "vitest", Value: TestingVitest, Label: "Vitest (fast, Vite-native)",
+				Packages: append([]Package{
+					{Name: "vitest", Dev: true},
+					{Name: "jsdom", Dev: true},
+				}, testingLibraries...)},
+			{ID: "jest", Value: TestingJest, Label: "Jest", Frameworks: viteAndNext,
+				Packages: append([]Package{
+					{Name: "jest", Dev: true},
+				}, testingLibraries...)},
+			{ID: "playwright", Value: TestingPlaywright, Label: "Playwright (E2E)", Frameworks: []string{FrameworkSvelteKit}},
+			{ID: "none", Value: TestingNone, Label: "None (set up later)"},
+		},
+	},
+	{
+		Key: "stateManagement", Flag: "state", Name: "state management",
+		Title: "State management", Description: "State management library",
+		Frameworks: notAstro,
+		Field:      func(c *Config) *string { return &c.StateManagement },
+		Options: []Option{
+			{ID: "zustand", Value: StateZustand, Label: "Zustand (lightweight)", Frameworks: reactAndNext, Packages: []Package{
+				{Name: "zustand"},
+			}},
+			{ID: "redux", Value: StateReduxToolkit, Label: "Redux Toolkit", Aliases: []string{"redux-toolkit"}, Frameworks: reactAndNext, Packages: []Package{
+				{Name: "@reduxjs/toolkit"},
+				{Name: "react-redux"},
+			}},
+			{ID: "context", Value: StateContextAPI, Label: "Context API only", Aliases: []string{"context-api"}, Frameworks: reactAndNext},
+			{ID: "pinia", Value: StatePinia, Label: "Pinia (recommended)", Frameworks: onlyVue, Packages: []Package{
+				{Name: "pinia"},
+			}},
+			{ID: "vuex", Value: StateVuex, Label: "Vuex", Frameworks: onlyVue},
+			{ID: "svelte-stores", Value: StateSvelteStores, Label: "Svelte Stores (built-in)", Aliases: []string{"svelte"},
+				Frameworks: []string{FrameworkSvelte, FrameworkSvelteKit}},
+			{ID: "solid-stores", Value: StateSolidStores, Label: "Solid Stores (built-in)", Frameworks: []string{FrameworkSolid}},
+			{ID: "ngrx", Value: StateNgRx, Label: "NgRx", Frameworks: onlyAngular},
+			{ID: "none", Value: StateNone, Label: "None"},
+		},
+	},
+	{
+		Key: "formManagement", Flag: "forms", Name: "form management",
+		Title: "Form Management", Description: "Form management library",
+		Frameworks: ViteFrameworks,
+		Field:      func(c *Config) *string { return &c.FormManagement },
+		Options: []Option{
+			{ID: "react-hook-form", Value: FormReactHookForm, Label: "React Hook Form (recommended)", Aliases: []string{"rhf"}, Frameworks: onlyReact, Packages: []Package{
+				{Name: "react-hook-form"},
+				{Name: "@hookform/resolvers"},
+				{Name: "zod"},
+			}},
+			{ID: "formik", Value: FormFormik, Label: "Formik", Frameworks: onlyReact, Packages: []Package{
+				{Name: "formik"},
+				{Name: "yup"},
+			}},
+			{ID: "tanstack-form", Value: FormTanStackForm, Label: "TanStack Form", Frameworks: onlyReact, Packages: []Package{
+				{Name: "@tanstack/react-form"},
+			}},
+			{ID: "vee-validate", Value: FormVeeValidate, Label: "VeeValidate", Frameworks: onlyVue, Packages: []Package{
+				{Name: "vee-validate"},
+				{Name: "yup"},
+			}},
+			{ID: "zod", Value: FormZod, Label: "Zod (validation)", Hidden: true, Packages: []Package{
+				{Name: "zod"},
+			}},
+			{ID: "yup", Value: FormYup, Label: "Yup (validation)", Hidden: true, Packages: []Package{
+				{Name: "yup"},
+			}},
+			{ID: "none", Value: FormNone, Label: "None (native)"},
+		},
+	},
+	{
+		Key: "dataFetching", Flag: "data", Name: "data fetching",
+		Title: "Data fetching approach", Description: "Data fetching approach",
+		Frameworks: notAstro,
+		Field:      func(c *Config) *string { return &c.DataFetching },
+		Options: []Option{
+			{ID: "tanstack-query", Value: DataTanStackQuery, Label: "TanStack Query", Aliases: []string{"tanstack", "react-query"},
+				Frameworks: []string{FrameworkReact, FrameworkNextJS, FrameworkSvelteKit}, Packages: []Package{
+					{Name: "@tanstack/react-query", Frameworks: reactAndNext},
+					{Name: "@tanstack/react-query-devtools", Dev: true, Frameworks: reactAndNext},
+					{Name: "@tanstack/svelte-query", Frameworks: []string{FrameworkSvelteKit}},
+				}},
+			{ID: "fetch", Value: DataFetchAPI, Label: "Fetch API", Aliases: []string{"fetch-api"}},
+			{ID: "axios", Value: DataAxios, Label: "Axios", Frameworks: viteAndNext, Packages: []Package{
+				{Name: "axios"},
+			}},
+			{ID: "swr", Value: DataSWR, Label: "SWR", Frameworks: reactAndNext, Packages: []Package{
+				{Name: "swr"},
+			}},
+			{ID: "none", Value: DataNone, Label: "None (no API calls)"},
+		},
+	},
+	{
+		Key: "animation", Flag: "animation", Name: "animation",
+		Title: "Animation library", Description: "Animation library",
+		Frameworks: ViteFrameworks,
+		Field:      func(c *Config) *string { return &c.Animation },
+		Options: []Option{
+			{ID: "framer-motion", Value: AnimationFramerMotion, Label: "Framer Motion (React, Vue)", Aliases: []string{"motion"},
+				Frameworks: reactAndVue, Packages: []Package{
+					{Name: "motion", Frameworks: onlyReact},
+					{Name: "motion-v", Frameworks: onlyVue},
+				}},
+			{ID: "gsap", Value: AnimationGSAP, Label: "GSAP (any framework)", Packages: []Package{
+				{Name: "gsap"},
+			}},
+			{ID: "auto-animate", Value: AnimationAutoAnimate, Label: "Auto Animate", Packages: []Package{
+				{Name: "@formkit/auto-animate"},
+			}},
+			{ID: "react-spring", Value: AnimationReactSpring, Label: "React Spring", Frameworks: onlyReact, Packages: []Package{
+				{Name: "@react-spring/web"},
+			}},
+			{ID: "none", Value: AnimationNone, Label: "None"},
+		},
+	},
+	{
+		Key: "icons", Flag: "icons", Name: "icons",
+		Title: "Icon library", Description: "Icon library",
+		Frameworks: ViteFrameworks,
+		Field:      func(c *Config) *string { return &c.Icons },
+		Options: []Option{
+			{ID: "heroicons", Value: IconsHeroicons, Label: "Heroicons", Frameworks: reactAndVue, Packages: []Package{
+				{Name: "@heroicons/react", Frameworks: onlyReact},
+				{Name: "@heroicons/vue", Frameworks: onlyVue},
+			}},
+			{ID: "lucide", Value: IconsLucide, Label: "Lucide", Packages: []Package{
+				{Name: "lucide-react", Frameworks: onlyReact},
+				{Name: "lucide-vue-next", Frameworks: onlyVue},
+				{Name: "lucide-angular", Frameworks: onlyAngular},
+				{Name: "lucide-svelte", Frameworks: []string{FrameworkSvelte}},
+				{Name: "lucide-solid", Frameworks: []string{FrameworkSolid}},
+				{Name: "lucide", Frameworks: []string{FrameworkVanilla}},
+			}},
+			{ID: "react-icons", Value: IconsReactIcons, Label: "React Icons", Frameworks: onlyReact, Packages: []Package{
+				{Name: "react-icons"},
+			}},
+			{ID: "vue-icons", Value: IconsVueIcons, Label: "Vue Icons", Frameworks: onlyVue, Packages: []Package{
+				{Name: "@vicons/ionicons5"},
+			}},
+			{ID: "font-awesome", Value: IconsFontAwesome, Label: "Font Awesome", Aliases: []string{"fontawesome"}, Packages: []Package{
+				{Name: "@fortawesome/fontawesome-svg-core"},
+				{Name: "@fortawesome/free-solid-svg-icons"},
+				{Name: "@fortawesome/react-fontawesome", Frameworks: onlyReact},
+				{Name: "@fortawesome/vue-fontawesome", Frameworks: onlyVue},
+			}},
+			{ID: "none", Value: IconsNone, Label: "None"},
+		},
+	},
+	{
+		Key: "dataViz", Flag: "dataviz", Name: "data visualization",
+		Title: "Data visualization", Description: "Data visualization library",
+		Frameworks: ViteFrameworks,
+		Field:      func(c *Config) *string { return &c.DataViz },
+		Options: []Option{
+			{ID: "recharts", Value: DataVizRecharts, Label: "Recharts (React)", Frameworks: onlyReact, Packages: []Package{
+				{Name: "recharts"},
+			}},
+			{ID: "chartjs", Value: DataVizChartJS, Label: "Chart.js (any framework)", Aliases: []string{"chart.js"}, Packages: []Package{
+				{Name: "chart.js"},
+				{Name: "react-chartjs-2", Frameworks: onlyReact},
+				{Name: "vue-chartjs", Frameworks: onlyVue},
+			}},
+			{ID: "echarts", Value: DataVizECharts, Label: "Apache ECharts", Packages: []Package{
+				{Name: "echarts"},
+				{Name: "echarts-for-react", Frameworks: onlyReact},
+				{Name: "vue-echarts", Frameworks: onlyVue},
+			}},
+			{ID: "nivo", Value: DataVizNivo, Label: "Nivo (React)", Frameworks: onlyReact, Packages: []Package{
+				{Name: "@nivo/core"},
+				{Name: "@nivo/line"},
+				{Name: "@nivo/bar"},
+			}},
+			{ID: "none", Value: DataVizNone, Label: "None"},
+		},
+	},
+	{
+		Key: "utilities", Flag: "utils", Name: "utilities",
+		Title: "Utility library", Description: "Utility library",
+		Frameworks: ViteFrameworks,
+		Field:      func(c *Config) *string { return &c.Utilities },
+		Options: []Option{
+			{ID: "date-fns", Value: UtilsDateFns, Label: "date-fns (date manipulation)", Packages: []Package{
+				{Name: "date-fns"},
+			}},
+			{ID: "dayjs", Value: UtilsDayJS, Label: "Day.js (lightweight dates)", Aliases: []string{"day.js"}, Packages: []Package{
+				{Name: "dayjs"},
+			}},
+			{ID: "lodash", Value: UtilsLodash, Label: "Lodash-es (tree-shakeable)", Aliases: []string{"lodash-es"}, Packages: []Package{
+				{Name: "lodash-es"},
+				{Name: "@types/lodash-es", Dev: true},
+			}},
+			{ID: "none", Value: UtilsNone, Label: "None"},
+		},
+	},
+	{
+		Key: "i18n", Flag: "i18n", Name: "internationalization",
+		Title: "Internationalization (i18n)", Description: "Internationalization library",
+		Frameworks: ViteFrameworks,
+		Field:      func(c *Config) *string { return &c.I18n },
+		Options: []Option{
+			{ID: "react-i18next", Value: I18nReactI18next, Label: "react-i18next (React)", Frameworks: onlyReact, Packages: []Package{
+				{Name: "react-i18next"},
+				{Name: "i18next"},
+			}},
+			{ID: "vue-i18n", Value: I18nVueI18n, Label: "vue-i18n (Vue)", Frameworks: onlyVue, Packages: []Package{
+				{Name: "vue-i18n"},
+			}},
+			{ID: "none", Value: I18nNone, Label: "None"},
+		},
+	},
+	{
+		Key: "structure", Flag: "structure", Name: "project structure",
+		Title: "Folder structure", Description: "Project folder structure",
+		Field: func(c *Config) *string { return &c.Structure },
+		Options: []Option{
+			{ID: "feature-based", Value: StructureFeatureBased, Label: "Feature-based (recommended)", Aliases: []string{"feature"}},
+			{ID: "layer-based", Value: StructureLayerBased, Label: "Layer-based", Aliases: []string{"layer"}},
+		},
+	},
+}
+
+// LookupGroup finds an option group by config key, flag or name (case-insensitive)
+func LookupGroup(name string) (*OptionGroup, bool) {
+	for i := range Registry {
+		g := &Registry[i]
+		if strings.EqualFold(g.Key, name) || (g.Flag != "" && strings.EqualFold(g.Flag, name)) || strings.EqualFold(g.Name, name) {
+			return g, true
+		}
+	}
+	return nil, false
+}
+
+// keyedFields caches KeyedField's lookups: struct type -> JSON name -> field index
+var keyedFields sync.Map
+
+// KeyedField returns the string field of the struct v points to whose JSON
+// name is key, an option group's Key. Config files and the public API
+// describe a stack with structs keyed like the registry and find their
+// fields here rather than through hand-written switches.
+func KeyedField(v interface{}, key string) *string {
+	rv := reflect.ValueOf(v).Elem()
+	fields, ok := keyedFields.Load(rv.Type())
+	if !ok {
+		index := make(map[string]int)
+		for i := 0; i < rv.NumField(); i++ {
+			f := rv.Type().Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if f.Type.Kind() == reflect.String && name != "" {
+				index[name] = i
+			}
+		}
+		fields, _ = keyedFields.LoadOrStore(rv.Type(), index)
+	}
+	i, ok := fields.(map[string]int)[key]
+	if !ok {
+		panic(fmt.Sprintf("models: %s has no field for %s", rv.Type(), key))
+	}
+	return rv.Field(i).Addr().Interface().(*string)
+}
+
+// Parse converts user input to the option's Config value. It accepts the
+// option ID, any alias or the Config value itself, ignoring case.
+func (g *OptionGroup) Parse(input string) (string, bool) {
+	for _, o := range g.Options {
+		if strings.EqualFold(input, o.ID) || strings.EqualFold(input, o.Value) {
+			return o.Value, true
+		}
+		for _, alias := range o.Aliases {
+			if strings.EqualFold(input, alias) {
+				return o.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Find returns the option with the given Config value
+func (g *OptionGroup) Find(value string) (Option, bool) {
+	for _, o := range g.Options {
+		if o.Value == value {
+			return o, true
+		}
+	}
+	return Option{}, false
+}
+
+// IDs returns the CLI value of every option
+func (g *OptionGroup) IDs() []string {
+	ids := make([]string, len(g.Options))
+	for i, o := range g.Options {
+		ids[i] = o.ID
+	}
+	return ids
+}
+
+// Values returns the Config value of every option
+func (g *OptionGroup) Values() []string {
+	values := make([]string, len(g.Options))
+	for i, o := range g.Options {
+		values[i] = o.Value
+	}
+	return values
+}
+
+// AppliesTo reports whether the question is relevant for a framework.
+// Values of groups that don't apply are ignored by the generators.
+func (g *OptionGroup) AppliesTo(framework string) bool {
+	return g.Frameworks == nil || containsString(g.Frameworks, framework)
+}
+
+// Supports reports whether the option can be used with a framework
+func (o Option) Supports(framework string) bool {
+	return o.Frameworks == nil || containsString(o.Frameworks, framework)
+}
+
+// OptionsFor returns the options supported by a framework, in registry order.
+// Hidden options are included only when includeHidden is true.
+func (g *OptionGroup) OptionsFor(framework string, includeHidden bool) []Option {
+	if !g.AppliesTo(framework) {
+		return nil
+	}
+	var options []Option
+	for _, o := range g.Options {
+		if o.Supports(framework) && (includeHidden || !o.Hidden) {
+			options = append(options, o)
+		}
+	}
+	return options
+}
+
+// Supports reports whether value is a valid choice for framework
+func (g *OptionGroup) Supports(value, framework string) bool {
+	o, ok := g.Find(value)
+	return ok && o.Supports(framework)
+}
+
+// Resolve returns value if framework supports it, otherwise the first
+// option the framework supports (preferring options offered in the TUI).
+// Groups that don't apply resolve to their "none" option when they have one.
+func (g *OptionGroup) Resolve(value, framework string) string {
+	if !g.AppliesTo(framework) {
+		if none, ok := g.Parse("none"); ok {
+			return none
+		}
+		return value
+	}
+	if g.Supports(value, framework) {
+		return value
+	}
+	for _, includeHidden := range []bool{false, true} {
+		if options := g.OptionsFor(framework, includeHidden); len(options) > 0 {
+			return options[0].Value
+		}
+	}
+	return value
+}
+
+// SelectedPackages returns the packages required by every option selected in
+// config, filtered for its framework. Given registry keys, only those groups
+// are considered. Duplicates are kept; callers key by name.
+func SelectedPackages(config Config, keys ...string) []Package {
+	var packages []Package
+	for i := range Registry {
+		g := &Registry[i]
+		if !g.AppliesTo(config.Framework) || (len(keys) > 0 && !containsString(keys, g.Key)) {
+			continue
+		}
+		o, ok := g.Find(*g.Field(&config))
+		if !ok {
+			continue
+		}
+		for _, p := range o.Packages {
+			if p.Frameworks == nil || containsString(p.Frameworks, config.Framework) {
+				packages = append(packages, p)
+			}
+		}
+	}
+	return packages
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package models_test
+
+import (
+	"frontforge/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestCheckCompatibilityReportsEveryViolation(t *testing.T) {
+	config := models.QuickPreset()
+	config.Framework = models.FrameworkVue
+	config.UILibrary = models.UILibraryVuetify
+	config.Routing = models.RoutingVueRouter
+	config.StateManagement = models.StatePinia
+	config.FormManagement = models.FormVeeValidate
+	config.DataFetching = models.DataAxios
+	config.Animation = models.AnimationGSAP
+	config.I18n = models.I18nReactI18next
+	config.DataViz = models.DataVizRecharts
+	config.Icons = models.IconsReactIcons
+
+	violations := models.CheckCompatibility(config)
+	want := map[string]string{
+		"icons":   models.IconsHeroicons,
+		"dataViz": models.DataVizChartJS,
+		"i18n":    models.I18nVueI18n,
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("got %d violations, want %d: %+v", len(violations), len(want), violations)
+	}
+	for _, v := range violations {
+		if want[v.Key] != v.Suggestion {
+			t.Errorf("%s: suggestion = %q, want %q", v.Key, v.Suggestion, want[v.Key])
+		}
+		if !strings.Contains(v.Message, "not compatible with Vue") {
+			t.Errorf("%s: unexpected message %q", v.Key, v.Message)
+		}
+	}
+}
+
+func TestCheckCompatibilityRules(t *testing.T) {
+	config := models.QuickPreset()
+	config.Styling = models.StylingSass
+
+	violations := models.CheckCompatibility(config)
+	if len(violations) != 1 || violations[0].Key != "uiLibrary" {
+		t.Fatalf("expected a Shadcn/ui violation, got %+v", violations)
+	}
+	if violations[0].Suggestion != models.UILibraryNone {
+		t.Errorf("suggestion = %q, want the rule's replacement", violations[0].Suggestion)
+	}
+
+	// Unset options are never evaluated, so partial configs can be checked
+	if v := models.CheckCompatibility(models.Config{UILibrary: models.UILibraryShadcn}); len(v) != 0 {
+		t.Errorf("partial config should pass, got %+v", v)
+	}
+}
+
+func TestFixCompatibility(t *testing.T) {
+	// Meta-frameworks are checked too; questions they skip are ignored
+	config := models.QuickPreset()
+	config.Framework = models.FrameworkSvelteKit
+	config.StateManagement = models.StateZustand
+	config.Routing = models.RoutingSvelteKit
+
+	// Explicit choices are kept and reported
+	models.FixCompatibility(&config, func(key string) bool { return key != "stateManagement" })
+	violations := models.CheckCompatibility(config)
+	if len(violations) != 1 || violations[0].Key != "stateManagement" {
+		t.Fatalf("expected only the kept violation, got %+v", violations)
+	}
+
+	fixed := models.FixCompatibility(&config, nil)
+	if len(fixed) != 1 || config.StateManagement != models.StateSvelteStores {
+		t.Errorf("state management = %q after fixing %+v", config.StateManagement, fixed)
+	}
+	if v := models.CheckCompatibility(config); len(v) != 0 {
+		t.Errorf("fixed config still has violations: %+v", v)
+	}
+}
+
+func TestAvailableOptionsFollowRules(t *testing.T) {
+	g, _ := models.LookupGroup("ui")
+	config := models.Config{Framework: models.FrameworkReact, Styling: models.StylingCSSModules}
+	for _, o := range g.AvailableOptions(config, false) {
+		if o.Value == models.UILibraryShadcn {
+			t.Error("Shadcn/ui should not be offered without Tailwind CSS")
+		}
+	}
+
+	config.Styling = models.StylingTailwind
+	if options := g.AvailableOptions(config, false); options[0].Value != models.UILibraryShadcn {
+		t.Errorf("Shadcn/ui should be offered with Tailwind CSS, got %v", options[0].Value)
+	}
+}
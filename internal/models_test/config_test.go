@@ -185,3 +185,22 @@ func TestConfigStruct(t *testing.T) {
 		t.Errorf("expected Language=typescript, got %s", config.Language)
 	}
 }
+
+func TestValidProjectName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"my-app", true},
+		{"My_App2", true},
+		{"", false},
+		{"my app", false},
+		{"../app", false},
+		{"café", false},
+	}
+	for _, tt := range tests {
+		if got := models.ValidProjectName(tt.name); got != tt.want {
+			t.Errorf("ValidProjectName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
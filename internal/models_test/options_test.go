@@ -0,0 +1,220 @@
+package models_test
+
+import (
+	"frontforge/internal/models"
+	"testing"
+)
+
+func TestRegistryGroups(t *testing.T) {
+	config := models.Config{}
+	seen := make(map[*string]string)
+
+	for _, g := range models.Registry {
+		if g.Key == "" || g.Name == "" || g.Title == "" || g.Description == "" {
+			t.Errorf("group %q is missing metadata", g.Key)
+		}
+
+		// Every group must bind a distinct Config field
+		field := g.Field(&config)
+		if other, ok := seen[field]; ok {
+			t.Errorf("groups %q and %q share a Config field", g.Key, other)
+		}
+		seen[field] = g.Key
+
+		ids := make(map[string]bool)
+		for _, o := range g.Options {
+			if ids[o.ID] {
+				t.Errorf("group %q has duplicate option ID %q", g.Key, o.ID)
+			}
+			ids[o.ID] = true
+		}
+
+		// Every framework the group applies to must have a valid choice
+		for _, fw := range models.AllFrameworks {
+			if g.AppliesTo(fw) && len(g.OptionsFor(fw, false)) == 0 {
+				t.Errorf("group %q offers no options for %s", g.Key, fw)
+			}
+		}
+	}
+}
+
+func TestKeyedField(t *testing.T) {
+	// Structs keyed like the registry, such as Config, resolve every group
+	var config models.Config
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		if models.KeyedField(&config, g.Key) != g.Field(&config) {
+			t.Errorf("KeyedField(%s) should be the field OptionGroup.Field returns", g.Key)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a key without a field")
+		}
+	}()
+	models.KeyedField(&struct {
+		Styling string `json:"styling"`
+	}{}, "routing")
+}
+
+func TestOptionGroupParse(t *testing.T) {
+	tests := []struct {
+		group string
+		input string
+		want  string
+		ok    bool
+	}{
+		{"framework", "react", models.FrameworkReact, true},
+		{"framework", "Next.js", models.FrameworkNextJS, true},
+		{"framework", "next", models.FrameworkNextJS, true},
+		{"lang", "TypeScript", models.LangTypeScript, true},
+		{"styling", "SCSS", models.StylingSass, true},
+		{"styling", "Sass/SCSS", models.StylingSass, true},
+		{"state", "redux-toolkit", models.StateReduxToolkit, true},
+		{"uiLibrary", "material-ui", models.UILibraryMUI, true},
+		{"formManagement", "zod", models.FormZod, true},
+		{"framework", "ember", "", false},
+		{"testing", "mocha", "", false},
+	}
+
+	for _, tt := range tests {
+		g, ok := models.LookupGroup(tt.group)
+		if !ok {
+			t.Fatalf("LookupGroup(%q) not found", tt.group)
+		}
+		got, ok := g.Parse(tt.input)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s.Parse(%q) = %q, %v; want %q, %v", tt.group, tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestOptionGroupResolve(t *testing.T) {
+	tests := []struct {
+		group     string
+		value     string
+		framework string
+		want      string
+	}{
+		{"routing", models.RoutingReactRouter, models.FrameworkReact, models.RoutingReactRouter},
+		{"routing", models.RoutingReactRouter, models.FrameworkVue, models.RoutingVueRouter},
+		{"routing", models.RoutingReactRouter, models.FrameworkAngular, models.RoutingAngularRouter},
+		{"routing", models.RoutingReactRouter, models.FrameworkNextJS, models.RoutingNextJSAppRouter},
+		{"uiLibrary", models.UILibraryShadcn, models.FrameworkSvelte, models.UILibraryNone},
+		{"formManagement", models.FormReactHookForm, models.FrameworkSolid, models.FormNone},
+		{"stateManagement", models.StateZustand, models.FrameworkAstro, models.StateNone},
+		{"animation", models.AnimationGSAP, models.FrameworkNextJS, models.AnimationNone},
+		{"testing", models.TestingJest, models.FrameworkSvelteKit, models.TestingVitest},
+	}
+
+	for _, tt := range tests {
+		g, _ := models.LookupGroup(tt.group)
+		if got := g.Resolve(tt.value, tt.framework); got != tt.want {
+			t.Errorf("%s.Resolve(%q, %s) = %q, want %q", tt.group, tt.value, tt.framework, got, tt.want)
+		}
+	}
+}
+
+func TestSelectedPackages(t *testing.T) {
+	config := models.QuickPreset()
+	config.Framework = models.FrameworkVue
+	config.Testing = models.TestingVitest
+	config.UILibrary = models.UILibraryShadcn // Unsupported, but packages follow the value
+
+	packages := make(map[string]models.Package)
+	for _, p := range models.SelectedPackages(config) {
+		packages[p.Name] = p
+	}
+
+	for _, name := range []string{"vue", "@vitejs/plugin-vue", "vitest", "@vue/test-utils", "typescript"} {
+		if _, ok := packages[name]; !ok {
+			t.Errorf("SelectedPackages() missing %s", name)
+		}
+	}
+	if _, ok := packages["@testing-library/react"]; ok {
+		t.Error("SelectedPackages() should not include React testing library for Vue")
+	}
+	if !packages["vitest"].Dev {
+		t.Error("vitest should be a dev dependency")
+	}
+}
+
+func TestPackageVariants(t *testing.T) {
+	// Every supported framework installs at least one package for options
+	// that have packages, so an option without a variant can't slip through
+	for _, g := range models.Registry {
+		for _, o := range g.Options {
+			if len(o.Packages) == 0 {
+				continue
+			}
+			for _, fw := range models.AllFrameworks {
+				if !g.AppliesTo(fw) || !o.Supports(fw) {
+					continue
+				}
+				found := false
+				for _, p := range o.Packages {
+					if p.Frameworks == nil || containsFramework(p.Frameworks, fw) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("%s %q supports %s but installs nothing for it", g.Key, o.ID, fw)
+				}
+			}
+		}
+	}
+
+	tests := []struct {
+		framework string
+		want      []string
+		notWant   []string
+	}{
+		{models.FrameworkReact, []string{"lucide-react", "@heroicons/react", "motion", "echarts-for-react"}, []string{"lucide-vue-next", "vue-echarts"}},
+		{models.FrameworkVue, []string{"lucide-vue-next", "@heroicons/vue", "motion-v", "vue-echarts"}, []string{"lucide-react", "@heroicons/react", "motion", "echarts-for-react"}},
+	}
+	for _, tt := range tests {
+		config := models.QuickPreset()
+		config.Framework = tt.framework
+		config.Icons = models.IconsLucide
+		config.DataViz = models.DataVizECharts
+
+		names := make(map[string]bool)
+		for _, p := range models.SelectedPackages(config) {
+			names[p.Name] = true
+		}
+		config.Icons = models.IconsHeroicons
+		for _, p := range models.SelectedPackages(config) {
+			names[p.Name] = true
+		}
+		for _, name := range tt.want {
+			if !names[name] {
+				t.Errorf("%s: missing %s", tt.framework, name)
+			}
+		}
+		for _, name := range tt.notWant {
+			if names[name] {
+				t.Errorf("%s: should not install %s", tt.framework, name)
+			}
+		}
+	}
+
+	// Libraries without a variant are rejected
+	config := models.QuickPreset()
+	config.Framework = models.FrameworkSvelte
+	config.Icons = models.IconsHeroicons
+	config.Animation = models.AnimationFramerMotion
+	if v := models.CheckCompatibility(config); len(v) < 2 {
+		t.Errorf("expected Heroicons and Framer Motion to be rejected for Svelte, got %+v", v)
+	}
+}
+
+// containsFramework reports whether list contains framework
+func containsFramework(list []string, framework string) bool {
+	for _, item := range list {
+		if item == framework {
+			return true
+		}
+	}
+	return false
+}
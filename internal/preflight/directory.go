@@ -90,7 +90,7 @@ func CheckDirectoryConflicts(projectPath string) CheckResult {
 	// Directory exists and has files
 	result.Passed = false
 	result.Message = fmt.Sprintf("Directory already exists with %d file(s)", len(entries))
-	result.Suggestion = "Choose a different directory name or remove existing files"
+	result.Suggestion = "Existing files are handled by -on-conflict (skip, overwrite, backup, merge or prompt)"
 	return result
 }
 
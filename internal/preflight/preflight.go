@@ -22,11 +22,11 @@ import (
 
 // CheckResult represents the result of a single pre-flight check
 type CheckResult struct {
-	Name       string // Display name of the check (e.g., "Node.js Version")
-	Passed     bool   // Whether the check passed
-	Message    string // Detailed status message
-	Suggestion string // Suggested action if check failed
-	Fatal      bool   // If true, generation cannot proceed
+	Name       string `json:"name"`                 // Display name of the check (e.g., "Node.js Version")
+	Passed     bool   `json:"passed"`               // Whether the check passed
+	Message    string `json:"message"`              // Detailed status message
+	Suggestion string `json:"suggestion,omitempty"` // Suggested action if check failed
+	Fatal      bool   `json:"fatal"`                // If true, generation cannot proceed
 }
 
 // PreflightResults holds the results of all pre-flight checks
@@ -39,53 +39,31 @@ type PreflightResults struct {
 // RunAllChecks executes all pre-flight validation checks
 // and returns a consolidated result
 func RunAllChecks(config models.Config) PreflightResults {
-	var checks []CheckResult
-	allPassed := true
-	fatalError := false
-
-	// Check 1: Node.js installation and version
-	nodeCheck := CheckNodeJS()
-	checks = append(checks, nodeCheck)
-	if !nodeCheck.Passed {
-		allPassed = false
-		if nodeCheck.Fatal {
-			fatalError = true
-		}
-	}
-
-	// Check 2: Package manager availability
-	pmCheck := CheckPackageManager(config.PackageManager)
-	checks = append(checks, pmCheck)
-	if !pmCheck.Passed {
-		allPassed = false
-		if pmCheck.Fatal {
-			fatalError = true
-		}
-	}
+	// Node.js and package manager availability, directory conflicts, disk space
+	return collect(
+		CheckNodeJS(),
+		CheckPackageManager(config.PackageManager),
+		CheckDirectoryConflicts(config.ProjectPath),
+		CheckDiskSpace(config.ProjectPath),
+	)
+}
 
-	// Check 3: Directory conflicts
-	dirCheck := CheckDirectoryConflicts(config.ProjectPath)
-	checks = append(checks, dirCheck)
-	if !dirCheck.Passed {
-		allPassed = false
-		if dirCheck.Fatal {
-			fatalError = true
-		}
-	}
+// RunToolChecks executes only the Node.js and package manager checks, for
+// generation that doesn't write to the project directory (such as -archive)
+func RunToolChecks(config models.Config) PreflightResults {
+	return collect(CheckNodeJS(), CheckPackageManager(config.PackageManager))
+}
 
-	// Check 4: Disk space
-	diskCheck := CheckDiskSpace(config.ProjectPath)
-	checks = append(checks, diskCheck)
-	if !diskCheck.Passed {
-		allPassed = false
-		if diskCheck.Fatal {
-			fatalError = true
+// collect aggregates check results
+func collect(checks ...CheckResult) PreflightResults {
+	results := PreflightResults{Checks: checks, AllPassed: true}
+	for _, check := range checks {
+		if !check.Passed {
+			results.AllPassed = false
+			if check.Fatal {
+				results.FatalError = true
+			}
 		}
 	}
-
-	return PreflightResults{
-		Checks:     checks,
-		AllPassed:  allPassed,
-		FatalError: fatalError,
-	}
+	return results
 }
@@ -0,0 +1,169 @@
+// Package presets manages user-saved configuration presets.
+//
+// Presets live in the user config directory (~/.config/frontforge/presets on
+// Linux) as one config file per preset, using the same versioned format as
+// -config files. They can be saved from the TUI, loaded with -preset or the
+// "Load preset" step, and managed with "frontforge presets".
+package presets
+
+import (
+	stderrors "errors"
+	"fmt"
+	"frontforge/internal/configfile"
+	"frontforge/internal/models"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// fileExt is the extension used for preset files
+const fileExt = ".json"
+
+// ErrNotFound is returned when a named preset does not exist
+var ErrNotFound = stderrors.New("preset not found")
+
+// Store reads and writes presets in a single directory
+type Store struct {
+	Dir string
+}
+
+// NewStore creates a store rooted at dir
+func NewStore(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+// DefaultDir returns the platform preset directory
+func DefaultDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "frontforge", "presets"), nil
+}
+
+// Default returns a store rooted at DefaultDir
+func Default() (*Store, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(dir), nil
+}
+
+// ValidateName checks that a preset name is safe to use as a file name.
+// Preset names follow the same rule as project names.
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("preset name is required")
+	}
+	if !models.ValidProjectName(name) {
+		return fmt.Errorf("preset name must contain only letters, numbers, hyphens, and underscores")
+	}
+	return nil
+}
+
+// path returns the file path for a preset name
+func (s *Store) path(name string) string {
+	return filepath.Join(s.Dir, name+fileExt)
+}
+
+// Save writes config as a named preset, replacing any existing preset.
+// Project name and path are not stored; they differ per project.
+func (s *Store) Save(name string, config models.Config) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	file := configfile.FromConfig(config)
+	file.Name = ""
+	file.Path = ""
+	// Refuse stacks that Load would reject
+	if err := file.Validate(); err != nil {
+		return err
+	}
+
+	data, err := file.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode preset: %w", err)
+	}
+
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create preset directory: %w", err)
+	}
+	if err := os.WriteFile(s.path(name), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write preset: %w", err)
+	}
+	return nil
+}
+
+// Load reads and validates a named preset
+func (s *Store) Load(name string) (*configfile.File, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(s.path(name)); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	file, err := configfile.Load(s.path(name))
+	if err != nil {
+		return nil, fmt.Errorf("preset %s: %w", name, err)
+	}
+	return file, nil
+}
+
+// List returns the names of all saved presets, sorted alphabetically.
+// A missing preset directory yields an empty list.
+func (s *Store) List() ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read preset directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileExt) {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), fileExt))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Delete removes a named preset
+func (s *Store) Delete(name string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if err := os.Remove(s.path(name)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		return fmt.Errorf("failed to delete preset: %w", err)
+	}
+	return nil
+}
+
+// Rename changes a preset's name. It refuses to overwrite an existing preset.
+func (s *Store) Rename(oldName, newName string) error {
+	if err := ValidateName(oldName); err != nil {
+		return err
+	}
+	if err := ValidateName(newName); err != nil {
+		return err
+	}
+	if _, err := os.Stat(s.path(oldName)); os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s", ErrNotFound, oldName)
+	}
+	if _, err := os.Stat(s.path(newName)); err == nil {
+		return fmt.Errorf("preset %s already exists", newName)
+	}
+	if err := os.Rename(s.path(oldName), s.path(newName)); err != nil {
+		return fmt.Errorf("failed to rename preset: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package presets_test
+
+import (
+	"errors"
+	"frontforge/internal/models"
+	"frontforge/internal/presets"
+	"frontforge/internal/testutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	store := presets.NewStore(filepath.Join(testutil.TempDir(t), "presets"))
+
+	config := models.QuickPreset()
+	config.ProjectName = "ignored"
+	config.ProjectPath = "/tmp/ignored"
+	config.Framework = models.FrameworkVue
+	config.StateManagement = models.StatePinia
+
+	// React-only quick preset options can't be saved with Vue
+	if err := store.Save("vue-stack", config); err == nil {
+		t.Fatal("expected incompatible preset to be rejected")
+	}
+	models.FixCompatibility(&config, nil)
+
+	testutil.AssertNoError(t, store.Save("vue-stack", config))
+	testutil.AssertFileExists(t, filepath.Join(store.Dir, "vue-stack.json"))
+
+	file, err := store.Load("vue-stack")
+	testutil.AssertNoError(t, err)
+
+	// Project-specific fields are not part of a preset
+	testutil.AssertEqual(t, file.Name, "")
+	testutil.AssertEqual(t, file.Path, "")
+	testutil.AssertEqual(t, file.Framework, models.FrameworkVue)
+	testutil.AssertEqual(t, file.StateManagement, models.StatePinia)
+}
+
+func TestLoadMissing(t *testing.T) {
+	store := presets.NewStore(testutil.TempDir(t))
+
+	_, err := store.Load("nope")
+	if !errors.Is(err, presets.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestListDeleteRename(t *testing.T) {
+	dir := testutil.TempDir(t)
+	store := presets.NewStore(dir)
+
+	// Missing directory lists as empty
+	names, err := presets.NewStore(filepath.Join(dir, "missing")).List()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, len(names), 0)
+
+	testutil.AssertNoError(t, store.Save("zeta", models.QuickPreset()))
+	testutil.AssertNoError(t, store.Save("alpha", models.QuickPreset()))
+	testutil.CreateTempFile(t, dir, "notes.txt", "not a preset")
+
+	names, err = store.List()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, strings.Join(names, ","), "alpha,zeta")
+
+	testutil.AssertNoError(t, store.Rename("zeta", "beta"))
+	err = store.Rename("alpha", "beta")
+	testutil.AssertError(t, err)
+
+	testutil.AssertNoError(t, store.Delete("alpha"))
+	if err := store.Delete("alpha"); !errors.Is(err, presets.ErrNotFound) {
+		t.Errorf("expected ErrNotFound on second delete, got %v", err)
+	}
+
+	names, err = store.List()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, strings.Join(names, ","), "beta")
+}
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"team-stack", false},
+		{"stack_2", false},
+		{"", true},
+		{"../escape", true},
+		{"has space", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := presets.ValidateName(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+// Package server implements the HTTP API behind "frontforge serve": a small
+// start.spring.io-style service for teams who don't use the CLI.
+//
+// Endpoints:
+//
+//	GET  /               HTML form (embedded)
+//	GET  /api/options    Option groups, their values and each framework's defaults
+//	POST /api/validate   Resolve a config and report incompatible options
+//	POST /api/dry-run    The files a config generates (?content=true adds contents)
+//	POST /api/generate   The project as a zip (?format=tar.gz for a tarball)
+//
+// Request bodies are config file documents (see configfile), so a saved
+// stack.json can be posted as is. Vite-based projects are generated in
+// memory; nothing touches the disk and nothing is executed. Meta-frameworks
+// are scaffolded by their upstream CLI in a temporary directory. A request
+// that times out or is abandoned stops its generation, killing the CLI.
+package server
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"frontforge/internal/configfile"
+	"frontforge/internal/generators"
+	"frontforge/internal/logger"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+//go:embed index.html
+var indexHTML []byte
+
+// Defaults for Options
+const (
+	DefaultMaxBodyBytes = 64 << 10
+	DefaultTimeout      = 2 * time.Minute
+)
+
+// Options configures the handler returned by New
+type Options struct {
+	MaxBodyBytes int64          // Largest accepted request body (default 64 KiB)
+	Timeout      time.Duration  // Time limit for one request (default 2 minutes)
+	Log          *logger.Logger // Request log; nil logs nothing
+}
+
+// errorResponse is the body of every failed API request
+type errorResponse struct {
+	Error      string             `json:"error"`
+	Violations []models.Violation `json:"violations,omitempty"`
+}
+
+// optionsResponse is the body of GET /api/options
+type optionsResponse struct {
+	Groups   []optionGroup               `json:"groups"`
+	Defaults map[string]*configfile.File `json:"defaults"` // Keyed by framework
+}
+
+// optionGroup describes a models.OptionGroup
+type optionGroup struct {
+	Key         string   `json:"key"`
+	Flag        string   `json:"flag,omitempty"`
+	Name        string   `json:"name"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Frameworks  []string `json:"frameworks,omitempty"` // nil = all
+	Options     []option `json:"options"`
+}
+
+// option describes a models.Option
+type option struct {
+	ID         string   `json:"id"`
+	Value      string   `json:"value"`
+	Label      string   `json:"label"`
+	Aliases    []string `json:"aliases,omitempty"`
+	Frameworks []string `json:"frameworks,omitempty"` // nil = all the group applies to
+	Hidden     bool     `json:"hidden,omitempty"`
+}
+
+// validateResponse is the body of POST /api/validate
+type validateResponse struct {
+	Valid      bool               `json:"valid"`
+	Config     *configfile.File   `json:"config,omitempty"` // With defaults filled in; nil when the options conflict
+	Violations []models.Violation `json:"violations,omitempty"`
+}
+
+// New returns the handler serving the form and the API
+func New(opts Options) http.Handler {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	s := &server{opts: opts}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleIndex)
+	mux.HandleFunc("/api/options", s.handleOptions)
+	mux.HandleFunc("/api/validate", s.handleValidate)
+	mux.HandleFunc("/api/dry-run", s.handleDryRun)
+	mux.HandleFunc("/api/generate", s.handleGenerate)
+
+	timeout, _ := json.Marshal(errorResponse{Error: fmt.Sprintf("request took longer than %s", opts.Timeout)})
+	return s.logRequests(http.TimeoutHandler(mux, opts.Timeout, string(timeout)))
+}
+
+type server struct {
+	opts Options
+}
+
+func (s *server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		s.writeError(w, http.StatusNotFound, fmt.Errorf("no such page: %s", r.URL.Path))
+		return
+	}
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(indexHTML)
+}
+
+func (s *server) handleOptions(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	resp := optionsResponse{Defaults: make(map[string]*configfile.File)}
+	for _, g := range models.Registry {
+		group := optionGroup{
+			Key: g.Key, Flag: g.Flag, Name: g.Name, Title: g.Title,
+			Description: g.Description, Frameworks: g.Frameworks,
+		}
+		for _, o := range g.Options {
+			group.Options = append(group.Options, option{
+				ID: o.ID, Value: o.Value, Label: o.Label,
+				Aliases: o.Aliases, Frameworks: o.Frameworks, Hidden: o.Hidden,
+			})
+		}
+		resp.Groups = append(resp.Groups, group)
+	}
+	for _, fw := range models.AllFrameworks {
+		resp.Defaults[fw] = configfile.FromConfig((&configfile.File{Framework: fw}).Resolve())
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+	file, status, err := s.readConfig(w, r)
+	var compat *models.CompatibilityError
+	switch {
+	case errors.As(err, &compat):
+		writeJSON(w, http.StatusOK, validateResponse{Violations: compat.Violations})
+		return
+	case err != nil:
+		s.writeError(w, status, err)
+		return
+	}
+	config := file.Resolve()
+	violations := models.CheckCompatibility(config)
+	writeJSON(w, http.StatusOK, validateResponse{
+		Valid:      len(violations) == 0,
+		Config:     configfile.FromConfig(config),
+		Violations: violations,
+	})
+}
+
+func (s *server) handleDryRun(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+	config, ok := s.readProject(w, r)
+	if !ok {
+		return
+	}
+	if models.IsMetaFramework(config.Framework) {
+		s.writeError(w, http.StatusUnprocessableEntity,
+			fmt.Errorf("%s projects are created by the upstream CLI and can't be previewed; download the archive instead", config.Framework))
+		return
+	}
+	manifest, err := generators.Plan(r.Context(), config, io.Discard)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := manifest.FprintJSON(&buf, r.URL.Query().Get("content") == "true"); err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf.Bytes())
+}
+
+func (s *server) handleGenerate(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+	format := vfs.FormatZip
+	if f := r.URL.Query().Get("format"); f != "" {
+		var ok bool
+		if format, ok = vfs.ArchiveFormatFor("project." + f); !ok {
+			s.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid format '%s'. Valid options: zip, tar.gz", f))
+			return
+		}
+	}
+	config, ok := s.readProject(w, r)
+	if !ok {
+		return
+	}
+
+	// Buffered so a failure can still be reported with a status code
+	var buf bytes.Buffer
+	if _, err := generators.GenerateArchive(r.Context(), &buf, format, config, io.Discard); err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	contentType := "application/zip"
+	if format == vfs.FormatTarGz {
+		contentType = "application/gzip"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", config.ProjectName+"."+string(format)))
+	w.Write(buf.Bytes())
+}
+
+// readConfig decodes the config document in the request body. The status
+// is the one to answer with when err is not nil.
+func (s *server) readConfig(w http.ResponseWriter, r *http.Request) (*configfile.File, int, error) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.opts.MaxBodyBytes))
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("request body is larger than %d bytes", s.opts.MaxBodyBytes)
+	case err != nil:
+		return nil, http.StatusBadRequest, err
+	}
+	file, err := configfile.Parse(data)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return file, http.StatusOK, nil
+}
+
+// readProject resolves the config of a project to generate, answering the
+// request itself when the config is unusable
+func (s *server) readProject(w http.ResponseWriter, r *http.Request) (models.Config, bool) {
+	file, status, err := s.readConfig(w, r)
+	var compat *models.CompatibilityError
+	if errors.As(err, &compat) {
+		s.writeViolations(w, compat.Violations)
+		return models.Config{}, false
+	}
+	if err != nil {
+		s.writeError(w, status, err)
+		return models.Config{}, false
+	}
+	if file.Name == "" {
+		s.writeError(w, http.StatusBadRequest, fmt.Errorf("name is required"))
+		return models.Config{}, false
+	}
+
+	config := file.Resolve()
+	if violations := models.CheckCompatibility(config); len(violations) > 0 {
+		s.writeViolations(w, violations)
+		return models.Config{}, false
+	}
+	// The path only anchors generated paths: Vite projects stay in memory
+	// and meta-frameworks are scaffolded in a temporary directory
+	config.ProjectPath = filepath.Join(os.TempDir(), "frontforge-serve", config.ProjectName)
+	config.AutoInstall = false
+	return config, true
+}
+
+func (s *server) writeViolations(w http.ResponseWriter, violations []models.Violation) {
+	err := &models.CompatibilityError{Violations: violations}
+	writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error(), Violations: violations})
+}
+
+func (s *server) writeError(w http.ResponseWriter, status int, err error) {
+	if status >= http.StatusInternalServerError && s.opts.Log != nil {
+		s.opts.Log.Error("Request failed", logger.F("error", err))
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// logRequests logs the method, path, status and duration of every request
+func (s *server) logRequests(next http.Handler) http.Handler {
+	if s.opts.Log == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		s.opts.Log.Info(r.Method+" "+r.URL.Path,
+			logger.F("status", rec.status),
+			logger.F("duration", time.Since(start).Round(time.Millisecond)))
+	})
+}
+
+// statusRecorder remembers the status code written through it
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// allowMethod answers 405 unless the request uses method
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: fmt.Sprintf("use %s", method)})
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
@@ -0,0 +1,201 @@
+package server_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"frontforge/internal/server"
+	"frontforge/internal/testutil"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// request sends a request to a fresh handler and returns the response
+func request(t *testing.T, opts server.Options, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	server.New(opts).ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rec
+}
+
+func TestIndex(t *testing.T) {
+	rec := request(t, server.Options{}, http.MethodGet, "/", "")
+	testutil.AssertEqual(t, rec.Code, http.StatusOK)
+	if !strings.Contains(rec.Body.String(), "/api/generate") {
+		t.Error("the form should post to the API")
+	}
+	testutil.AssertEqual(t, request(t, server.Options{}, http.MethodGet, "/missing", "").Code, http.StatusNotFound)
+}
+
+func TestOptions(t *testing.T) {
+	rec := request(t, server.Options{}, http.MethodGet, "/api/options", "")
+	testutil.AssertEqual(t, rec.Code, http.StatusOK)
+
+	var resp struct {
+		Groups []struct {
+			Key     string `json:"key"`
+			Options []struct {
+				ID string `json:"id"`
+			} `json:"options"`
+		} `json:"groups"`
+		Defaults map[string]map[string]interface{} `json:"defaults"`
+	}
+	testutil.AssertNoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	keys := make(map[string]int)
+	for _, g := range resp.Groups {
+		keys[g.Key] = len(g.Options)
+	}
+	if keys["framework"] == 0 || keys["styling"] == 0 {
+		t.Errorf("groups should list their options: %v", keys)
+	}
+	testutil.AssertEqual(t, resp.Defaults["Vue"]["stateManagement"], "Pinia")
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		code      int
+		valid     bool
+		violation string
+	}{
+		{"defaults", `{"framework": "svelte"}`, http.StatusOK, true, ""},
+		{"conflict", `{"framework": "vue", "uiLibrary": "shadcn"}`, http.StatusOK, false, "uiLibrary"},
+		{"unknown value", `{"styling": "crayons"}`, http.StatusBadRequest, false, ""},
+		{"not JSON", `framework: vue`, http.StatusBadRequest, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(t, server.Options{}, http.MethodPost, "/api/validate", tt.body)
+			testutil.AssertEqual(t, rec.Code, tt.code)
+			if tt.code != http.StatusOK {
+				return
+			}
+			var resp struct {
+				Valid      bool `json:"valid"`
+				Violations []struct {
+					Key string `json:"key"`
+				} `json:"violations"`
+			}
+			testutil.AssertNoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			testutil.AssertEqual(t, resp.Valid, tt.valid)
+			if tt.violation != "" && (len(resp.Violations) != 1 || resp.Violations[0].Key != tt.violation) {
+				t.Errorf("violations = %+v, want %s", resp.Violations, tt.violation)
+			}
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	rec := request(t, server.Options{}, http.MethodPost, "/api/dry-run?content=true", `{"name": "web", "framework": "vue"}`)
+	testutil.AssertEqual(t, rec.Code, http.StatusOK)
+	var resp struct {
+		Entries []struct {
+			Path    string  `json:"path"`
+			Content *string `json:"content"`
+		} `json:"entries"`
+	}
+	testutil.AssertNoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	found := false
+	for _, e := range resp.Entries {
+		if e.Path == "src/App.vue" {
+			found = e.Content != nil && strings.Contains(*e.Content, "<template>")
+		}
+	}
+	if !found {
+		t.Errorf("src/App.vue should be listed with its content:\n%s", rec.Body.String())
+	}
+
+	// Meta-framework files only exist once the upstream CLI has run
+	rec = request(t, server.Options{}, http.MethodPost, "/api/dry-run", `{"name": "web", "framework": "nextjs"}`)
+	testutil.AssertEqual(t, rec.Code, http.StatusUnprocessableEntity)
+}
+
+func TestGenerate(t *testing.T) {
+	rec := request(t, server.Options{}, http.MethodPost, "/api/generate", `{"name": "web", "framework": "solid"}`)
+	testutil.AssertEqual(t, rec.Code, http.StatusOK)
+	testutil.AssertEqual(t, rec.Header().Get("Content-Type"), "application/zip")
+	testutil.AssertEqual(t, rec.Header().Get("Content-Disposition"), `attachment; filename="web.zip"`)
+
+	r, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	testutil.AssertNoError(t, err)
+	var pkg []byte
+	for _, f := range r.File {
+		if f.Name == "web/package.json" {
+			rc, err := f.Open()
+			testutil.AssertNoError(t, err)
+			pkg, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+	if !strings.Contains(string(pkg), "solid-js") {
+		t.Errorf("the archive should hold the Solid project's package.json, got %q", pkg)
+	}
+}
+
+func TestGenerateTimeoutStopsScaffold(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake npx is a shell script")
+	}
+	// An npx that hangs, so only the request timeout can end the scaffold
+	bin, tmp := testutil.TempDir(t), testutil.TempDir(t)
+	started := filepath.Join(bin, "started")
+	script := "#!/bin/sh\ntouch " + started + "\nexec sleep 30\n"
+	if err := os.WriteFile(filepath.Join(bin, "npx"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("TMPDIR", tmp)
+
+	rec := request(t, server.Options{Timeout: 500 * time.Millisecond}, http.MethodPost, "/api/generate", `{"name": "web", "framework": "nextjs"}`)
+	testutil.AssertEqual(t, rec.Code, http.StatusServiceUnavailable)
+	testutil.AssertFileExists(t, started)
+
+	// The scaffold is killed and its temporary directory removed
+	scaffolds := func() []string {
+		dirs, _ := filepath.Glob(filepath.Join(tmp, "frontforge-scaffold-*"))
+		return dirs
+	}
+	for deadline := time.Now().Add(5 * time.Second); len(scaffolds()) > 0 && time.Now().Before(deadline); {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if dirs := scaffolds(); len(dirs) > 0 {
+		t.Errorf("the timed-out scaffold should be stopped and cleaned up, found %v", dirs)
+	}
+}
+
+func TestRequestErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   server.Options
+		method string
+		target string
+		body   string
+		code   int
+	}{
+		{"wrong method", server.Options{}, http.MethodGet, "/api/generate", "", http.StatusMethodNotAllowed},
+		{"missing name", server.Options{}, http.MethodPost, "/api/generate", `{"framework": "vue"}`, http.StatusBadRequest},
+		{"invalid format", server.Options{}, http.MethodPost, "/api/generate?format=rar", `{"name": "web"}`, http.StatusBadRequest},
+		{"conflicting options", server.Options{}, http.MethodPost, "/api/generate", `{"name": "web", "framework": "vue", "uiLibrary": "shadcn"}`, http.StatusUnprocessableEntity},
+		{"body too large", server.Options{MaxBodyBytes: 16}, http.MethodPost, "/api/validate", `{"framework": "vue"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(t, tt.opts, tt.method, tt.target, tt.body)
+			testutil.AssertEqual(t, rec.Code, tt.code)
+			var resp struct {
+				Error string `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Error == "" {
+				t.Errorf("expected a JSON error, got %s", rec.Body.String())
+			}
+		})
+	}
+}
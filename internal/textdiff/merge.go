@@ -0,0 +1,110 @@
+package textdiff
+
+import "strings"
+
+// Conflict markers written by Merge3
+const (
+	MarkerOurs   = "<<<<<<<"
+	MarkerSep    = "======="
+	MarkerTheirs = ">>>>>>>"
+)
+
+// Merge3 merges the changes from base to ours and from base to theirs
+// line by line, like diff3. Regions both sides changed differently are
+// written between conflict markers labelled with the names. It returns the
+// merged text and the number of conflicts.
+func Merge3(base, ours, theirs, oursName, theirsName string) (string, int) {
+	if ours == theirs || theirs == base {
+		return ours, 0
+	}
+	if ours == base {
+		return theirs, 0
+	}
+
+	b, o, t := splitLines(base), splitLines(ours), splitLines(theirs)
+	mo := matches(diffLines(b, o), len(b))
+	mt := matches(diffLines(b, t), len(b))
+
+	var out []string
+	conflicts := 0
+	i, x, y := 0, 0, 0 // Positions in base, ours and theirs
+	for i < len(b) || x < len(o) || y < len(t) {
+		// Lines unchanged on both sides
+		k := 0
+		for i+k < len(b) && mo[i+k] == x+k && mt[i+k] == y+k {
+			k++
+		}
+		if k > 0 {
+			out = append(out, b[i:i+k]...)
+			i, x, y = i+k, x+k, y+k
+			continue
+		}
+
+		// A changed region runs to the next base line both sides kept
+		j := i
+		for j < len(b) && (mo[j] < 0 || mt[j] < 0) {
+			j++
+		}
+		xEnd, yEnd := len(o), len(t)
+		if j < len(b) {
+			xEnd, yEnd = mo[j], mt[j]
+		}
+		baseChunk, oursChunk, theirsChunk := b[i:j], o[x:xEnd], t[y:yEnd]
+		switch {
+		case equalLines(oursChunk, baseChunk), equalLines(oursChunk, theirsChunk):
+			out = append(out, theirsChunk...)
+		case equalLines(theirsChunk, baseChunk):
+			out = append(out, oursChunk...)
+		default:
+			conflicts++
+			out = append(out, MarkerOurs+" "+oursName)
+			out = append(out, oursChunk...)
+			out = append(out, MarkerSep)
+			out = append(out, theirsChunk...)
+			out = append(out, MarkerTheirs+" "+theirsName)
+		}
+		i, x, y = j, xEnd, yEnd
+	}
+
+	if len(out) == 0 {
+		return "", conflicts
+	}
+	merged := strings.Join(out, "\n")
+	if strings.HasSuffix(ours, "\n") || strings.HasSuffix(theirs, "\n") {
+		merged += "\n"
+	}
+	return merged, conflicts
+}
+
+// matches maps each base line to the line it is kept as in an edit script,
+// or -1 if it was removed
+func matches(ops []op, n int) []int {
+	m := make([]int, n)
+	i, j := 0, 0
+	for _, o := range ops {
+		switch o.kind {
+		case ' ':
+			m[i] = j
+			i++
+			j++
+		case '-':
+			m[i] = -1
+			i++
+		case '+':
+			j++
+		}
+	}
+	return m
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package textdiff_test
+
+import (
+	"frontforge/internal/testutil"
+	"frontforge/internal/textdiff"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedEqual(t *testing.T) {
+	testutil.AssertEqual(t, textdiff.Unified("a", "b", "x\ny\n", "x\ny\n", 3), "")
+}
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "changed line",
+			a:    "one\ntwo\nthree\n",
+			b:    "one\n2\nthree\n",
+			want: "--- old\n+++ new\n@@ -1,3 +1,3 @@\n one\n-two\n+2\n three\n",
+		},
+		{
+			name: "added to empty",
+			a:    "",
+			b:    "node_modules\n",
+			want: "--- old\n+++ new\n@@ -0,0 +1 @@\n+node_modules\n",
+		},
+		{
+			name: "appended line",
+			a:    "a\nb\n",
+			b:    "a\nb\nc\n",
+			want: "--- old\n+++ new\n@@ -1,2 +1,3 @@\n a\n b\n+c\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.AssertEqual(t, textdiff.Unified("old", "new", tt.a, tt.b, 3), tt.want)
+		})
+	}
+}
+
+func TestUnifiedSeparateHunks(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 20; i++ {
+		line := string(rune('a' + i))
+		a = append(a, line)
+		b = append(b, line)
+	}
+	b[1] = "B"
+	b[18] = "S"
+
+	diff := textdiff.Unified("old", "new", strings.Join(a, "\n")+"\n", strings.Join(b, "\n")+"\n", 1)
+	if got := strings.Count(diff, "@@ -"); got != 2 {
+		t.Fatalf("expected 2 hunks, got %d:\n%s", got, diff)
+	}
+	for _, hunk := range []string{
+		"@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		"@@ -18,3 +18,3 @@\n r\n-s\n+S\n t\n",
+	} {
+		if !strings.Contains(diff, hunk) {
+			t.Errorf("missing hunk %q in:\n%s", hunk, diff)
+		}
+	}
+}
+
+func TestMerge3(t *testing.T) {
+	base := "import a\n\nconst x = 1\nconst y = 2\n\nexport default x\n"
+	tests := []struct {
+		name          string
+		ours, theirs  string
+		want          string
+		wantConflicts int
+	}{
+		{
+			name:   "only theirs changed",
+			ours:   base,
+			theirs: "import a\n\nconst x = 10\nconst y = 2\n\nexport default x\n",
+			want:   "import a\n\nconst x = 10\nconst y = 2\n\nexport default x\n",
+		},
+		{
+			name:   "separate changes",
+			ours:   "import a\nimport b\n\nconst x = 1\nconst y = 2\n\nexport default x\n",
+			theirs: "import a\n\nconst x = 1\nconst y = 2\n\nexport default y\n",
+			want:   "import a\nimport b\n\nconst x = 1\nconst y = 2\n\nexport default y\n",
+		},
+		{
+			name:   "same change on both sides",
+			ours:   "import a\n\nconst x = 3\nconst y = 2\n\nexport default x\n",
+			theirs: "import a\n\nconst x = 3\nconst y = 2\n\nexport default x\n// new\n",
+			want:   "import a\n\nconst x = 3\nconst y = 2\n\nexport default x\n// new\n",
+		},
+		{
+			name:          "conflicting change",
+			ours:          "import a\n\nconst x = 3\nconst y = 2\n\nexport default x\n",
+			theirs:        "import a\n\nconst x = 4\nconst y = 2\n\nexport default x\n",
+			want:          "import a\n\n<<<<<<< current\nconst x = 3\n=======\nconst x = 4\n>>>>>>> generated\nconst y = 2\n\nexport default x\n",
+			wantConflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := textdiff.Merge3(base, tt.ours, tt.theirs, "current", "generated")
+			testutil.AssertEqual(t, got, tt.want)
+			testutil.AssertEqual(t, conflicts, tt.wantConflicts)
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package tui
+
+import (
+	"fmt"
+	"frontforge/internal/generators"
+	"frontforge/internal/models"
+	"frontforge/internal/textdiff"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// stagedMsg carries a generated project waiting to be committed
+type stagedMsg struct{ staged *generators.Staged }
+
+// conflictKeys maps the keys of the conflicts screen to actions. Upper case
+// applies the action to every file.
+var conflictKeys = map[string]string{
+	"s": models.OnConflictSkip,
+	"o": models.OnConflictOverwrite,
+	"b": models.OnConflictBackup,
+	"m": models.OnConflictMerge,
+}
+
+// stageProject generates the project into a staging directory
+func (m Model) stageProject() tea.Msg {
+	staged, err := generators.Stage(m.config, os.Stdout)
+	if err != nil {
+		return errorMsg{err: err}
+	}
+	return stagedMsg{staged: staged}
+}
+
+// commitStaged moves the staged project into place with the chosen actions
+func (m Model) commitStaged() tea.Cmd {
+	staged, choices := m.staged, m.conflictChoices
+	return func() tea.Msg {
+		result, err := staged.Commit(choices)
+		if err != nil {
+			return errorMsg{err: err}
+		}
+		return generationCompleteMsg{result: result}
+	}
+}
+
+// showConflicts opens the conflicts screen for a staged project. Each file
+// starts as merge when it can be merged and skip otherwise.
+func (m *Model) showConflicts(staged *generators.Staged) {
+	m.staged = staged
+	m.conflictChoices = make(map[string]string)
+	for _, c := range staged.Conflicts {
+		m.conflictChoices[c.Path] = models.OnConflictSkip
+		if generators.CanMerge(c.Path) {
+			m.conflictChoices[c.Path] = models.OnConflictMerge
+		}
+	}
+	m.conflictCursor = 0
+	m.diffOffset = 0
+	m.currentState = StateConflicts
+}
+
+// discardStaged removes a staged project that was never committed
+func (m *Model) discardStaged() {
+	if m.staged != nil {
+		m.staged.Discard()
+		m.staged = nil
+	}
+}
+
+// updateConflicts handles keys on the conflicts screen
+func (m Model) updateConflicts(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	conflicts := m.staged.Conflicts
+	key := msg.String()
+	switch key {
+	case "up", "k":
+		if m.conflictCursor > 0 {
+			m.conflictCursor--
+			m.diffOffset = 0
+		}
+	case "down", "j":
+		if m.conflictCursor < len(conflicts)-1 {
+			m.conflictCursor++
+			m.diffOffset = 0
+		}
+	case "pgdown", "ctrl+d", " ":
+		m.diffOffset += m.diffHeight()
+	case "pgup", "ctrl+u":
+		m.diffOffset -= m.diffHeight()
+		if m.diffOffset < 0 {
+			m.diffOffset = 0
+		}
+	case "enter":
+		m.currentState = StateForging
+		m.anim.CurrentTask = "Moving files into place"
+		return m, tea.Batch(m.commitStaged(), m.spinner.Tick)
+	case "esc":
+		// Abandon this run and return to the review screen
+		m.discardStaged()
+		m.currentState = StateReview
+	default:
+		action, ok := conflictKeys[strings.ToLower(key)]
+		if !ok {
+			break
+		}
+		if key != strings.ToLower(key) {
+			for _, c := range conflicts {
+				m.conflictChoices[c.Path] = conflictChoice(action, c.Path)
+			}
+		} else {
+			path := conflicts[m.conflictCursor].Path
+			m.conflictChoices[path] = conflictChoice(action, path)
+		}
+	}
+	return m, nil
+}
+
+// conflictChoice keeps files that can't be merged instead of merging them
+func conflictChoice(action, path string) string {
+	if action == models.OnConflictMerge && !generators.CanMerge(path) {
+		return models.OnConflictSkip
+	}
+	return action
+}
+
+// diffHeight is the number of diff lines shown at once
+func (m Model) diffHeight() int {
+	height := m.layout.Height - len(m.staged.Conflicts) - 16
+	if height < 8 {
+		height = 8
+	}
+	return height
+}
+
+// viewConflicts lists the files that already exist with different content,
+// with the action chosen for each and a diff of the selected one
+func (m Model) viewConflicts() string {
+	var b strings.Builder
+	contentWidth := m.layout.AdaptiveBox - 4
+	conflicts := m.staged.Conflicts
+
+	header := RenderStatusHeader("warning", "EXISTING FILES")
+	b.WriteString(header + "\n\n")
+	subtitle := helpTextStyle.Render(fmt.Sprintf("%d file(s) in %s differ from the generated ones. Choose what to do with each.", len(conflicts), m.config.ProjectPath))
+	b.WriteString(subtitle + "\n\n")
+
+	for i, c := range conflicts {
+		rel, _ := filepath.Rel(m.config.ProjectPath, c.Path)
+		cursor := "  "
+		nameStyle := forgeMutedStyle
+		if i == m.conflictCursor {
+			cursor = forgeInfoStyle.Render("› ")
+			nameStyle = inputValueStyle
+		}
+		action := fmt.Sprintf("[%s]", m.conflictChoices[c.Path])
+		b.WriteString(cursor + forgeWarningStyle.Render(fmt.Sprintf("%-12s", action)) + nameStyle.Render(filepath.ToSlash(rel)) + "\n")
+	}
+	b.WriteString("\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(colorAnvilGray).
+		Render(strings.Repeat("─", contentWidth))
+	b.WriteString(divider + "\n")
+
+	// Diff of the selected file, scrolled by diffOffset
+	selected := conflicts[m.conflictCursor]
+	rel, _ := filepath.Rel(m.config.ProjectPath, selected.Path)
+	rel = filepath.ToSlash(rel)
+	diff := textdiff.Unified(rel+" (existing)", rel+" (generated)", string(selected.Existing), string(selected.Generated), 3)
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	offset := m.diffOffset
+	if offset > len(lines)-1 {
+		offset = len(lines) - 1
+	}
+	end := offset + m.diffHeight()
+	if end > len(lines) {
+		end = len(lines)
+	}
+	for _, line := range lines[offset:end] {
+		style := forgeMutedStyle
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			style = forgeLabelStyle
+		case strings.HasPrefix(line, "@@"):
+			style = forgeInfoStyle
+		case strings.HasPrefix(line, "+"):
+			style = forgeSuccessStyle
+		case strings.HasPrefix(line, "-"):
+			style = forgeErrorStyle
+		}
+		if runes := []rune(line); contentWidth > 0 && len(runes) > contentWidth {
+			line = string(runes[:contentWidth])
+		}
+		b.WriteString(style.Render(line) + "\n")
+	}
+	if end < len(lines) {
+		b.WriteString(forgeMutedStyle.Render(fmt.Sprintf("… %d more line(s) [PgDn]", len(lines)-end)) + "\n")
+	}
+	b.WriteString(divider + "\n\n")
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colorAshGray).
+		Align(lipgloss.Center).
+		Width(contentWidth)
+	b.WriteString(hintStyle.Render("[s] Skip  [o] Overwrite  [b] Backup  [m] Merge  (upper case: all files)") + "\n")
+	b.WriteString(hintStyle.Render("[↑/↓] Select  [PgUp/PgDn] Scroll diff  [Enter] Continue  [Esc] Cancel") + "\n")
+
+	return lipgloss.NewStyle().
+		Padding(1, 0).
+		Render(b.String())
+}
+
+// SetConflictPolicy sets how existing files are handled; prompt (the
+// default) shows the conflicts screen
+func (m *Model) SetConflictPolicy(policy string) {
+	m.config.OnConflict = policy
+}
+
+// SetStaged opens the conflicts screen for a staged project (for testing)
+func (m *Model) SetStaged(staged *generators.Staged) {
+	m.showConflicts(staged)
+}
+
+// GetResult returns the outcome of the last generation
+func (m Model) GetResult() *generators.Result {
+	return m.result
+}
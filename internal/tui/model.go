@@ -5,7 +5,9 @@
 //
 // State machine flow:
 //
-//	Welcome → Form → Confirm → PreflightChecks → Forging → Complete
+//	Welcome → [Load preset] → Form → Confirm → PreflightChecks → Forging → Complete
+//
+// Saved presets can be stored from the Review and Finished screens.
 //
 // The TUI follows the Elm Architecture pattern:
 //   - Model: Holds application state and configuration
@@ -19,17 +21,22 @@
 //   - Minimal animations (< 300ms) for feedback only
 //
 // The Model struct is organized into sub-structs for clarity:
-//   - formState: Form field values
+//   - formState: Form field values (shared pointer, bound to the Huh form)
+//   - presetState: Saved preset selection and naming
 //   - layout: Terminal dimensions
 //   - anim: Simple animation counters
 package tui
 
 import (
 	"fmt"
+	"frontforge/internal/generators"
 	"frontforge/internal/models"
 	"frontforge/internal/preflight"
+	"frontforge/internal/presets"
 	"frontforge/internal/tui/state"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -50,6 +57,8 @@ const (
 	StateForging                      // Generation in progress (was StateGenerating)
 	StateFinished                     // Success screen (was StateSuccess)
 	StateCracked                      // Error screen (was StateError)
+	StateSavePreset                   // Naming dialog for saving a preset
+	StateConflicts                    // Existing files differ from the generated ones
 
 	// Legacy state aliases for compatibility
 	StateForm       = StateBlueprint
@@ -67,18 +76,39 @@ type Model struct {
 	err           error
 
 	// Sub-states for better organization
-	formState state.FormState
-	layout    state.LayoutState
-	anim      state.AnimationState
+	// formState and presetState are pointers because Huh binds form fields
+	// to their addresses, and the Model itself is passed by value
+	formState   *state.FormState
+	presetState *state.PresetState
+	layout      state.LayoutState
+	anim        state.AnimationState
 
 	// Components
-	form     *huh.Form
-	spinner  spinner.Model
-	progress *ProgressTracker
+	form       *huh.Form
+	presetForm *huh.Form // "Load preset" step; nil when no presets are saved
+	saveForm   *huh.Form // "Save preset" naming dialog
+	spinner    spinner.Model
+	progress   *ProgressTracker
+
+	// Preset storage (nil if the user config directory is unavailable)
+	presetStore     *presets.Store
+	saveReturnState State // Screen to return to after saving a preset
 
 	// Generation state
 	generationComplete bool
 	preflightResults   *preflight.PreflightResults
+	result             *generators.Result // Set once the project is in place
+
+	// Verification (-verify): install, then the project's own scripts
+	verifyTimeout time.Duration                 // Per-step limit; zero keeps the defaults
+	verification  []generators.ValidationResult // One result per step that ran
+	verifyErr     error                         // Why verification couldn't run
+
+	// Conflicts screen: a staged project waiting for a choice per existing file
+	staged          *generators.Staged
+	conflictChoices map[string]string // Action per conflicting path
+	conflictCursor  int
+	diffOffset      int // First diff line shown
 }
 
 // Init initializes the Bubbletea model
@@ -94,18 +124,33 @@ func NewModel() Model {
 	s.Spinner = ForgingSpinner()
 	s.Style = forgeSpinnerStyle
 
+	formState := state.NewFormState()
+
+	// Saved presets are optional; a missing config directory just hides the step
+	var available []string
+	store, err := presets.Default()
+	if err == nil {
+		available, _ = store.List()
+	} else {
+		store = nil
+	}
+	presetState := state.NewPresetState(available)
+
 	m := Model{
 		currentState:  StateWelcome,
 		previousState: StateWelcome,
-		config:        models.Config{},
-		formState:     state.NewFormState(),
+		config:        models.Config{OnConflict: models.OnConflictPrompt},
+		formState:     &formState,
+		presetState:   &presetState,
 		layout:        state.NewLayoutState(),
 		anim:          state.NewAnimationState(),
 		spinner:       s,
 		progress:      NewProgressTracker(len(QuestionCatalog)),
+		presetStore:   store,
 	}
 
 	m.form = m.createForm()
+	m.presetForm = m.createPresetForm()
 	return m
 }
 
@@ -139,9 +184,10 @@ func NewModelWithPath(absPath string, userPath string) Model {
 	return m
 }
 
-// createForm builds the Huh form with all questions
+// createForm builds the Huh form: setup mode and project name, followed by
+// one question per option registry group
 func (m *Model) createForm() *huh.Form {
-	form := huh.NewForm(
+	groups := []*huh.Group{
 		// Group 1: Setup mode
 		huh.NewGroup(
 			huh.NewSelect[string]().
@@ -160,531 +206,223 @@ func (m *Model) createForm() *huh.Form {
 				Placeholder("my-app").
 				Value(&m.formState.ProjectName).
 				Validate(func(s string) error {
-					if !validateProjectName(s) {
+					if !models.ValidProjectName(s) {
 						return fmt.Errorf("project name must contain only letters, numbers, hyphens, and underscores")
 					}
 					return nil
 				}),
 		),
+	}
 
-		// Group 3: Language (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Select your language").
-				Options(
-					huh.NewOption("TypeScript (recommended)", models.LangTypeScript),
-					huh.NewOption("JavaScript", models.LangJavaScript),
-				).
-				Value(&m.formState.Language),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick)
-		}),
-
-		// Group 4: Framework (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Choose a framework").
-				Options(
-					huh.NewOption("React", models.FrameworkReact),
-					huh.NewOption("Vue", models.FrameworkVue),
-					huh.NewOption("Angular (standalone)", models.FrameworkAngular),
-					huh.NewOption("Svelte", models.FrameworkSvelte),
-					huh.NewOption("Solid", models.FrameworkSolid),
-					huh.NewOption("Vanilla (no framework)", models.FrameworkVanilla),
-					// Meta-frameworks (shell out to upstream CLIs)
-					huh.NewOption("Next.js (React)", models.FrameworkNextJS),
-					huh.NewOption("Astro (content-focused)", models.FrameworkAstro),
-					huh.NewOption("SvelteKit (Svelte)", models.FrameworkSvelteKit),
-				).
-				Value(&m.formState.Framework),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick)
-		}),
-
-		// Group 5: Package manager (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Package manager").
-				Options(
-					huh.NewOption("npm", models.PackageManagerNpm),
-					huh.NewOption("yarn", models.PackageManagerYarn),
-					huh.NewOption("pnpm", models.PackageManagerPnpm),
-					huh.NewOption("bun", models.PackageManagerBun),
-				).
-				Value(&m.formState.PackageManager),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick)
-		}),
-
-		// Group 6: Styling for Vite-based frameworks (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("How would you like to style your app?").
-				Options(
-					huh.NewOption("Tailwind CSS", models.StylingTailwind),
-					huh.NewOption("Bootstrap", models.StylingBootstrap),
-					huh.NewOption("CSS Modules", models.StylingCSSModules),
-					huh.NewOption("Sass/SCSS", models.StylingSass),
-					huh.NewOption("Styled Components", models.StylingStyled),
-					huh.NewOption("Vanilla CSS", models.StylingVanilla),
-				).
-				Value(&m.formState.Styling),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.isMetaSelected()
-		}),
-
-		// Group 6-meta: Styling for meta-frameworks (filtered by OptionMatrix)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("How would you like to style your app?").
-				Options(
-					huh.NewOption("Tailwind CSS", models.StylingTailwind),
-					huh.NewOption("CSS Modules", models.StylingCSSModules),
-					huh.NewOption("Sass/SCSS", models.StylingSass),
-					huh.NewOption("Vanilla CSS", models.StylingVanilla),
-				).
-				Value(&m.formState.Styling),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || !m.isMetaSelected()
-		}),
-
-		// Group 6a: UI Component Library for React (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("UI Component Library").
-				Options(
-					huh.NewOption("Shadcn/ui (recommended)", models.UILibraryShadcn),
-					huh.NewOption("Material-UI (MUI)", models.UILibraryMUI),
-					huh.NewOption("Chakra UI", models.UILibraryChakra),
-					huh.NewOption("Ant Design", models.UILibraryAntD),
-					huh.NewOption("Headless UI", models.UILibraryHeadless),
-					huh.NewOption("None", models.UILibraryNone),
-				).
-				Value(&m.formState.UILibrary),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkReact || m.isMetaSelected()
-		}),
-
-		// Group 6b: UI Component Library for Vue (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("UI Component Library").
-				Options(
-					huh.NewOption("Vuetify", models.UILibraryVuetify),
-					huh.NewOption("PrimeVue", models.UILibraryPrimeVue),
-					huh.NewOption("Element Plus", models.UILibraryElementUI),
-					huh.NewOption("Naive UI", models.UILibraryNaiveUI),
-					huh.NewOption("None", models.UILibraryNone),
-				).
-				Value(&m.formState.UILibrary),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkVue
-		}),
-
-		// Group 6c: UI Component Library for Angular (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("UI Component Library").
-				Options(
-					huh.NewOption("Angular Material", models.UILibraryAngularMaterial),
-					huh.NewOption("PrimeNG", models.UILibraryPrimeNG),
-					huh.NewOption("NG-ZORRO", models.UILibraryNGZorro),
-					huh.NewOption("None", models.UILibraryNone),
-				).
-				Value(&m.formState.UILibrary),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkAngular
-		}),
-
-		// Group 7: Routing for React (only shown in custom mode, not meta-frameworks)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Routing solution").
-				Options(
-					huh.NewOption("React Router", models.RoutingReactRouter),
-					huh.NewOption("TanStack Router", models.RoutingTanStackRouter),
-					huh.NewOption("File-based routing", models.RoutingFileBased),
-					huh.NewOption("None (single page)", models.RoutingNone),
-				).
-				Value(&m.formState.Routing),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkReact || m.isMetaSelected()
-		}),
-
-		// Group 7b: Routing for Svelte (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Routing solution").
-				Options(
-					huh.NewOption("SvelteKit (built-in)", models.RoutingSvelteKit),
-					huh.NewOption("None (single page)", models.RoutingNone),
-				).
-				Value(&m.formState.Routing),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkSvelte
-		}),
-
-		// Group 7c: Routing for Solid (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Routing solution").
-				Options(
-					huh.NewOption("Solid Router", models.RoutingSolidRouter),
-					huh.NewOption("None (single page)", models.RoutingNone),
-				).
-				Value(&m.formState.Routing),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkSolid
-		}),
-
-		// Group 8: Testing for Vite-based (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Testing framework").
-				Options(
-					huh.NewOption("Vitest (fast, Vite-native)", models.TestingVitest),
-					huh.NewOption("Jest", models.TestingJest),
-					huh.NewOption("None (set up later)", models.TestingNone),
-				).
-				Value(&m.formState.Testing),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.isMetaSelected()
-		}),
-
-		// Group 8-meta: Testing for Next.js (Vitest + Jest + None)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Testing framework").
-				Options(
-					huh.NewOption("Vitest (fast, Vite-native)", models.TestingVitest),
-					huh.NewOption("Jest", models.TestingJest),
-					huh.NewOption("None (set up later)", models.TestingNone),
-				).
-				Value(&m.formState.Testing),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkNextJS
-		}),
-
-		// Group 8-meta: Testing for SvelteKit (Vitest + Playwright + None)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Testing framework").
-				Options(
-					huh.NewOption("Vitest (fast, Vite-native)", models.TestingVitest),
-					huh.NewOption("Playwright (E2E)", models.TestingPlaywright),
-					huh.NewOption("None (set up later)", models.TestingNone),
-				).
-				Value(&m.formState.Testing),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkSvelteKit
-		}),
-
-		// Group 8-meta: Testing for Astro (Vitest + None)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Testing framework").
-				Options(
-					huh.NewOption("Vitest (fast, Vite-native)", models.TestingVitest),
-					huh.NewOption("None (set up later)", models.TestingNone),
-				).
-				Value(&m.formState.Testing),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkAstro
-		}),
-
-		// Group 9: State management for React (only shown in custom mode, not meta)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("State management").
-				Options(
-					huh.NewOption("Zustand (lightweight)", models.StateZustand),
-					huh.NewOption("Redux Toolkit", models.StateReduxToolkit),
-					huh.NewOption("Context API only", models.StateContextAPI),
-					huh.NewOption("None", models.StateNone),
-				).
-				Value(&m.formState.StateManagement),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkReact || m.isMetaSelected()
-		}),
-
-		// Group 9-meta: State management for Next.js
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("State management").
-				Options(
-					huh.NewOption("Zustand (lightweight)", models.StateZustand),
-					huh.NewOption("Redux Toolkit", models.StateReduxToolkit),
-					huh.NewOption("Context API only", models.StateContextAPI),
-					huh.NewOption("None", models.StateNone),
-				).
-				Value(&m.formState.StateManagement),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkNextJS
-		}),
-
-		// Group 9-meta: State management for SvelteKit
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("State management").
-				Options(
-					huh.NewOption("Svelte Stores (built-in)", models.StateSvelteStores),
-					huh.NewOption("None", models.StateNone),
-				).
-				Value(&m.formState.StateManagement),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkSvelteKit
-		}),
-
-		// Group 9b: State management for Vue (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("State management").
-				Options(
-					huh.NewOption("Pinia (recommended)", models.StatePinia),
-					huh.NewOption("Vuex", models.StateVuex),
-					huh.NewOption("None", models.StateNone),
-				).
-				Value(&m.formState.StateManagement),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkVue
-		}),
-
-		// Group 9c: State management for Svelte (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("State management").
-				Options(
-					huh.NewOption("Svelte Stores (built-in)", models.StateSvelteStores),
-					huh.NewOption("None", models.StateNone),
-				).
-				Value(&m.formState.StateManagement),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkSvelte
-		}),
-
-		// Group 9d: State management for Solid (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("State management").
-				Options(
-					huh.NewOption("Solid Stores (built-in)", models.StateSolidStores),
-					huh.NewOption("None", models.StateNone),
-				).
-				Value(&m.formState.StateManagement),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkSolid
-		}),
+	// Remaining questions (only shown in custom mode)
+	for i := range models.Registry {
+		groups = append(groups, m.createOptionGroups(&models.Registry[i])...)
+	}
 
-		// Group 9e: State management for Angular (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("State management").
-				Options(
-					huh.NewOption("NgRx", models.StateNgRx),
-					huh.NewOption("None", models.StateNone),
-				).
-				Value(&m.formState.StateManagement),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkAngular
-		}),
+	// No confirmation question - review screen handles this
+	form := huh.NewForm(groups...)
 
-		// Group 9f: Form Management for React (only shown in custom mode, not meta)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Form Management").
-				Options(
-					huh.NewOption("React Hook Form (recommended)", models.FormReactHookForm),
-					huh.NewOption("Formik", models.FormFormik),
-					huh.NewOption("TanStack Form", models.FormTanStackForm),
-					huh.NewOption("None (native)", models.FormNone),
-				).
-				Value(&m.formState.FormManagement),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkReact || m.isMetaSelected()
-		}),
+	// Apply custom forge theme to match the rest of the TUI
+	form.WithTheme(ForgeTheme())
 
-		// Group 9g: Form Management for Vue (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Form Management").
-				Options(
-					huh.NewOption("VeeValidate", models.FormVeeValidate),
-					huh.NewOption("None (native)", models.FormNone),
-				).
-				Value(&m.formState.FormManagement),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkVue
-		}),
+	return form
+}
 
-		// Group 10: Data fetching for Vite-based (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Data fetching approach").
-				Options(
-					huh.NewOption("TanStack Query", models.DataTanStackQuery),
-					huh.NewOption("Fetch API", models.DataFetchAPI),
-					huh.NewOption("Axios", models.DataAxios),
-					huh.NewOption("SWR", models.DataSWR),
-					huh.NewOption("None (no API calls)", models.DataNone),
-				).
-				Value(&m.formState.DataFetching),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.isMetaSelected()
-		}),
+// optionBucket is a set of answers that are offered the same choices for a
+// question: the framework plus any options that restrict it through
+// compatibility rules (e.g. styling for the UI library)
+type optionBucket struct {
+	states  []string
+	options []models.Option
+}
 
-		// Group 10-meta: Data fetching for Next.js (TanStack + SWR + Axios + Fetch + None)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Data fetching approach").
-				Options(
-					huh.NewOption("TanStack Query", models.DataTanStackQuery),
-					huh.NewOption("SWR", models.DataSWR),
-					huh.NewOption("Axios", models.DataAxios),
-					huh.NewOption("Fetch API", models.DataFetchAPI),
-					huh.NewOption("None (no API calls)", models.DataNone),
-				).
-				Value(&m.formState.DataFetching),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkNextJS
-		}),
+// bucketOptions groups every framework and rule-dependency combination by the
+// options g offers for it, and returns how many combinations there are.
+// Combinations with fewer than two choices are not asked at all.
+func bucketOptions(g *models.OptionGroup) ([]optionBucket, int) {
+	var buckets []optionBucket
+	configs := optionStates(g)
+	for _, config := range configs {
+		options := g.AvailableOptions(config, false)
+		if len(options) < 2 {
+			continue
+		}
+		key := stateKey(g, config)
+		found := false
+		for i := range buckets {
+			if sameOptions(buckets[i].options, options) {
+				buckets[i].states = append(buckets[i].states, key)
+				found = true
+				break
+			}
+		}
+		if !found {
+			buckets = append(buckets, optionBucket{states: []string{key}, options: options})
+		}
+	}
+	return buckets, len(configs)
+}
 
-		// Group 10-meta: Data fetching for SvelteKit (TanStack + Fetch + None)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Data fetching approach").
-				Options(
-					huh.NewOption("TanStack Query", models.DataTanStackQuery),
-					huh.NewOption("Fetch API", models.DataFetchAPI),
-					huh.NewOption("None (no API calls)", models.DataNone),
-				).
-				Value(&m.formState.DataFetching),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.formState.Framework != models.FrameworkSvelteKit
-		}),
+// optionStates returns a config for every framework combined with every value
+// of the options that restrict g
+func optionStates(g *models.OptionGroup) []models.Config {
+	var configs []models.Config
+	for _, framework := range models.AllFrameworks {
+		configs = append(configs, models.Config{Framework: framework})
+	}
+	for _, key := range models.RuleDependencies(g.Key) {
+		dep, _ := models.LookupGroup(key)
+		var expanded []models.Config
+		for _, config := range configs {
+			for _, o := range dep.Options {
+				*dep.Field(&config) = o.Value
+				expanded = append(expanded, config)
+			}
+		}
+		configs = expanded
+	}
+	return configs
+}
 
-		// Group 10a: Animation (only shown in custom mode, not meta)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Animation library").
-				Options(
-					huh.NewOption("Framer Motion (React)", models.AnimationFramerMotion),
-					huh.NewOption("GSAP (any framework)", models.AnimationGSAP),
-					huh.NewOption("Auto Animate", models.AnimationAutoAnimate),
-					huh.NewOption("React Spring", models.AnimationReactSpring),
-					huh.NewOption("None", models.AnimationNone),
-				).
-				Value(&m.formState.Animation),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.isMetaSelected()
-		}),
+// stateKey identifies the answers in config that decide which options g offers
+func stateKey(g *models.OptionGroup, config models.Config) string {
+	parts := []string{config.Framework}
+	for _, key := range models.RuleDependencies(g.Key) {
+		dep, _ := models.LookupGroup(key)
+		parts = append(parts, *dep.Field(&config))
+	}
+	return strings.Join(parts, "|")
+}
 
-		// Group 10b: Icons (only shown in custom mode, not meta)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Icon library").
-				Options(
-					huh.NewOption("Heroicons", models.IconsHeroicons),
-					huh.NewOption("Lucide", models.IconsLucide),
-					huh.NewOption("React Icons", models.IconsReactIcons),
-					huh.NewOption("Font Awesome", models.IconsFontAwesome),
-					huh.NewOption("None", models.IconsNone),
-				).
-				Value(&m.formState.Icons),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.isMetaSelected()
-		}),
+// sameOptions reports whether two option lists hold the same values in the same order
+func sameOptions(a, b []models.Option) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Value != b[i].Value {
+			return false
+		}
+	}
+	return true
+}
 
-		// Group 10c: Data Visualization (only shown in custom mode, not meta)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Data visualization").
-				Options(
-					huh.NewOption("Recharts (React)", models.DataVizRecharts),
-					huh.NewOption("Chart.js (any framework)", models.DataVizChartJS),
-					huh.NewOption("Apache ECharts", models.DataVizECharts),
-					huh.NewOption("Nivo (React)", models.DataVizNivo),
-					huh.NewOption("None", models.DataVizNone),
-				).
-				Value(&m.formState.DataViz),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.isMetaSelected()
-		}),
+// createOptionGroups builds one form group per option bucket of g, each shown
+// only in custom mode and only for the answers in its bucket
+func (m *Model) createOptionGroups(g *models.OptionGroup) []*huh.Group {
+	var groups []*huh.Group
+	buckets, total := bucketOptions(g)
+	for _, bucket := range buckets {
+		options := make([]huh.Option[string], len(bucket.options))
+		for i, o := range bucket.options {
+			options[i] = huh.NewOption(o.Label, o.Value)
+		}
 
-		// Group 10d: Utilities (only shown in custom mode, not meta)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Utility library").
-				Options(
-					huh.NewOption("date-fns (date manipulation)", models.UtilsDateFns),
-					huh.NewOption("Day.js (lightweight dates)", models.UtilsDayJS),
-					huh.NewOption("Lodash-es (tree-shakeable)", models.UtilsLodash),
-					huh.NewOption("None", models.UtilsNone),
-				).
-				Value(&m.formState.Utilities),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.isMetaSelected()
-		}),
+		// A bucket covering every combination is always active; this also
+		// keeps the framework question independent of its own answer
+		states := bucket.states
+		active := func() bool {
+			return len(states) == total || containsString(states, stateKey(g, formConfig(m.formState)))
+		}
 
-		// Group 10e: Internationalization (only shown in custom mode, not meta)
-		huh.NewGroup(
+		groups = append(groups, huh.NewGroup(
 			huh.NewSelect[string]().
-				Title("Internationalization (i18n)").
-				Options(
-					huh.NewOption("react-i18next (React)", models.I18nReactI18next),
-					huh.NewOption("vue-i18n (Vue)", models.I18nVueI18n),
-					huh.NewOption("None", models.I18nNone),
-				).
-				Value(&m.formState.I18n),
+				Title(g.Title).
+				Options(options...).
+				Accessor(&scopedAccessor{value: formField(m.formState, g.Key), active: active}),
 		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick) || m.isMetaSelected()
-		}),
+			return m.formState.SetupMode == string(models.SetupModeQuick) || !active()
+		}))
+	}
+	return groups
+}
 
-		// Group 11: Folder structure (only shown in custom mode)
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Folder structure").
-				Options(
-					huh.NewOption("Feature-based (recommended)", models.StructureFeatureBased),
-					huh.NewOption("Layer-based", models.StructureLayerBased),
-				).
-				Value(&m.formState.Structure),
-		).WithHideFunc(func() bool {
-			return m.formState.SetupMode == string(models.SetupModeQuick)
-		}),
-		// No confirmation question - review screen handles this
-	)
+// scopedAccessor binds a select to a form field but only writes while its
+// bucket is active. Huh writes the highlighted option when a select is built,
+// so without this the per-framework questions sharing a field would
+// overwrite each other (and any preset values) on construction.
+type scopedAccessor struct {
+	value  *string
+	active func() bool
+}
 
-	// Apply custom forge theme to match the rest of the TUI
-	form.WithTheme(ForgeTheme())
+// Get returns the bound field value
+func (a *scopedAccessor) Get() string {
+	return *a.value
+}
 
-	return form
+// Set updates the bound field while the bucket is active
+func (a *scopedAccessor) Set(value string) {
+	if a.active() {
+		*a.value = value
+	}
 }
 
-// isMetaSelected returns true if the current framework selection is a meta-framework
-func (m *Model) isMetaSelected() bool {
-	return models.IsMetaFramework(m.formState.Framework)
+// formField maps a registry key (models.OptionGroup.Key) to the matching FormState field
+func formField(fs *state.FormState, key string) *string {
+	switch key {
+	case "language":
+		return &fs.Language
+	case "framework":
+		return &fs.Framework
+	case "packageManager":
+		return &fs.PackageManager
+	case "styling":
+		return &fs.Styling
+	case "uiLibrary":
+		return &fs.UILibrary
+	case "routing":
+		return &fs.Routing
+	case "testing":
+		return &fs.Testing
+	case "stateManagement":
+		return &fs.StateManagement
+	case "formManagement":
+		return &fs.FormManagement
+	case "dataFetching":
+		return &fs.DataFetching
+	case "animation":
+		return &fs.Animation
+	case "icons":
+		return &fs.Icons
+	case "dataViz":
+		return &fs.DataViz
+	case "utilities":
+		return &fs.Utilities
+	case "i18n":
+		return &fs.I18n
+	case "structure":
+		return &fs.Structure
+	}
+	panic("tui: unknown form field " + key)
 }
 
-// validateProjectName checks if project name is valid
-func validateProjectName(name string) bool {
-	if name == "" {
-		return false
+// formConfig returns the options currently answered in fs
+func formConfig(fs *state.FormState) models.Config {
+	var config models.Config
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		*g.Field(&config) = *formField(fs, g.Key)
 	}
-	// Must contain only alphanumeric, hyphens, and underscores
-	for _, r := range name {
-		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_') {
-			return false
+	return config
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
 		}
 	}
-	return true
+	return false
 }
 
-// ValidateProjectName is the public version of validateProjectName for testing
+// ValidateProjectName explains why a project name is rejected
 func ValidateProjectName(name string) error {
 	if name == "" {
 		return fmt.Errorf("project name is required")
 	}
-	if !validateProjectName(name) {
+	if !models.ValidProjectName(name) {
 		return fmt.Errorf("project name must contain only alphanumeric characters, hyphens, and underscores")
 	}
 	return nil
@@ -717,64 +455,15 @@ func (m *Model) applyFormDataToConfig() {
 		return
 	}
 
-	// Apply custom configuration
-	m.config.Language = m.formState.Language
-	m.config.Framework = m.formState.Framework
-	m.config.PackageManager = m.formState.PackageManager
-	m.config.Styling = m.formState.Styling
-	m.config.UILibrary = m.formState.UILibrary
-	m.config.Testing = m.formState.Testing
-	m.config.FormManagement = m.formState.FormManagement
-	m.config.DataFetching = m.formState.DataFetching
-	m.config.Animation = m.formState.Animation
-	m.config.Icons = m.formState.Icons
-	m.config.DataViz = m.formState.DataViz
-	m.config.Utilities = m.formState.Utilities
-	m.config.I18n = m.formState.I18n
-	m.config.Structure = m.formState.Structure
-
-	// Handle framework-specific defaults
-	switch m.formState.Framework {
-	case models.FrameworkVanilla:
-		m.config.Routing = models.RoutingNone
-		m.config.StateManagement = models.StateNone
-		m.config.UILibrary = models.UILibraryNone
-		m.config.FormManagement = models.FormNone
-	case models.FrameworkVue:
-		m.config.Routing = models.RoutingVueRouter
-		m.config.StateManagement = m.formState.StateManagement
-	case models.FrameworkAngular:
-		m.config.Routing = models.RoutingAngularRouter
-		m.config.StateManagement = m.formState.StateManagement
-	case models.FrameworkSvelte:
-		m.config.Routing = m.formState.Routing
-		m.config.StateManagement = m.formState.StateManagement
-		m.config.UILibrary = models.UILibraryNone
-		m.config.FormManagement = models.FormNone
-	case models.FrameworkSolid:
-		m.config.Routing = m.formState.Routing
-		m.config.StateManagement = m.formState.StateManagement
-		m.config.UILibrary = models.UILibraryNone
-		m.config.FormManagement = models.FormNone
-	case models.FrameworkReact:
-		m.config.Routing = m.formState.Routing
-		m.config.StateManagement = m.formState.StateManagement
-	case models.FrameworkNextJS:
-		m.config.Routing = models.RoutingNextJSAppRouter
-		m.config.StateManagement = m.formState.StateManagement
-		m.config.UILibrary = models.UILibraryNone
-		m.config.FormManagement = models.FormNone
-	case models.FrameworkAstro:
-		m.config.Routing = models.RoutingAstroPages
-		m.config.StateManagement = models.StateNone
-		m.config.UILibrary = models.UILibraryNone
-		m.config.FormManagement = models.FormNone
-	case models.FrameworkSvelteKit:
-		m.config.Routing = models.RoutingSvelteKit
-		m.config.StateManagement = models.StateSvelteStores
-		m.config.UILibrary = models.UILibraryNone
-		m.config.FormManagement = models.FormNone
+	// Apply custom configuration. Answers the chosen framework doesn't
+	// support, or wasn't asked, resolve to its default through the registry.
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		*g.Field(&m.config) = g.Resolve(*formField(m.formState, g.Key), m.formState.Framework)
 	}
+	// Replace answers that break a compatibility rule (e.g. Shadcn/ui from a
+	// preset after switching away from Tailwind)
+	models.FixCompatibility(&m.config, nil)
 }
 
 // Public methods for testing
@@ -791,12 +480,32 @@ func (m *Model) SetCurrentState(s State) {
 
 // GetFormState returns the form state
 func (m Model) GetFormState() state.FormState {
-	return m.formState
+	return *m.formState
 }
 
 // SetFormState sets the form state (for testing)
 func (m *Model) SetFormState(fs state.FormState) {
-	m.formState = fs
+	*m.formState = fs
+}
+
+// SetPresetStore replaces the preset store and reloads the "Load preset" step (for testing)
+func (m *Model) SetPresetStore(store *presets.Store) {
+	m.presetStore = store
+	m.presetState.Available, _ = store.List()
+	m.presetForm = m.createPresetForm()
+}
+
+// SetPresetSelection sets the chosen preset and applies it (for testing)
+func (m *Model) SetPresetSelection(name string) error {
+	m.presetState.Selected = name
+	return m.applyPreset()
+}
+
+// SavePreset saves the current configuration under name (for testing)
+func (m *Model) SavePreset(name string) string {
+	m.presetState.SaveName = name
+	m.savePreset()
+	return m.presetState.Message
 }
 
 // GetConfig returns the config
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"frontforge/internal/configfile"
+	"frontforge/internal/models"
+	"frontforge/internal/presets"
+	"frontforge/internal/tui/state"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// createPresetForm builds the "Load preset" step shown before the main form.
+// Returns nil when no presets are saved, so the step is skipped entirely.
+func (m *Model) createPresetForm() *huh.Form {
+	if len(m.presetState.Available) == 0 {
+		return nil
+	}
+
+	options := []huh.Option[string]{huh.NewOption("None (start fresh)", "")}
+	for _, name := range m.presetState.Available {
+		options = append(options, huh.NewOption(name, name))
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Load preset").
+				Description("Start from a saved configuration").
+				Options(options...).
+				Value(&m.presetState.Selected),
+		),
+	)
+	form.WithTheme(ForgeTheme())
+
+	return form
+}
+
+// createSavePresetForm builds the single-input form used to name a preset
+func (m *Model) createSavePresetForm() *huh.Form {
+	m.presetState.SaveName = ""
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Preset name").
+				Placeholder("team-stack").
+				Value(&m.presetState.SaveName).
+				Validate(presets.ValidateName),
+		),
+	)
+	form.WithTheme(ForgeTheme())
+
+	return form
+}
+
+// startSavePreset opens the naming dialog, returning to from when done
+func (m *Model) startSavePreset(from State) tea.Cmd {
+	m.saveForm = m.createSavePresetForm()
+	m.saveReturnState = from
+	m.presetState.Message = ""
+	m.currentState = StateSavePreset
+	return m.saveForm.Init()
+}
+
+// applyPreset loads the selected preset into the form state.
+// Custom mode is forced so every loaded choice can be reviewed and edited.
+func (m *Model) applyPreset() error {
+	if m.presetState.Selected == "" || m.presetStore == nil {
+		return nil
+	}
+
+	file, err := m.presetStore.Load(m.presetState.Selected)
+	if err != nil {
+		return err
+	}
+
+	applyFileToFormState(file, m.formState)
+	m.formState.SetupMode = string(models.SetupModeCustom)
+	return nil
+}
+
+// savePreset writes the current configuration under the typed name
+func (m *Model) savePreset() {
+	if m.presetStore == nil {
+		m.presetState.Message = "Presets are unavailable: no user config directory"
+		return
+	}
+
+	m.applyFormDataToConfig()
+	if err := m.presetStore.Save(m.presetState.SaveName, m.config); err != nil {
+		m.presetState.Message = fmt.Sprintf("Could not save preset: %v", err)
+		return
+	}
+
+	m.presetState.Message = fmt.Sprintf("Saved preset %q", m.presetState.SaveName)
+}
+
+// applyFileToFormState copies every non-empty preset field into the form state
+func applyFileToFormState(file *configfile.File, fs *state.FormState) {
+	var config models.Config
+	file.Apply(&config)
+
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		if value := *g.Field(&config); value != "" {
+			*formField(fs, g.Key) = value
+		}
+	}
+}
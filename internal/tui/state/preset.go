@@ -0,0 +1,16 @@
+package state
+
+// PresetState holds user preset selection and saving state
+type PresetState struct {
+	Available []string // Names of saved presets, loaded at startup
+	Selected  string   // Preset chosen in the "Load preset" step ("" = start fresh)
+	SaveName  string   // Name typed into the "Save preset" form
+	Message   string   // Feedback shown after a save attempt
+}
+
+// NewPresetState creates a PresetState for the given saved preset names
+func NewPresetState(available []string) PresetState {
+	return PresetState{
+		Available: available,
+	}
+}
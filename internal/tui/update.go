@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"frontforge/internal/generators"
+	"frontforge/internal/models"
 	"frontforge/internal/preflight"
 	"time"
 
@@ -15,7 +16,7 @@ import (
 type runPreflightChecksMsg struct{}
 type preflightCompleteMsg struct{}
 type generateProjectMsg struct{}
-type generationCompleteMsg struct{}
+type generationCompleteMsg struct{ result *generators.Result }
 type errorMsg struct{ err error }
 
 // Update handles messages and updates the model
@@ -25,10 +26,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Global key handlers (except during critical states)
 		switch msg.String() {
 		case "ctrl+c":
+			m.discardStaged()
 			return m, tea.Quit
 		case "q":
-			// Prevent accidental quit during preflight checks or generation
-			if m.currentState != StatePreflightChecks && m.currentState != StateForging {
+			// Prevent accidental quit during preflight checks, generation,
+			// conflict review or while typing a preset name
+			if m.currentState != StatePreflightChecks && m.currentState != StateForging &&
+				m.currentState != StateSavePreset && m.currentState != StateConflicts {
 				return m, tea.Quit
 			}
 		}
@@ -52,6 +56,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Move from welcome to blueprint phase
 				m.currentState = StateBlueprint
 				m.anim.TickCount = 0 // Reset tick counter
+				// Offer saved presets first, if there are any
+				if m.presetForm != nil {
+					return m, m.presetForm.Init()
+				}
 				// Initialize the form now
 				return m, m.form.Init()
 			case "esc":
@@ -72,11 +80,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Show confirmation before going back
 				m.currentState = StateConfirmBack
 				return m, nil
+			case "s", "S":
+				return m, m.startSavePreset(StateReview)
 			case "esc":
 				// Cancel - quit the application
 				return m, tea.Quit
 			}
 
+		case StateFinished:
+			switch msg.String() {
+			case "s", "S":
+				return m, m.startSavePreset(StateFinished)
+			case "enter", "esc":
+				return m, tea.Quit
+			}
+
+		case StateConflicts:
+			return m.updateConflicts(msg)
+
+		case StateSavePreset:
+			if msg.String() == "esc" {
+				// Cancel without saving
+				m.currentState = m.saveReturnState
+				return m, nil
+			}
+
 		case StateConfirmForge:
 			switch msg.String() {
 			case "y", "Y", "enter":
@@ -192,8 +220,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Brief animation feedback (500ms max)
 				start := time.Now()
 
-				// Generate the project
-				err := generators.SetupProject(m.config)
+				// Stage the project; nothing is written to the target yet
+				msg := m.stageProject()
 
 				// Brief minimum display for visual feedback
 				elapsed := time.Since(start)
@@ -201,25 +229,89 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if elapsed < minDisplayTime {
 					time.Sleep(minDisplayTime - elapsed)
 				}
-
-				if err != nil {
-					return errorMsg{err: err}
-				}
-				return generationCompleteMsg{}
+				return msg
 			},
 			m.spinner.Tick, // Keep spinner ticking during generation
 		)
 
+	case stagedMsg:
+		// Existing files that differ: let the user choose under the prompt policy
+		if len(msg.staged.Conflicts) > 0 && m.config.OnConflict == models.OnConflictPrompt {
+			m.showConflicts(msg.staged)
+			return m, nil
+		}
+		m.staged = msg.staged
+		m.conflictChoices = nil
+		return m, m.commitStaged()
+
 	case generationCompleteMsg:
+		m.staged = nil
+		m.result = msg.result
+		if m.config.Verify {
+			m.anim.CurrentTask = "Installing dependencies and verifying the project"
+			return m, tea.Batch(m.verifyProject(), m.spinner.Tick)
+		}
+		// Stay on the Finished screen so the configuration can be saved as a preset
 		m.currentState = StateFinished
-		return m, tea.Quit
+		return m, nil
+
+	case verificationCompleteMsg:
+		m.verification, m.verifyErr = msg.results, msg.err
+		m.currentState = StateFinished
+		return m, nil
 
 	case errorMsg:
+		m.staged = nil // Commit and Stage clean up after themselves
 		m.err = msg.err
 		m.currentState = StateCracked
 		return m, tea.Quit
 	}
 
+	// Handle the "Save preset" naming dialog
+	if m.currentState == StateSavePreset {
+		form, cmd := m.saveForm.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.saveForm = f
+		}
+
+		switch m.saveForm.State {
+		case huh.StateCompleted:
+			m.savePreset()
+			m.currentState = m.saveReturnState
+			return m, nil
+
+		case huh.StateAborted:
+			m.currentState = m.saveReturnState
+			return m, nil
+		}
+
+		return m, cmd
+	}
+
+	// Handle the "Load preset" step before the main form
+	if m.currentState == StateBlueprint && m.presetForm != nil {
+		form, cmd := m.presetForm.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.presetForm = f
+		}
+
+		switch m.presetForm.State {
+		case huh.StateCompleted:
+			if err := m.applyPreset(); err != nil {
+				m.presetState.Message = fmt.Sprintf("Could not load preset: %v", err)
+			}
+			// Rebuild the main form so its selects pick up the loaded values
+			m.presetForm = nil
+			m.form = m.createForm()
+			return m, m.form.Init()
+
+		case huh.StateAborted:
+			return m, tea.Quit
+		}
+
+		return m, cmd
+	}
+
 	// Handle form state
 	if m.currentState == StateBlueprint {
 		// Update the form with the message
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"frontforge/internal/generators"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// verificationCompleteMsg carries the results of the -verify steps, or why
+// they couldn't run
+type verificationCompleteMsg struct {
+	results []generators.ValidationResult
+	err     error
+}
+
+// verifyProject installs the dependencies, then runs the project's
+// typecheck, lint, test and build scripts
+func (m Model) verifyProject() tea.Cmd {
+	config, timeout := m.config, m.verifyTimeout
+	return func() tea.Msg {
+		// The screen is redrawn while this runs, so install output is dropped
+		if err := generators.RunInstallTo(config.ProjectPath, config, io.Discard); err != nil {
+			return verificationCompleteMsg{err: err}
+		}
+		results := generators.Verify(context.Background(), config.ProjectPath, config, timeout, nil)
+		return verificationCompleteMsg{results: results}
+	}
+}
+
+// renderVerification lists the -verify steps on the Finished screen, with
+// the output of those that failed
+func (m Model) renderVerification(b *strings.Builder, divider string) {
+	if !m.config.Verify {
+		return
+	}
+	b.WriteString(sectionHeaderStyle.Render("VERIFICATION") + "\n\n")
+
+	if m.verifyErr != nil {
+		b.WriteString("  " + forgeErrorStyle.Render("✗ Could not verify: "+m.verifyErr.Error()) + "\n")
+		b.WriteString("\n" + divider + "\n\n")
+		return
+	}
+	if len(m.verification) == 0 {
+		b.WriteString(forgeMutedStyle.Render("  No typecheck, lint, test or build scripts to run") + "\n")
+	}
+
+	outputStyle := lipgloss.NewStyle().Foreground(colorDraftPencil)
+	for _, r := range m.verification {
+		if r.Passed {
+			b.WriteString(fmt.Sprintf("  %s %s\n",
+				lipgloss.NewStyle().Foreground(colorTemperedGreen).Bold(true).Render("✓"),
+				inputValueStyle.Render(r.Check)))
+			continue
+		}
+		b.WriteString(fmt.Sprintf("  %s %s %s\n",
+			lipgloss.NewStyle().Foreground(colorCrackedRed).Bold(true).Render("✗"),
+			inputValueStyle.Render(r.Check),
+			forgeMutedStyle.Render(r.Message)))
+		for _, line := range lastLines(r.Output, 10) {
+			b.WriteString("    " + outputStyle.Render(line) + "\n")
+		}
+	}
+	b.WriteString("\n" + divider + "\n\n")
+}
+
+// lastLines returns up to n trailing lines of s
+func lastLines(s string, n int) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// SetVerify installs the dependencies and runs the project's typecheck, lint,
+// test and build scripts after generation. A positive timeout replaces each
+// step's default limit.
+func (m *Model) SetVerify(timeout time.Duration) {
+	m.config.Verify = true
+	m.verifyTimeout = timeout
+}
+
+// SetVerification shows verification results on the Finished screen (for
+// testing)
+func (m *Model) SetVerification(results []generators.ValidationResult, err error) {
+	m.config.Verify = true
+	m.verification, m.verifyErr = results, err
+	m.currentState = StateFinished
+}
@@ -34,6 +34,10 @@ func (m Model) View() string {
 		return m.viewSuccess()
 	case StateCracked: // StateError is alias to StateCracked
 		return m.viewError()
+	case StateConflicts:
+		return m.viewConflicts()
+	case StateSavePreset:
+		return m.viewSavePreset()
 	default:
 		return ""
 	}
@@ -166,8 +170,45 @@ func (m Model) viewWelcome() string {
 }
 
 func (m Model) viewForm() string {
+	// Saved presets are offered before the main form
+	if m.presetForm != nil {
+		return m.presetForm.View()
+	}
+
 	// Just show the form - Huh handles everything
-	return m.form.View()
+	view := m.form.View()
+	if m.presetState.Message != "" {
+		view = m.renderPresetMessage() + "\n\n" + view
+	}
+	return view
+}
+
+// viewSavePreset renders the dialog for naming a preset
+func (m Model) viewSavePreset() string {
+	var b strings.Builder
+
+	b.WriteString(sectionHeaderStyle.Render("SAVE AS PRESET") + "\n\n")
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colorDraftPencil)
+	b.WriteString(hintStyle.Render("Reuse this stack later with -preset <name> or the \"Load preset\" step") + "\n\n")
+
+	b.WriteString(m.saveForm.View() + "\n\n")
+
+	b.WriteString(lipgloss.NewStyle().
+		Foreground(colorAshGray).
+		Render("[Enter] Save  •  [Esc] Cancel") + "\n")
+
+	return lipgloss.NewStyle().
+		Padding(1, 0).
+		Render(b.String())
+}
+
+// renderPresetMessage renders feedback from the last preset load or save
+func (m Model) renderPresetMessage() string {
+	return lipgloss.NewStyle().
+		Foreground(colorSteelBlue).
+		Render(m.presetState.Message)
 }
 
 // viewReview renders the configuration review screen before forging
@@ -226,6 +267,10 @@ func (m Model) viewReview() string {
 		Foreground(colorSteelBlue).
 		Render("[←] Back to edit")
 
+	saveAction := lipgloss.NewStyle().
+		Foreground(colorSteelBlue).
+		Render("[S] Save as preset")
+
 	cancelAction := lipgloss.NewStyle().
 		Foreground(colorAshGray).
 		Render("[Esc] Cancel")
@@ -238,15 +283,23 @@ func (m Model) viewReview() string {
 	b.WriteString(primaryCentered + "\n\n")
 
 	// Show secondary actions side by side
-	secondaryActions := backAction + "  " + lipgloss.NewStyle().
+	bullet := "  " + lipgloss.NewStyle().
 		Foreground(colorAnvilGray).
-		Render("•") + "  " + cancelAction
+		Render("•") + "  "
+	secondaryActions := backAction + bullet + saveAction + bullet + cancelAction
 	secondaryCentered := lipgloss.NewStyle().
 		Width(contentWidth).
 		Align(lipgloss.Center).
 		Render(secondaryActions)
 	b.WriteString(secondaryCentered + "\n")
 
+	if m.presetState.Message != "" {
+		b.WriteString("\n" + lipgloss.NewStyle().
+			Width(contentWidth).
+			Align(lipgloss.Center).
+			Render(m.renderPresetMessage()) + "\n")
+	}
+
 	// Return without border
 	return lipgloss.NewStyle().
 		Padding(1, 0).
@@ -341,6 +394,18 @@ func (m Model) viewSuccess() string {
 
 	b.WriteString(divider + "\n\n")
 
+	// Existing files, and what the conflict policy did with each
+	if m.result != nil && len(m.result.Conflicts) > 0 {
+		b.WriteString(sectionHeaderStyle.Render("EXISTING FILES") + "\n\n")
+		for _, c := range m.result.Conflicts {
+			b.WriteString(forgeMutedStyle.Render(fmt.Sprintf("  %-10s ", c.Action)) + inputValueStyle.Render(c.Path) + "\n")
+		}
+		b.WriteString("\n" + divider + "\n\n")
+	}
+
+	// Results of the project's own scripts, with -verify
+	m.renderVerification(&b, divider)
+
 	// Next steps section with clear instructions
 	b.WriteString(sectionHeaderStyle.Render("NEXT STEPS") + "\n\n")
 
@@ -419,7 +484,18 @@ func (m Model) viewSuccess() string {
 		Foreground(colorSilverSheen).
 		Align(lipgloss.Center).
 		Width(contentWidth)
-	b.WriteString(closingStyle.Render("Happy coding!") + "\n")
+	b.WriteString(closingStyle.Render("Happy coding!") + "\n\n")
+
+	if m.presetState.Message != "" {
+		b.WriteString(closingStyle.Render(m.renderPresetMessage()) + "\n\n")
+	}
+
+	// Exit and preset hints
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colorAshGray).
+		Align(lipgloss.Center).
+		Width(contentWidth)
+	b.WriteString(hintStyle.Render("[S] Save as preset  •  [Enter] Exit") + "\n")
 
 	// Return without border
 	return lipgloss.NewStyle().
@@ -1,9 +1,16 @@
 package tui_test
 
 import (
+	"errors"
+	"frontforge/internal/generators"
 	"frontforge/internal/models"
+	"frontforge/internal/presets"
+	"frontforge/internal/testutil"
 	"frontforge/internal/tui"
 	"frontforge/internal/tui/state"
+	"io"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -265,6 +272,43 @@ func TestConfigMapping(t *testing.T) {
 	}
 }
 
+func TestConfigMappingResolvesUnsupportedAnswers(t *testing.T) {
+	// Answers left over from another framework fall back to the chosen
+	// framework's defaults instead of producing an invalid config
+	fs := state.NewFormState()
+	fs.Framework = models.FrameworkSvelteKit
+	fs.StateManagement = models.StateNone
+	fs.Testing = models.TestingPlaywright
+
+	m := tui.NewModel()
+	m.SetFormState(fs)
+	m.ApplyFormDataToConfig()
+	config := m.GetConfig()
+
+	testutil.AssertEqual(t, config.Routing, models.RoutingSvelteKit)
+	testutil.AssertEqual(t, config.StateManagement, models.StateNone) // user's answer is kept
+	testutil.AssertEqual(t, config.Testing, models.TestingPlaywright)
+	testutil.AssertEqual(t, config.UILibrary, models.UILibraryNone)
+	testutil.AssertEqual(t, config.FormManagement, models.FormNone)
+	testutil.AssertEqual(t, config.Animation, models.AnimationNone)
+}
+
+func TestConfigMappingFixesRuleViolations(t *testing.T) {
+	// Shadcn/ui left over from a Tailwind answer is dropped with the rule's fix
+	fs := state.NewFormState()
+	fs.Framework = models.FrameworkReact
+	fs.Styling = models.StylingSass
+	fs.UILibrary = models.UILibraryShadcn
+
+	m := tui.NewModel()
+	m.SetFormState(fs)
+	m.ApplyFormDataToConfig()
+	config := m.GetConfig()
+
+	testutil.AssertEqual(t, config.UILibrary, models.UILibraryNone)
+	testutil.AssertEqual(t, len(models.CheckCompatibility(config)), 0)
+}
+
 func TestStateAliases(t *testing.T) {
 	// Verify legacy state aliases work correctly
 	if tui.StateForm != tui.StateBlueprint {
@@ -325,3 +369,141 @@ func TestAnimationState(t *testing.T) {
 		t.Errorf("Expected TickCount 0 after reset, got %d", animState.TickCount)
 	}
 }
+
+func TestPresetSaveAndLoad(t *testing.T) {
+	store := presets.NewStore(testutil.TempDir(t))
+
+	m := tui.NewModel()
+	m.SetPresetStore(store)
+
+	fs := m.GetFormState()
+	fs.ProjectName = "preset-app"
+	fs.Framework = models.FrameworkSvelte
+	fs.Styling = models.StylingSass
+	m.SetFormState(fs)
+
+	msg := m.SavePreset("svelte-sass")
+	if !strings.Contains(msg, "Saved") {
+		t.Fatalf("expected save confirmation, got %q", msg)
+	}
+
+	// A fresh model sees the preset and loads it into custom mode
+	loaded := tui.NewModel()
+	loaded.SetPresetStore(store)
+	testutil.AssertNoError(t, loaded.SetPresetSelection("svelte-sass"))
+
+	got := loaded.GetFormState()
+	testutil.AssertEqual(t, got.Framework, models.FrameworkSvelte)
+	testutil.AssertEqual(t, got.Styling, models.StylingSass)
+	testutil.AssertEqual(t, got.SetupMode, string(models.SetupModeCustom))
+	testutil.AssertEqual(t, got.ProjectName, "my-app") // not stored in presets
+}
+
+func TestSavePresetKeyFromReview(t *testing.T) {
+	m := tui.NewModel()
+	m.SetPresetStore(presets.NewStore(testutil.TempDir(t)))
+	m.SetCurrentState(tui.StateReview)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = updated.(tui.Model)
+	testutil.AssertEqual(t, m.GetCurrentState(), tui.StateSavePreset)
+
+	// Typing "q" in the name field must not quit
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if cmd != nil {
+		if _, quit := cmd().(tea.QuitMsg); quit {
+			t.Error("q should not quit while naming a preset")
+		}
+	}
+
+	// Esc returns to review without saving
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(tui.Model)
+	testutil.AssertEqual(t, m.GetCurrentState(), tui.StateReview)
+}
+
+func TestConflictsScreen(t *testing.T) {
+	dir := testutil.TempDir(t)
+	testutil.CreateTempFile(t, dir, "README.md", "mine")
+	testutil.CreateTempFile(t, dir, ".gitignore", "secrets/\n")
+
+	config := models.QuickPreset()
+	config.ProjectName = "app"
+	config.ProjectPath = dir
+	config.OnConflict = models.OnConflictPrompt
+	staged, err := generators.Stage(config, io.Discard)
+	testutil.AssertNoError(t, err)
+
+	m := tui.NewModelWithPath(dir, ".")
+	m.SetStaged(staged)
+	testutil.AssertEqual(t, m.GetCurrentState(), tui.StateConflicts)
+
+	// Files are listed with their default action and the selected file's diff
+	view := m.View()
+	for _, want := range []string{"[merge]", ".gitignore", "[skip]", "README.md", "-secrets/"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+
+	key := func(k string) tea.Cmd {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		updated, cmd := m.Update(msg)
+		m = updated.(tui.Model)
+		return cmd
+	}
+
+	key("down")
+	if !strings.Contains(m.View(), "-mine") {
+		t.Error("moving down should show the README.md diff")
+	}
+	key("b")
+	if cmd := key("q"); cmd != nil {
+		t.Error("q should not quit while reviewing conflicts")
+	}
+	testutil.AssertEqual(t, m.GetCurrentState(), tui.StateConflicts)
+
+	// Enter commits in the background, then shows the Finished screen
+	batch, ok := key("enter")().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("expected enter to start the commit")
+	}
+	for _, cmd := range batch {
+		if msg := cmd(); msg != nil {
+			updated, _ := m.Update(msg)
+			m = updated.(tui.Model)
+		}
+	}
+	testutil.AssertEqual(t, m.GetCurrentState(), tui.StateFinished)
+	testutil.AssertEqual(t, len(m.GetResult().Conflicts), 2)
+	testutil.AssertFileContains(t, filepath.Join(dir, "README.md.bak"), "mine")
+	testutil.AssertFileContains(t, filepath.Join(dir, ".gitignore"), "secrets/\n\n")
+	testutil.AssertFileContains(t, filepath.Join(dir, ".gitignore"), "node_modules")
+}
+
+func TestFinishedScreenShowsVerification(t *testing.T) {
+	m := tui.NewModel()
+	m.SetVerification([]generators.ValidationResult{
+		{ID: generators.CheckTypecheck, Check: "Type check", Passed: true},
+		{ID: generators.CheckBuild, Check: "Build", Message: "npm run build failed: exit status 1", Output: "error: build broke"},
+	}, nil)
+	testutil.AssertEqual(t, m.GetCurrentState(), tui.StateFinished)
+
+	view := m.View()
+	for _, want := range []string{"VERIFICATION", "✓ Type check", "✗ Build", "exit status 1", "error: build broke"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+
+	m.SetVerification(nil, errors.New("install failed"))
+	if !strings.Contains(m.View(), "Could not verify: install failed") {
+		t.Error("view should explain why verification didn't run")
+	}
+}
@@ -0,0 +1,111 @@
+package versions
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultRegistry is used when no .npmrc sets a registry
+const DefaultRegistry = "https://registry.npmjs.org/"
+
+// NpmConfig holds the .npmrc settings that affect registry requests
+type NpmConfig struct {
+	Registry      string            // Default registry URL, with a trailing slash
+	ScopeRegistry map[string]string // "@scope" -> registry URL
+	AuthTokens    map[string]string // "//host/path/" -> token
+}
+
+// LoadNpmConfig reads the user ~/.npmrc and then the .npmrc in each of dirs.
+// Project settings take precedence, as with npm itself. Missing files are
+// ignored.
+func LoadNpmConfig(dirs ...string) *NpmConfig {
+	c := &NpmConfig{
+		Registry:      DefaultRegistry,
+		ScopeRegistry: make(map[string]string),
+		AuthTokens:    make(map[string]string),
+	}
+
+	var files []string
+	if home, err := os.UserHomeDir(); err == nil {
+		files = append(files, filepath.Join(home, ".npmrc"))
+	}
+	for _, dir := range dirs {
+		if dir != "" {
+			files = append(files, filepath.Join(dir, ".npmrc"))
+		}
+	}
+	// Later files override earlier ones
+	for _, path := range files {
+		c.readFile(path)
+	}
+	return c
+}
+
+// readFile merges the settings from one .npmrc file
+func (c *NpmConfig) readFile(path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = os.Expand(strings.Trim(strings.TrimSpace(value), `"'`), os.Getenv)
+
+		switch {
+		case key == "registry":
+			c.Registry = withSlash(value)
+		case strings.HasPrefix(key, "@") && strings.HasSuffix(key, ":registry"):
+			c.ScopeRegistry[strings.TrimSuffix(key, ":registry")] = withSlash(value)
+		case strings.HasPrefix(key, "//") && strings.HasSuffix(key, ":_authToken"):
+			c.AuthTokens[withSlash(strings.TrimSuffix(key, ":_authToken"))] = value
+		}
+	}
+}
+
+// RegistryFor returns the registry that serves a package, honoring scoped registries
+func (c *NpmConfig) RegistryFor(name string) string {
+	if strings.HasPrefix(name, "@") {
+		scope, _, _ := strings.Cut(name, "/")
+		if url, ok := c.ScopeRegistry[scope]; ok {
+			return url
+		}
+	}
+	return c.Registry
+}
+
+// TokenFor returns the auth token configured for a registry URL. Tokens are
+// keyed by the URL without its scheme; the longest matching key wins.
+func (c *NpmConfig) TokenFor(registry string) string {
+	_, rest, ok := strings.Cut(registry, ":")
+	if !ok {
+		return ""
+	}
+	best, token := "", ""
+	for key, value := range c.AuthTokens {
+		if strings.HasPrefix(withSlash(rest), key) && len(key) > len(best) {
+			best, token = key, value
+		}
+	}
+	return token
+}
+
+// withSlash ensures a URL ends with a slash
+func withSlash(url string) string {
+	if strings.HasSuffix(url, "/") {
+		return url
+	}
+	return url + "/"
+}
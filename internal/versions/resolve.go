@@ -0,0 +1,231 @@
+package versions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long a registry answer is reused before refetching
+const DefaultCacheTTL = 24 * time.Hour
+
+// Sources a resolved version can come from
+const (
+	SourceRegistry = "registry" // Fetched from the registry just now
+	SourceCache    = "cache"    // Fetched earlier and still within the TTL
+	SourceCatalog  = "catalog"  // Embedded catalog fallback
+)
+
+// Resolution records how one package version was resolved
+type Resolution struct {
+	Name    string
+	Version string
+	Source  string
+	Err     error // Why the catalog was used instead, if it was
+}
+
+// Resolver looks up the latest published version of packages from the npm
+// registry's packument endpoint, caching answers on disk. Failures fall back
+// to the catalog version.
+type Resolver struct {
+	Npm      *NpmConfig
+	CacheDir string        // "" disables the disk cache
+	TTL      time.Duration // Cache lifetime
+	Client   *http.Client
+	Now      func() time.Time
+
+	mu       sync.Mutex
+	resolved map[string]Resolution
+	offline  error // Set after a network error so later lookups don't wait again
+}
+
+// NewResolver creates a resolver using the .npmrc files in dirs (see
+// LoadNpmConfig) and the user cache directory
+func NewResolver(dirs ...string) *Resolver {
+	cacheDir := ""
+	if base, err := os.UserCacheDir(); err == nil {
+		cacheDir = filepath.Join(base, "frontforge", "registry")
+	}
+	return &Resolver{
+		Npm:      LoadNpmConfig(dirs...),
+		CacheDir: cacheDir,
+		TTL:      DefaultCacheTTL,
+		Client:   &http.Client{Timeout: 10 * time.Second},
+		Now:      time.Now,
+	}
+}
+
+// Resolve returns a caret range for the latest version of name, or fallback
+// if it can't be determined
+func (r *Resolver) Resolve(name, fallback string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.resolved == nil {
+		r.resolved = make(map[string]Resolution)
+	}
+	if res, ok := r.resolved[name]; ok {
+		return res.Version
+	}
+
+	res := Resolution{Name: name, Version: fallback, Source: SourceCatalog}
+	if latest, source, err := r.latest(name); err != nil {
+		res.Err = err
+	} else {
+		res.Version = "^" + latest
+		res.Source = source
+	}
+	r.resolved[name] = res
+	return res.Version
+}
+
+// Resolutions returns every package resolved so far, sorted by name
+func (r *Resolver) Resolutions() []Resolution {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]Resolution, 0, len(r.resolved))
+	for _, res := range r.resolved {
+		list = append(list, res)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// cacheEntry is the on-disk form of a registry answer
+type cacheEntry struct {
+	Latest  string    `json:"latest"`
+	Fetched time.Time `json:"fetched"`
+}
+
+// latest returns the latest version of name from the cache or the registry
+func (r *Resolver) latest(name string) (version, source string, err error) {
+	registry := r.Npm.RegistryFor(name)
+	cachePath := r.cachePath(registry, name)
+
+	if entry, ok := r.readCache(cachePath); ok && r.Now().Sub(entry.Fetched) < r.TTL {
+		return entry.Latest, SourceCache, nil
+	}
+	if r.offline != nil {
+		return "", "", r.offline
+	}
+
+	version, err = r.fetch(registry, name)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) {
+			r.offline = err
+		}
+		return "", "", err
+	}
+	r.writeCache(cachePath, cacheEntry{Latest: version, Fetched: r.Now()})
+	return version, SourceRegistry, nil
+}
+
+// fetch requests the abbreviated packument and returns its "latest" dist-tag
+func (r *Resolver) fetch(registry, name string) (string, error) {
+	// Scoped names escape the slash: @scope%2Fname
+	req, err := http.NewRequest(http.MethodGet, registry+url.PathEscape(name), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.npm.install-v1+json")
+	if token := r.Npm.TokenFor(registry); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("registry request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry returned %s for %s", resp.Status, name)
+	}
+
+	var packument struct {
+		DistTags map[string]string `json:"dist-tags"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&packument); err != nil {
+		return "", fmt.Errorf("invalid packument for %s: %w", name, err)
+	}
+	latest := packument.DistTags["latest"]
+	if latest == "" {
+		return "", fmt.Errorf("packument for %s has no latest tag", name)
+	}
+	return latest, nil
+}
+
+// cachePath returns the cache file for a package, separated per registry
+func (r *Resolver) cachePath(registry, name string) string {
+	if r.CacheDir == "" {
+		return ""
+	}
+	host := "registry"
+	if u, err := url.Parse(registry); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	return filepath.Join(r.CacheDir, url.PathEscape(host), url.PathEscape(name)+".json")
+}
+
+// readCache loads a cache entry; a missing or corrupt file is a miss
+func (r *Resolver) readCache(path string) (cacheEntry, bool) {
+	var entry cacheEntry
+	if path == "" {
+		return entry, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, &entry) != nil || entry.Latest == "" {
+		return entry, false
+	}
+	return entry, true
+}
+
+// writeCache stores a cache entry. Errors are ignored: the cache is an optimization.
+func (r *Resolver) writeCache(path string, entry cacheEntry) {
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
+
+var (
+	activeMu sync.Mutex
+	active   *Resolver
+)
+
+// UseResolver makes Version resolve packages through r until the returned
+// function is called
+func UseResolver(r *Resolver) (restore func()) {
+	activeMu.Lock()
+	previous := active
+	active = r
+	activeMu.Unlock()
+	return func() {
+		activeMu.Lock()
+		active = previous
+		activeMu.Unlock()
+	}
+}
+
+// activeResolver returns the resolver installed by UseResolver, if any
+func activeResolver() *Resolver {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	return active
+}
@@ -0,0 +1,158 @@
+// Package versions provides the embedded catalog of npm package versions.
+//
+// Every package version FrontForge writes to a package.json comes from
+// catalog.json, so bumping a dependency is a one-line change. The same
+// catalog renders the tables in PACKAGE_VERSIONS.md ("frontforge versions
+// -update PACKAGE_VERSIONS.md").
+package versions
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed catalog.json
+var catalogJSON []byte
+
+// Markers delimiting the generated tables in PACKAGE_VERSIONS.md
+const (
+	BeginMarker = "<!-- versions:begin -->"
+	EndMarker   = "<!-- versions:end -->"
+)
+
+// Entry is a single package in the catalog
+type Entry struct {
+	Name    string `json:"name"`
+	Version string `json:"version"` // npm semver range, e.g. "^19.2.4"
+	Notes   string `json:"notes"`
+}
+
+// Section groups related packages, e.g. "Testing"
+type Section struct {
+	Name     string  `json:"name"`
+	Packages []Entry `json:"packages"`
+}
+
+// Catalog is the parsed catalog.json
+type Catalog struct {
+	Updated  string    `json:"updated"` // Date the versions were last checked (YYYY-MM-DD)
+	Sections []Section `json:"sections"`
+
+	byName map[string]string
+}
+
+var (
+	loadOnce sync.Once
+	loaded   *Catalog
+	loadErr  error
+)
+
+// Load returns the embedded catalog, parsing it on first use
+func Load() (*Catalog, error) {
+	loadOnce.Do(func() {
+		loaded, loadErr = Parse(catalogJSON)
+	})
+	return loaded, loadErr
+}
+
+// Parse decodes a catalog document, rejecting duplicate or incomplete entries
+func Parse(data []byte) (*Catalog, error) {
+	var c Catalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid version catalog: %w", err)
+	}
+	c.byName = make(map[string]string)
+	for _, s := range c.Sections {
+		for _, e := range s.Packages {
+			if e.Name == "" || e.Version == "" {
+				return nil, fmt.Errorf("invalid version catalog: entry in %q is missing a name or version", s.Name)
+			}
+			if _, dup := c.byName[e.Name]; dup {
+				return nil, fmt.Errorf("invalid version catalog: %s is listed twice", e.Name)
+			}
+			c.byName[e.Name] = e.Version
+		}
+	}
+	return &c, nil
+}
+
+// Lookup returns the version range for a package
+func (c *Catalog) Lookup(name string) (string, bool) {
+	v, ok := c.byName[name]
+	return v, ok
+}
+
+// Version returns the catalog version range for a package, or the latest
+// published version while a resolver is installed (see UseResolver). It
+// panics if the package is missing: every package FrontForge installs must be
+// in the catalog, which the versions tests enforce.
+func Version(name string) string {
+	c, err := Load()
+	if err != nil {
+		panic(err)
+	}
+	v, ok := c.Lookup(name)
+	if !ok {
+		panic(fmt.Sprintf("versions: %s is not in the catalog", name))
+	}
+	if r := activeResolver(); r != nil {
+		return r.Resolve(name, v)
+	}
+	return v
+}
+
+// Markdown renders the catalog as the tables of PACKAGE_VERSIONS.md
+func (c *Catalog) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Versions are read from the embedded catalog (`internal/versions/catalog.json`), last checked %s.\n", c.updatedText())
+	for _, s := range c.Sections {
+		fmt.Fprintf(&b, "\n## %s\n\n", s.Name)
+		b.WriteString("| Package | Version | Notes |\n")
+		b.WriteString("|---------|---------|-------|\n")
+		for _, e := range s.Packages {
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", e.Name, e.Version, e.Notes)
+		}
+	}
+	return b.String()
+}
+
+// UpdateFile replaces the text between BeginMarker and EndMarker in path
+// with the rendered tables
+func (c *Catalog) UpdateFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	updated, err := c.ReplaceTables(string(data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReplaceTables returns doc with the text between the markers regenerated
+func (c *Catalog) ReplaceTables(doc string) (string, error) {
+	begin := strings.Index(doc, BeginMarker)
+	end := strings.Index(doc, EndMarker)
+	if begin < 0 || end < begin {
+		return "", fmt.Errorf("missing %s ... %s markers", BeginMarker, EndMarker)
+	}
+	return doc[:begin+len(BeginMarker)] + "\n" + c.Markdown() + doc[end:], nil
+}
+
+// updatedText formats the catalog date for display, e.g. "February 25, 2026"
+func (c *Catalog) updatedText() string {
+	t, err := time.Parse("2006-01-02", c.Updated)
+	if err != nil {
+		return c.Updated
+	}
+	return t.Format("January 2, 2006")
+}
@@ -0,0 +1,161 @@
+package versions_test
+
+import (
+	"fmt"
+	"frontforge/internal/versions"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// registryStub serves packuments for the given latest versions and counts requests
+func registryStub(t *testing.T, latest map[string]string, token string) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		// Scoped names must arrive as a single path segment
+		escaped := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		name, err := url.PathUnescape(escaped)
+		if err != nil || strings.Contains(escaped, "/") {
+			http.Error(w, "bad package path "+escaped, http.StatusBadRequest)
+			return
+		}
+		version, ok := latest[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"name":%q,"dist-tags":{"latest":%q}}`, name, version)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// newResolver returns a resolver for registry with an isolated cache directory
+func newResolver(t *testing.T, registry string) *versions.Resolver {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir()) // Ignore the developer's ~/.npmrc
+	r := versions.NewResolver()
+	r.Npm.Registry = registry + "/"
+	r.CacheDir = t.TempDir()
+	return r
+}
+
+func TestResolverFetchesLatest(t *testing.T) {
+	server, _ := registryStub(t, map[string]string{"vite": "9.1.0", "@types/react": "20.0.1"}, "")
+	r := newResolver(t, server.URL)
+
+	if got := r.Resolve("vite", "^7.0.0"); got != "^9.1.0" {
+		t.Errorf("Resolve(vite) = %q, want ^9.1.0", got)
+	}
+	if got := r.Resolve("@types/react", "^19.0.0"); got != "^20.0.1" {
+		t.Errorf("Resolve(@types/react) = %q, want ^20.0.1", got)
+	}
+	for _, res := range r.Resolutions() {
+		if res.Source != versions.SourceRegistry {
+			t.Errorf("%s: source = %s, want registry", res.Name, res.Source)
+		}
+	}
+}
+
+func TestResolverUsesNpmrc(t *testing.T) {
+	server, requests := registryStub(t, map[string]string{"@acme/ui": "2.0.0"}, "s3cret")
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("ACME_TOKEN", "s3cret")
+	project := t.TempDir()
+	npmrc := fmt.Sprintf("@acme:registry=%s\n%s/:_authToken=${ACME_TOKEN}\n", server.URL, server.URL[len("http:"):])
+	if err := os.WriteFile(filepath.Join(project, ".npmrc"), []byte(npmrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := versions.NewResolver(project)
+	r.CacheDir = ""
+	if got := r.Npm.RegistryFor("react"); got != versions.DefaultRegistry {
+		t.Errorf("unscoped packages should use the default registry, got %s", got)
+	}
+	if got := r.Resolve("@acme/ui", "^1.0.0"); got != "^2.0.0" {
+		t.Errorf("Resolve(@acme/ui) = %q, want ^2.0.0 (requests: %d)", got, atomic.LoadInt32(requests))
+	}
+}
+
+func TestResolverCache(t *testing.T) {
+	server, requests := registryStub(t, map[string]string{"vite": "9.1.0"}, "")
+	r := newResolver(t, server.URL)
+	r.Resolve("vite", "^7.0.0")
+
+	// A new resolver within the TTL reads the cache
+	now := time.Now()
+	cached := versions.NewResolver()
+	cached.Npm, cached.CacheDir = r.Npm, r.CacheDir
+	cached.Now = func() time.Time { return now.Add(time.Hour) }
+	if got := cached.Resolve("vite", "^7.0.0"); got != "^9.1.0" {
+		t.Errorf("cached Resolve = %q", got)
+	}
+	if n := atomic.LoadInt32(requests); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+	if res := cached.Resolutions(); res[0].Source != versions.SourceCache {
+		t.Errorf("source = %s, want cache", res[0].Source)
+	}
+
+	// After the TTL the registry is asked again
+	expired := versions.NewResolver()
+	expired.Npm, expired.CacheDir = r.Npm, r.CacheDir
+	expired.Now = func() time.Time { return now.Add(versions.DefaultCacheTTL + time.Hour) }
+	expired.Resolve("vite", "^7.0.0")
+	if n := atomic.LoadInt32(requests); n != 2 {
+		t.Errorf("expected a refetch after the TTL, got %d requests", n)
+	}
+}
+
+func TestResolverFallsBackToCatalog(t *testing.T) {
+	server, _ := registryStub(t, map[string]string{}, "")
+	r := newResolver(t, server.URL)
+
+	// Unknown packages keep the catalog version
+	if got := r.Resolve("vite", "^7.0.0"); got != "^7.0.0" {
+		t.Errorf("Resolve after 404 = %q, want the fallback", got)
+	}
+
+	// So does an unreachable registry
+	server.Close()
+	offline := newResolver(t, server.URL)
+	for _, name := range []string{"react", "vue"} {
+		if got := offline.Resolve(name, "^1.0.0"); got != "^1.0.0" {
+			t.Errorf("Resolve(%s) offline = %q, want the fallback", name, got)
+		}
+	}
+	for _, res := range offline.Resolutions() {
+		if res.Source != versions.SourceCatalog || res.Err == nil {
+			t.Errorf("%s: expected a catalog fallback with an error, got %+v", res.Name, res)
+		}
+	}
+}
+
+func TestVersionUsesActiveResolver(t *testing.T) {
+	server, _ := registryStub(t, map[string]string{"vite": "9.1.0"}, "")
+	r := newResolver(t, server.URL)
+
+	catalog := versions.Version("vite")
+	restore := versions.UseResolver(r)
+	if got := versions.Version("vite"); got != "^9.1.0" {
+		t.Errorf("Version(vite) with resolver = %q", got)
+	}
+	restore()
+	if got := versions.Version("vite"); got != catalog {
+		t.Errorf("Version(vite) after restore = %q, want %q", got, catalog)
+	}
+}
@@ -0,0 +1,119 @@
+package versions_test
+
+import (
+	"frontforge/internal/models"
+	"frontforge/internal/versions"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// versionLiteral matches string literals that look like npm versions
+var versionLiteral = regexp.MustCompile(`^[\^~]?\d+\.\d+\.\d+`)
+
+func TestCatalogCoversRegistry(t *testing.T) {
+	catalog, err := versions.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	for _, g := range models.Registry {
+		for _, o := range g.Options {
+			for _, p := range o.Packages {
+				if _, ok := catalog.Lookup(p.Name); !ok {
+					t.Errorf("%s %q installs %s, which is not in the catalog", g.Key, o.ID, p.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestNoHardcodedVersions(t *testing.T) {
+	catalog, err := versions.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	root := filepath.Join("..", "..")
+	err = filepath.Walk(filepath.Join(root, "internal"), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == "versions" {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.BasicLit:
+				value, _ := strconv.Unquote(n.Value)
+				// "0.0.0" is the generated project's own version
+				if n.Kind == token.STRING && versionLiteral.MatchString(value) && value != "0.0.0" {
+					t.Errorf("%s: hardcoded version %s; add it to internal/versions/catalog.json", path, n.Value)
+				}
+			case *ast.CallExpr:
+				// versions.Version("name") must name a catalog entry
+				sel, ok := n.Fun.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "Version" || len(n.Args) != 1 {
+					return true
+				}
+				if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "versions" {
+					return true
+				}
+				if lit, ok := n.Args[0].(*ast.BasicLit); ok {
+					name, _ := strconv.Unquote(lit.Value)
+					if _, ok := catalog.Lookup(name); !ok {
+						t.Errorf("%s: %s is not in the catalog", path, name)
+					}
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPackageVersionsDocIsCurrent(t *testing.T) {
+	catalog, err := versions.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join("..", "..", "PACKAGE_VERSIONS.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, err := catalog.ReplaceTables(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated != string(data) {
+		t.Error("PACKAGE_VERSIONS.md is out of date; run 'frontforge versions -update PACKAGE_VERSIONS.md'")
+	}
+}
+
+func TestParseRejectsDuplicates(t *testing.T) {
+	_, err := versions.Parse([]byte(`{"sections": [
+		{"name": "A", "packages": [{"name": "vite", "version": "^7.0.0"}]},
+		{"name": "B", "packages": [{"name": "vite", "version": "^7.1.0"}]}
+	]}`))
+	if err == nil || !strings.Contains(err.Error(), "listed twice") {
+		t.Errorf("expected duplicate error, got %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package vfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ArchiveFormat selects the container Archive writes
+type ArchiveFormat string
+
+const (
+	FormatZip   ArchiveFormat = "zip"
+	FormatTarGz ArchiveFormat = "tar.gz"
+)
+
+// ArchiveFormatFor picks the format from a file name's extension
+func ArchiveFormatFor(name string) (ArchiveFormat, bool) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return FormatZip, true
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return FormatTarGz, true
+	}
+	return "", false
+}
+
+// Archive streams everything written under root into a zip or tar.gz, with
+// paths placed under prefix (usually the project name). Each file can only
+// be written once since entries can't be replaced after they are streamed.
+// Call Close to finish the archive.
+type Archive struct {
+	root    string
+	prefix  string
+	written *Memory // What has been streamed, for reads
+	modTime time.Time
+
+	zw *zip.Writer
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+// NewArchive creates an archive filesystem writing to w
+func NewArchive(w io.Writer, format ArchiveFormat, root, prefix string) (*Archive, error) {
+	a := &Archive{
+		root:    filepath.Clean(root),
+		prefix:  strings.Trim(filepath.ToSlash(prefix), "/"),
+		written: NewMemory(),
+		modTime: time.Now(),
+	}
+	switch format {
+	case FormatZip:
+		a.zw = zip.NewWriter(w)
+	case FormatTarGz:
+		a.gz = gzip.NewWriter(w)
+		a.tw = tar.NewWriter(a.gz)
+	default:
+		return nil, fmt.Errorf("unsupported archive format %q", format)
+	}
+	// Everything above root exists as far as generators are concerned
+	if err := a.written.MkdirAll(filepath.Dir(a.root)); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// MkdirAll adds directory entries for path and any missing parents under root
+func (a *Archive) MkdirAll(dir string) error {
+	dir = filepath.Clean(dir)
+	name, err := a.entryName(dir)
+	if err != nil {
+		return err
+	}
+	if isDir(a.written, dir) {
+		return nil
+	}
+	if parent := filepath.Dir(dir); parent != dir && !isDir(a.written, parent) {
+		if err := a.MkdirAll(parent); err != nil {
+			return err
+		}
+	}
+	if name != "" {
+		if err := a.writeHeader(name+"/", 0, fs.ModeDir|0755); err != nil {
+			return err
+		}
+	}
+	return a.written.MkdirAll(dir)
+}
+
+// WriteFile streams a file into the archive
+func (a *Archive) WriteFile(file string, data []byte, perm fs.FileMode) error {
+	file = filepath.Clean(file)
+	name, err := a.entryName(file)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return fmt.Errorf("archive: %s is the archive root", file)
+	}
+	if Exists(a.written, file) {
+		return fmt.Errorf("archive: %s was already written", name)
+	}
+	if err := checkParent(a.written, file); err != nil {
+		return err
+	}
+
+	if a.zw != nil {
+		header := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: a.modTime}
+		header.SetMode(perm)
+		w, err := a.zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	} else {
+		if err := a.writeHeader(name, int64(len(data)), perm); err != nil {
+			return err
+		}
+		if _, err := a.tw.Write(data); err != nil {
+			return err
+		}
+	}
+	return a.written.WriteFile(file, data, perm)
+}
+
+// ReadFile returns a file already written to the archive
+func (a *Archive) ReadFile(file string) ([]byte, error) {
+	return a.written.ReadFile(file)
+}
+
+// Stat describes a path already written to the archive
+func (a *Archive) Stat(file string) (fs.FileInfo, error) {
+	return a.written.Stat(file)
+}
+
+// Close finishes the archive. It does not close the underlying writer.
+func (a *Archive) Close() error {
+	if a.zw != nil {
+		return a.zw.Close()
+	}
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	return a.gz.Close()
+}
+
+// writeHeader adds a directory entry (zip) or any header (tar)
+func (a *Archive) writeHeader(name string, size int64, mode fs.FileMode) error {
+	if a.zw != nil {
+		header := &zip.FileHeader{Name: name, Modified: a.modTime}
+		header.SetMode(mode)
+		_, err := a.zw.CreateHeader(header)
+		return err
+	}
+	header := &tar.Header{
+		Name:    name,
+		Size:    size,
+		Mode:    int64(mode.Perm()),
+		ModTime: a.modTime,
+	}
+	if mode.IsDir() {
+		header.Typeflag = tar.TypeDir
+	} else {
+		header.Typeflag = tar.TypeReg
+	}
+	return a.tw.WriteHeader(header)
+}
+
+// entryName maps an OS path under root to its slash-separated archive name
+func (a *Archive) entryName(file string) (string, error) {
+	rel, err := filepath.Rel(a.root, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("archive: %s is outside %s", file, a.root)
+	}
+	if rel == "." {
+		return a.prefix, nil // "" when the root has no directory entry
+	}
+	return path.Join(a.prefix, filepath.ToSlash(rel)), nil
+}
+
+// isDir reports whether path is a directory in fsys
+func isDir(fsys FS, path string) bool {
+	info, err := fsys.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,103 @@
+package vfs
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sync"
+)
+
+// Entry is a file or directory written to a DryRun
+type Entry struct {
+	Path  string // As passed to WriteFile or MkdirAll
+	IsDir bool
+	Data  []byte
+	Mode  fs.FileMode
+}
+
+// DryRun records writes in memory on top of a read-only base, so generators
+// that read back what they wrote (or files already on disk) behave as they
+// would for real
+type DryRun struct {
+	base    FS
+	overlay *Memory
+
+	mu      sync.Mutex
+	entries []Entry
+	index   map[string]int
+}
+
+// NewDryRun creates a dry-run filesystem reading through to base
+func NewDryRun(base FS) *DryRun {
+	return &DryRun{
+		base:    base,
+		overlay: NewMemory(),
+		index:   make(map[string]int),
+	}
+}
+
+// MkdirAll records a directory
+func (d *DryRun) MkdirAll(path string) error {
+	path = filepath.Clean(path)
+	if info, err := d.overlay.Stat(path); err == nil && info.IsDir() {
+		return nil
+	}
+	if err := d.overlay.MkdirAll(path); err != nil {
+		return err
+	}
+	d.record(Entry{Path: path, IsDir: true, Mode: fs.ModeDir | 0755})
+	return nil
+}
+
+// WriteFile records a file. Writing the same path again replaces the entry.
+func (d *DryRun) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	path = filepath.Clean(path)
+	if err := checkParent(d, path); err != nil {
+		return err
+	}
+	// The parent may only exist in the base
+	if err := d.overlay.MkdirAll(filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := d.overlay.WriteFile(path, data, perm); err != nil {
+		return err
+	}
+	d.record(Entry{Path: path, Data: append([]byte(nil), data...), Mode: perm})
+	return nil
+}
+
+// ReadFile reads from the recorded writes, then the base
+func (d *DryRun) ReadFile(path string) ([]byte, error) {
+	data, err := d.overlay.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return d.base.ReadFile(path)
+	}
+	return data, err
+}
+
+// Stat describes a path from the recorded writes, then the base
+func (d *DryRun) Stat(path string) (fs.FileInfo, error) {
+	info, err := d.overlay.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return d.base.Stat(path)
+	}
+	return info, err
+}
+
+// Entries returns everything written, in the order first written
+func (d *DryRun) Entries() []Entry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]Entry(nil), d.entries...)
+}
+
+func (d *DryRun) record(e Entry) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if i, ok := d.index[e.Path]; ok {
+		d.entries[i] = e
+		return
+	}
+	d.index[e.Path] = len(d.entries)
+	d.entries = append(d.entries, e)
+}
@@ -0,0 +1,128 @@
+package vfs
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Memory is an in-memory filesystem. It behaves like Disk (writes need an
+// existing parent directory), which makes it suitable for testing generators.
+type Memory struct {
+	mu    sync.Mutex
+	files map[string]memFile
+	dirs  map[string]bool
+}
+
+type memFile struct {
+	data []byte
+	mode fs.FileMode
+}
+
+// NewMemory creates an empty in-memory filesystem
+func NewMemory() *Memory {
+	return &Memory{
+		files: make(map[string]memFile),
+		dirs:  make(map[string]bool),
+	}
+}
+
+// MkdirAll creates a directory and its parents
+func (m *Memory) MkdirAll(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.mkdirAll(filepath.Clean(path))
+}
+
+func (m *Memory) mkdirAll(path string) error {
+	for p := path; ; p = filepath.Dir(p) {
+		if _, ok := m.files[p]; ok {
+			return &fs.PathError{Op: "mkdir", Path: p, Err: fs.ErrExist}
+		}
+		m.dirs[p] = true
+		if parent := filepath.Dir(p); parent == p {
+			return nil
+		}
+	}
+}
+
+// WriteFile stores a copy of data
+func (m *Memory) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	path = filepath.Clean(path)
+	if err := checkParent(m, path); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.dirs[path] {
+		return &fs.PathError{Op: "open", Path: path, Err: fs.ErrExist}
+	}
+	m.files[path] = memFile{data: append([]byte(nil), data...), mode: perm}
+	return nil
+}
+
+// ReadFile returns a copy of a file's contents
+func (m *Memory) ReadFile(path string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.files[filepath.Clean(path)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), f.data...), nil
+}
+
+// Remove deletes a file
+func (m *Memory) Remove(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	path = filepath.Clean(path)
+	if _, ok := m.files[path]; !ok {
+		return &fs.PathError{Op: "remove", Path: path, Err: fs.ErrNotExist}
+	}
+	delete(m.files, path)
+	return nil
+}
+
+// Stat describes a file or directory
+func (m *Memory) Stat(path string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	path = filepath.Clean(path)
+	if f, ok := m.files[path]; ok {
+		return fileInfo{name: filepath.Base(path), size: int64(len(f.data)), mode: f.mode}, nil
+	}
+	if m.dirs[path] {
+		return fileInfo{name: filepath.Base(path), mode: fs.ModeDir | 0755}, nil
+	}
+	return nil, &fs.PathError{Op: "stat", Path: path, Err: fs.ErrNotExist}
+}
+
+// Files returns the paths of all files, sorted
+func (m *Memory) Files() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	paths := make([]string, 0, len(m.files))
+	for p := range m.files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// fileInfo implements fs.FileInfo for the non-disk filesystems
+type fileInfo struct {
+	name string
+	size int64
+	mode fs.FileMode
+}
+
+func (fi fileInfo) Name() string       { return fi.name }
+func (fi fileInfo) Size() int64        { return fi.size }
+func (fi fileInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi fileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi fileInfo) Sys() interface{}   { return nil }
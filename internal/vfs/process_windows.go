@@ -0,0 +1,17 @@
+//go:build windows
+// +build windows
+
+package vfs
+
+import "os"
+
+// processAlive reports whether a process with the given ID is running.
+// FindProcess opens a handle, which fails once the process is gone.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = p.Release()
+	return true
+}
@@ -0,0 +1,77 @@
+package vfs
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sync"
+)
+
+// ErrKept is returned by filesystems that leave an existing file in place
+// instead of writing the new content (see Recorder)
+var ErrKept = errors.New("existing file kept")
+
+// Recorder wraps an FS and remembers which files were written through it.
+// Writes the wrapped FS answers with ErrKept succeed without being recorded,
+// so the file isn't taken for a generated one.
+type Recorder struct {
+	FS
+
+	mu    sync.Mutex
+	files []string
+	seen  map[string]bool
+}
+
+// Record wraps fsys in a Recorder
+func Record(fsys FS) *Recorder {
+	return &Recorder{FS: fsys, seen: make(map[string]bool)}
+}
+
+// WriteFile writes through to the wrapped FS and records the path
+func (r *Recorder) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	if err := r.FS.WriteFile(path, data, perm); errors.Is(err, ErrKept) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	path = filepath.Clean(path)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.seen[path] {
+		r.seen[path] = true
+		r.files = append(r.files, path)
+	}
+	return nil
+}
+
+// HostPath forwards to the wrapped FS
+func (r *Recorder) HostPath(path string) (string, bool) {
+	return HostPath(r.FS, path)
+}
+
+// Remove forwards to the wrapped FS and forgets the path if it was recorded
+func (r *Recorder) Remove(path string) error {
+	if err := Remove(r.FS, path); err != nil {
+		return err
+	}
+	path = filepath.Clean(path)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.seen[path] {
+		delete(r.seen, path)
+		for i, f := range r.files {
+			if f == path {
+				r.files = append(r.files[:i], r.files[i+1:]...)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Files returns the written paths in the order first written
+func (r *Recorder) Files() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.files...)
+}
@@ -0,0 +1,626 @@
+package vfs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StateDir holds FrontForge's bookkeeping inside a project: the manifest,
+// and the journal and staging area of an unfinished commit
+const StateDir = ".frontforge"
+
+// Transaction stages writes to a target directory on disk and moves them
+// into place on Commit, so a failure never leaves partial output.
+//
+// A target that doesn't exist yet is staged in a hidden sibling directory
+// and renamed into place in one step. Output for an existing directory is
+// staged under its .frontforge directory and committed file by file. Each
+// move is recorded in a journal first, so an interrupted commit can be rolled
+// back (see Begin).
+//
+// Reads fall through to the target, so generators that update existing
+// files see them. Files removed through the transaction are moved out of
+// the target on Commit, after the staged output.
+type Transaction struct {
+	target  string // Directory the output belongs in
+	staging string // Temporary directory removed after Commit or Rollback
+	root    string // Mirror of target inside staging
+
+	// Recovered is set when Begin rolled back an earlier interrupted commit
+	Recovered bool
+
+	// Resolve decides how Commit handles files that would replace different
+	// content in the target; nil overwrites them
+	Resolve ConflictFunc
+
+	mu      sync.Mutex
+	done    bool
+	removed map[string]bool // Target files to delete on Commit
+	journal *journal        // nil until an existing directory is committed
+}
+
+// journal records the moves made while committing into an existing directory
+type journal struct {
+	path    string
+	Staging string         `json:"staging"`
+	Entries []journalEntry `json:"entries"`
+}
+
+type journalEntry struct {
+	Path   string `json:"path"`             // Target path
+	Backup string `json:"backup,omitempty"` // Where the original was moved, if it existed
+}
+
+// Conflict is a staged file that would replace different content in the target
+type Conflict struct {
+	Path      string // Target path
+	Existing  []byte
+	Generated []byte
+}
+
+// Resolution says how to commit a conflicting file
+type Resolution struct {
+	Keep   bool   // Leave the existing file and drop the generated one
+	Data   []byte // Commit this instead of the generated content, if non-nil
+	Backup bool   // Copy the existing file to <name>.bak before replacing it
+}
+
+// ConflictFunc decides how to commit a conflicting file
+type ConflictFunc func(Conflict) (Resolution, error)
+
+// stagingPrefix starts the names of staging directories, inside StateDir for
+// an existing target
+const stagingPrefix = "staging-"
+
+// ownerFile in a staging directory holds the ID of the process staging there
+const ownerFile = "pid"
+
+// staleAfter is how long a staging directory without an owner is left alone,
+// in case its process hasn't written ownerFile yet
+const staleAfter = time.Minute
+
+// Begin starts a transaction for target. If an earlier commit into target
+// was interrupted, it is rolled back first, and staging directories left by
+// generations that were killed before Commit are removed.
+func Begin(target string) (*Transaction, error) {
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return nil, err
+	}
+	tx := &Transaction{target: target, removed: make(map[string]bool)}
+	sweepStaging(filepath.Dir(target), siblingPrefix(target))
+
+	info, err := os.Stat(target)
+	switch {
+	case err == nil && !info.IsDir():
+		return nil, fmt.Errorf("%s is not a directory", target)
+	case err == nil:
+		if tx.Recovered, err = Recover(target); err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Join(target, StateDir), 0755); err != nil {
+			return nil, err
+		}
+		tx.staging, err = os.MkdirTemp(filepath.Join(target, StateDir), stagingPrefix)
+	case errors.Is(err, fs.ErrNotExist):
+		parent := filepath.Dir(target)
+		if err := os.MkdirAll(parent, 0755); err != nil {
+			return nil, err
+		}
+		tx.staging, err = os.MkdirTemp(parent, siblingPrefix(target))
+	default:
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(tx.staging, ownerFile), []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+		tx.cleanup()
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	// Keep the base name: upstream CLIs name the project after the directory
+	tx.root = filepath.Join(tx.staging, filepath.Base(target))
+	if err := os.Mkdir(tx.root, 0755); err != nil {
+		tx.cleanup()
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	return tx, nil
+}
+
+// HostPath returns where a path under the target is staged on disk
+func (tx *Transaction) HostPath(path string) (string, bool) {
+	staged, err := tx.stagedPath(path)
+	if err != nil {
+		return path, false
+	}
+	return staged, true
+}
+
+// MkdirAll creates a staged directory
+func (tx *Transaction) MkdirAll(path string) error {
+	staged, err := tx.stagedPath(path)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(staged, 0755)
+}
+
+// WriteFile writes a staged file. The parent must exist staged or in the target.
+func (tx *Transaction) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	staged, err := tx.stagedPath(path)
+	if err != nil {
+		return err
+	}
+	if err := checkParent(tx, path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(staged), 0755); err != nil {
+		return err
+	}
+	tx.mu.Lock()
+	delete(tx.removed, filepath.Clean(path))
+	tx.mu.Unlock()
+	return os.WriteFile(staged, data, perm)
+}
+
+// Remove discards a staged file and deletes the target's on Commit
+func (tx *Transaction) Remove(path string) error {
+	staged, err := tx.stagedPath(path)
+	if err != nil {
+		return err
+	}
+	path = filepath.Clean(path)
+	stagedErr := os.Remove(staged)
+	if stagedErr != nil && !errors.Is(stagedErr, fs.ErrNotExist) {
+		return stagedErr
+	}
+	info, err := os.Lstat(path)
+	if err == nil && !info.IsDir() {
+		tx.mu.Lock()
+		tx.removed[path] = true
+		tx.mu.Unlock()
+		return nil
+	}
+	if stagedErr == nil {
+		return nil
+	}
+	return &fs.PathError{Op: "remove", Path: path, Err: fs.ErrNotExist}
+}
+
+// isRemoved reports whether path is a target file deleted through the transaction
+func (tx *Transaction) isRemoved(path string) bool {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	return tx.removed[filepath.Clean(path)]
+}
+
+// ReadFile reads the staged file, or the target's if it wasn't written
+func (tx *Transaction) ReadFile(path string) ([]byte, error) {
+	if tx.isRemoved(path) {
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	if staged, err := tx.stagedPath(path); err == nil {
+		data, err := os.ReadFile(staged)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return data, err
+		}
+	}
+	return os.ReadFile(path)
+}
+
+// Stat describes the staged path, or the target's
+func (tx *Transaction) Stat(path string) (fs.FileInfo, error) {
+	if tx.isRemoved(path) {
+		return nil, &fs.PathError{Op: "stat", Path: path, Err: fs.ErrNotExist}
+	}
+	if staged, err := tx.stagedPath(path); err == nil {
+		info, err := os.Stat(staged)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return info, err
+		}
+	}
+	return os.Stat(path)
+}
+
+// Conflicts lists the staged files that would replace a different file in
+// the target, in path order
+func (tx *Transaction) Conflicts() ([]Conflict, error) {
+	var conflicts []Conflict
+	err := filepath.WalkDir(tx.root, func(staged string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(tx.root, staged)
+		if err != nil {
+			return err
+		}
+		c, err := readConflict(staged, filepath.Join(tx.target, rel))
+		if err != nil || c == nil {
+			return err
+		}
+		conflicts = append(conflicts, *c)
+		return nil
+	})
+	return conflicts, err
+}
+
+// readConflict compares a staged file with its target, returning nil if the
+// target is missing, not a regular file or identical
+func readConflict(staged, target string) (*Conflict, error) {
+	info, err := os.Lstat(target)
+	if err != nil || !info.Mode().IsRegular() {
+		return nil, nil
+	}
+	existing, err := os.ReadFile(target)
+	if err != nil {
+		return nil, err
+	}
+	generated, err := os.ReadFile(staged)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(existing, generated) {
+		return nil, nil
+	}
+	return &Conflict{Path: target, Existing: existing, Generated: generated}, nil
+}
+
+// Commit moves the staged output into the target. On failure everything
+// moved so far is put back.
+func (tx *Transaction) Commit() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.done {
+		return errors.New("transaction already finished")
+	}
+	tx.done = true
+
+	if _, err := os.Stat(tx.target); errors.Is(err, fs.ErrNotExist) {
+		// New directory: a single rename
+		if err := os.Rename(tx.root, tx.target); err != nil {
+			tx.cleanup()
+			return err
+		}
+		tx.cleanup()
+		return nil
+	}
+
+	tx.journal = &journal{
+		path:    filepath.Join(tx.target, StateDir, "journal.json"),
+		Staging: tx.staging,
+	}
+	err := tx.commitDir("")
+	if err == nil {
+		err = tx.commitRemovals()
+	}
+	if err != nil {
+		if rbErr := tx.journal.rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		tx.cleanup()
+		return err
+	}
+	// Removing the journal is the commit point
+	if err := os.Remove(tx.journal.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	tx.cleanup()
+	return nil
+}
+
+// commitDir moves the contents of a staged directory into the target.
+// Directories missing from the target move as a whole.
+func (tx *Transaction) commitDir(rel string) error {
+	entries, err := os.ReadDir(filepath.Join(tx.root, rel))
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		name := filepath.Join(rel, e.Name())
+		staged := filepath.Join(tx.root, name)
+		target := filepath.Join(tx.target, name)
+
+		info, err := os.Lstat(target)
+		if err == nil && e.IsDir() && info.IsDir() {
+			if err := tx.commitDir(name); err != nil {
+				return err
+			}
+			continue
+		}
+		if err == nil && e.Type().IsRegular() && info.Mode().IsRegular() {
+			keep, err := tx.resolve(staged, target)
+			if err != nil {
+				return err
+			}
+			if keep {
+				continue
+			}
+		}
+
+		entry := journalEntry{Path: target}
+		if err == nil {
+			entry.Backup = filepath.Join(tx.staging, "backup", name)
+		}
+		// Write-ahead: the journal lists the move before it happens
+		tx.journal.Entries = append(tx.journal.Entries, entry)
+		if err := tx.journal.save(); err != nil {
+			return err
+		}
+		if entry.Backup != "" {
+			if err := os.MkdirAll(filepath.Dir(entry.Backup), 0755); err != nil {
+				return err
+			}
+			if err := os.Rename(target, entry.Backup); err != nil {
+				return err
+			}
+		}
+		if err := os.Rename(staged, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitRemovals moves the removed files out of the target, keeping them in
+// the staging directory until the commit point so they can be put back
+func (tx *Transaction) commitRemovals() error {
+	paths := make([]string, 0, len(tx.removed))
+	for path := range tx.removed {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if _, err := os.Lstat(path); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		rel, err := filepath.Rel(tx.target, path)
+		if err != nil {
+			return err
+		}
+		entry := journalEntry{Path: path, Backup: filepath.Join(tx.staging, "removed", rel)}
+		tx.journal.Entries = append(tx.journal.Entries, entry)
+		if err := tx.journal.save(); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(entry.Backup), 0755); err != nil {
+			return err
+		}
+		if err := os.Rename(path, entry.Backup); err != nil {
+			return err
+		}
+		// Directories left empty go too; rollback recreates them
+		for dir := filepath.Dir(path); dir != tx.target && strings.HasPrefix(dir, tx.target); dir = filepath.Dir(dir) {
+			if os.Remove(dir) != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// resolve applies Resolve to a staged file that replaces an existing one,
+// reporting whether the existing file stays. Identical files are left alone.
+func (tx *Transaction) resolve(staged, target string) (bool, error) {
+	c, err := readConflict(staged, target)
+	if err != nil {
+		return false, err
+	}
+	if c == nil {
+		return true, nil
+	}
+	if tx.Resolve == nil {
+		return false, nil
+	}
+	res, err := tx.Resolve(*c)
+	if err != nil {
+		return false, err
+	}
+	if res.Keep {
+		return true, nil
+	}
+	if res.Data != nil {
+		info, err := os.Stat(staged)
+		if err != nil {
+			return false, err
+		}
+		if err := os.WriteFile(staged, res.Data, info.Mode().Perm()); err != nil {
+			return false, err
+		}
+	}
+	if res.Backup {
+		backup := tx.backupPath(target)
+		// A new file: rolling back removes it
+		tx.journal.Entries = append(tx.journal.Entries, journalEntry{Path: backup})
+		if err := tx.journal.save(); err != nil {
+			return false, err
+		}
+		if err := copyFile(target, backup); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// backupPath picks an unused <name>.bak, <name>.bak.1, ... next to target
+func (tx *Transaction) backupPath(target string) string {
+	for i := 0; ; i++ {
+		path := target + ".bak"
+		if i > 0 {
+			path = fmt.Sprintf("%s.bak.%d", target, i)
+		}
+		staged, _ := tx.stagedPath(path)
+		if _, err := os.Lstat(path); err != nil {
+			if _, err := os.Lstat(staged); err != nil {
+				return path
+			}
+		}
+	}
+}
+
+// copyFile copies a regular file, keeping its permissions
+func copyFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, info.Mode().Perm())
+}
+
+// Rollback discards the staged output. It does nothing after Commit.
+func (tx *Transaction) Rollback() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.done {
+		return nil
+	}
+	tx.done = true
+	tx.cleanup()
+	return nil
+}
+
+// cleanup removes the staging directory, and .frontforge if it is left empty
+func (tx *Transaction) cleanup() {
+	_ = os.RemoveAll(tx.staging)
+	_ = os.Remove(filepath.Join(tx.target, StateDir))
+}
+
+// stagedPath maps a path under the target into the staging directory
+func (tx *Transaction) stagedPath(path string) (string, error) {
+	rel, err := filepath.Rel(tx.target, filepath.Clean(path))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside %s", path, tx.target)
+	}
+	return filepath.Join(tx.root, rel), nil
+}
+
+// siblingPrefix starts the names of the directories a new target is staged in
+func siblingPrefix(target string) string {
+	return "." + filepath.Base(target) + ".frontforge-"
+}
+
+// sweepStaging removes the staging directories in dir whose names start with
+// prefix and whose process is gone. A process killed before Commit leaves
+// its staging directory behind, and nothing else would clean it up.
+func sweepStaging(dir, prefix string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		staging := filepath.Join(dir, e.Name())
+		if !stagingLive(staging) {
+			_ = os.RemoveAll(staging)
+		}
+	}
+}
+
+// stagingLive reports whether the process that created a staging directory
+// may still be using it
+func stagingLive(staging string) bool {
+	data, err := os.ReadFile(filepath.Join(staging, ownerFile))
+	if err != nil {
+		info, err := os.Stat(staging)
+		return err == nil && time.Since(info.ModTime()) < staleAfter
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	return err == nil && (pid == os.Getpid() || processAlive(pid))
+}
+
+// Recover rolls back a commit into target that was interrupted, reporting
+// whether there was one, then removes the staging directories of
+// generations that were killed before committing
+func Recover(target string) (bool, error) {
+	defer sweepStaging(filepath.Join(target, StateDir), stagingPrefix)
+	path := filepath.Join(target, StateDir, "journal.json")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	j := &journal{path: path}
+	if err := json.Unmarshal(data, j); err != nil {
+		return false, fmt.Errorf("corrupt journal %s: %w", path, err)
+	}
+	if err := j.rollback(); err != nil {
+		return false, err
+	}
+	if j.Staging != "" {
+		_ = os.RemoveAll(j.Staging)
+	}
+	return true, nil
+}
+
+// save writes the journal atomically
+func (j *journal) save() error {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// rollback undoes the journaled moves in reverse and removes the journal.
+// An entry whose backup is missing never got as far as moving the original.
+func (j *journal) rollback() error {
+	for i := len(j.Entries) - 1; i >= 0; i-- {
+		e := j.Entries[i]
+		if e.Backup == "" {
+			if err := os.RemoveAll(e.Path); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := os.Lstat(e.Backup); err != nil {
+			continue
+		}
+		if err := os.RemoveAll(e.Path); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(e.Path), 0755); err != nil {
+			return err
+		}
+		if err := os.Rename(e.Backup, e.Path); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(j.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
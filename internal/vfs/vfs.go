@@ -0,0 +1,119 @@
+// Package vfs is the filesystem abstraction generators write through.
+//
+// Generators only see the FS interface, so the same code can write to disk
+// (Disk), to a staging directory committed all at once (Transaction), to
+// memory for tests (Memory), to an overlay that records what would change
+// (DryRun) or straight into a zip or tar.gz (Archive). Paths are ordinary OS
+// paths for the final location; the other implementations map or capture
+// them.
+package vfs
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FS is the set of filesystem operations generators need
+type FS interface {
+	// MkdirAll creates a directory and any missing parents
+	MkdirAll(path string) error
+
+	// WriteFile creates or replaces a file. The parent directory must exist.
+	WriteFile(path string, data []byte, perm fs.FileMode) error
+
+	// ReadFile returns a file's contents; missing files report fs.ErrNotExist
+	ReadFile(path string) ([]byte, error)
+
+	// Stat describes a file or directory; missing paths report fs.ErrNotExist
+	Stat(path string) (fs.FileInfo, error)
+}
+
+// HostPather is implemented by filesystems whose files live on disk,
+// possibly somewhere other than their path (see Transaction)
+type HostPather interface {
+	HostPath(path string) (string, bool)
+}
+
+// HostPath returns where path is stored on disk, for external tools that
+// can't write through an FS. It returns false for filesystems not backed by
+// the disk.
+func HostPath(fsys FS, path string) (string, bool) {
+	if h, ok := fsys.(HostPather); ok {
+		return h.HostPath(path)
+	}
+	return path, false
+}
+
+// Remover is implemented by filesystems that can delete files
+type Remover interface {
+	Remove(path string) error
+}
+
+// Remove deletes a file through fsys. Filesystems that can't delete (such
+// as archives) report errors.ErrUnsupported.
+func Remove(fsys FS, path string) error {
+	if r, ok := fsys.(Remover); ok {
+		return r.Remove(path)
+	}
+	return &fs.PathError{Op: "remove", Path: path, Err: errors.ErrUnsupported}
+}
+
+// Disk is the real filesystem
+type Disk struct{}
+
+// HostPath returns path unchanged
+func (Disk) HostPath(path string) (string, bool) {
+	return path, true
+}
+
+// MkdirAll creates a directory with mode 0755
+func (Disk) MkdirAll(path string) error {
+	return os.MkdirAll(path, 0755)
+}
+
+// WriteFile writes a file on disk
+func (Disk) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(path, data, perm)
+}
+
+// ReadFile reads a file from disk
+func (Disk) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+// Remove deletes a file on disk
+func (Disk) Remove(path string) error {
+	return os.Remove(path)
+}
+
+// Stat describes a path on disk
+func (Disk) Stat(path string) (fs.FileInfo, error) {
+	return os.Stat(path)
+}
+
+// WriteString writes a text file with mode 0644
+func WriteString(fsys FS, path, content string) error {
+	return fsys.WriteFile(path, []byte(content), 0644)
+}
+
+// Exists reports whether path exists in fsys
+func Exists(fsys FS, path string) bool {
+	_, err := fsys.Stat(path)
+	return err == nil
+}
+
+// checkParent returns an error like the one os.WriteFile gives when the
+// parent directory of path is missing
+func checkParent(fsys FS, path string) error {
+	dir := filepath.Dir(path)
+	info, err := fsys.Stat(dir)
+	if err != nil {
+		return &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	if !info.IsDir() {
+		return &fs.PathError{Op: "open", Path: path, Err: fs.ErrInvalid}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package vfs
+
+import "io/fs"
+
+// Watcher wraps an FS and calls a function before every write, which can
+// fail the write to stop generation midway (on cancellation, for example)
+type Watcher struct {
+	FS
+
+	before func(path string, dir bool) error
+}
+
+// Watch wraps fsys so before is called ahead of each MkdirAll (dir true)
+// and WriteFile
+func Watch(fsys FS, before func(path string, dir bool) error) *Watcher {
+	return &Watcher{FS: fsys, before: before}
+}
+
+// MkdirAll calls the watch function, then creates the directory
+func (w *Watcher) MkdirAll(path string) error {
+	if err := w.before(path, true); err != nil {
+		return err
+	}
+	return w.FS.MkdirAll(path)
+}
+
+// WriteFile calls the watch function, then writes the file
+func (w *Watcher) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	if err := w.before(path, false); err != nil {
+		return err
+	}
+	return w.FS.WriteFile(path, data, perm)
+}
+
+// HostPath forwards to the wrapped FS
+func (w *Watcher) HostPath(path string) (string, bool) {
+	return HostPath(w.FS, path)
+}
+
+// Remove forwards to the wrapped FS
+func (w *Watcher) Remove(path string) error {
+	return Remove(w.FS, path)
+}
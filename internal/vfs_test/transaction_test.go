@@ -0,0 +1,252 @@
+package vfs_test
+
+import (
+	"encoding/json"
+	"frontforge/internal/testutil"
+	"frontforge/internal/vfs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTransactionNewDirectory(t *testing.T) {
+	parent := testutil.TempDir(t)
+	target := filepath.Join(parent, "app")
+
+	tx, err := vfs.Begin(target)
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, tx.MkdirAll(filepath.Join(target, "src")))
+	testutil.AssertNoError(t, vfs.WriteString(tx, filepath.Join(target, "src", "main.ts"), "x"))
+	testutil.AssertFileNotExists(t, target)
+
+	staged, ok := tx.HostPath(target)
+	if !ok || filepath.Base(staged) != "app" {
+		t.Errorf("HostPath(target) = %s, %v; want a staged dir named app", staged, ok)
+	}
+
+	testutil.AssertNoError(t, tx.Commit())
+	testutil.AssertFileContains(t, filepath.Join(target, "src", "main.ts"), "x")
+
+	// The staging directory is gone
+	entries, _ := os.ReadDir(parent)
+	if len(entries) != 1 {
+		t.Errorf("expected only the project in %s, found %d entries", parent, len(entries))
+	}
+}
+
+func TestTransactionExistingDirectory(t *testing.T) {
+	target := testutil.TempDir(t)
+	testutil.CreateTempFile(t, target, "README.md", "mine")
+	testutil.CreateTempFile(t, target, "notes.txt", "keep")
+
+	tx, err := vfs.Begin(target)
+	testutil.AssertNoError(t, err)
+
+	// Existing files are readable through the transaction
+	data, err := tx.ReadFile(filepath.Join(target, "notes.txt"))
+	if err != nil || string(data) != "keep" {
+		t.Errorf("ReadFile(notes.txt) = %q, %v", data, err)
+	}
+
+	testutil.AssertNoError(t, vfs.WriteString(tx, filepath.Join(target, "README.md"), "generated"))
+	testutil.AssertNoError(t, tx.MkdirAll(filepath.Join(target, "src")))
+	testutil.AssertNoError(t, vfs.WriteString(tx, filepath.Join(target, "src", "main.ts"), "x"))
+	testutil.AssertFileContains(t, filepath.Join(target, "README.md"), "mine")
+
+	testutil.AssertNoError(t, tx.Commit())
+	testutil.AssertFileContains(t, filepath.Join(target, "README.md"), "generated")
+	testutil.AssertFileContains(t, filepath.Join(target, "notes.txt"), "keep")
+	testutil.AssertFileExists(t, filepath.Join(target, "src", "main.ts"))
+	testutil.AssertFileNotExists(t, filepath.Join(target, ".frontforge"))
+}
+
+func TestTransactionRollback(t *testing.T) {
+	target := testutil.TempDir(t)
+	testutil.CreateTempFile(t, target, "README.md", "mine")
+
+	tx, err := vfs.Begin(target)
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, vfs.WriteString(tx, filepath.Join(target, "README.md"), "generated"))
+	testutil.AssertNoError(t, vfs.WriteString(tx, filepath.Join(target, "index.html"), "<html>"))
+	testutil.AssertNoError(t, tx.Rollback())
+
+	testutil.AssertFileContains(t, filepath.Join(target, "README.md"), "mine")
+	testutil.AssertFileNotExists(t, filepath.Join(target, "index.html"))
+	testutil.AssertFileNotExists(t, filepath.Join(target, ".frontforge"))
+
+	// Writes outside the target are refused
+	tx, err = vfs.Begin(target)
+	testutil.AssertNoError(t, err)
+	defer tx.Rollback()
+	if err := vfs.WriteString(tx, filepath.Join(filepath.Dir(target), "escape"), ""); err == nil {
+		t.Error("expected an error writing outside the target")
+	}
+}
+
+func TestTransactionRemove(t *testing.T) {
+	target := testutil.TempDir(t)
+	testutil.CreateTempFile(t, target, "jest.config.js", "config")
+	testutil.CreateTempFile(t, filepath.Join(target, "src", "test"), "setup.js", "setup")
+	testutil.CreateTempFile(t, target, "README.md", "mine")
+
+	tx, err := vfs.Begin(target)
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, vfs.Remove(tx, filepath.Join(target, "jest.config.js")))
+	testutil.AssertNoError(t, vfs.Remove(tx, filepath.Join(target, "src", "test", "setup.js")))
+	if err := vfs.Remove(tx, filepath.Join(target, "missing.js")); err == nil {
+		t.Error("expected an error removing a missing file")
+	}
+
+	// Removed files are gone from the transaction's view only
+	if vfs.Exists(tx, filepath.Join(target, "jest.config.js")) {
+		t.Error("removed file still visible through the transaction")
+	}
+	testutil.AssertFileExists(t, filepath.Join(target, "jest.config.js"))
+
+	testutil.AssertNoError(t, tx.Commit())
+	testutil.AssertFileNotExists(t, filepath.Join(target, "jest.config.js"))
+	testutil.AssertFileNotExists(t, filepath.Join(target, "src"))
+	testutil.AssertFileContains(t, filepath.Join(target, "README.md"), "mine")
+	testutil.AssertFileNotExists(t, filepath.Join(target, ".frontforge"))
+
+	// Rolling back an interrupted removal puts the file and its directory back
+	staging := filepath.Join(target, ".frontforge", "staging-1")
+	testutil.CreateTempFile(t, filepath.Join(staging, "removed", "src", "test"), "setup.js", "setup")
+	journal, _ := json.Marshal(map[string]interface{}{
+		"staging": staging,
+		"entries": []map[string]string{
+			{"path": filepath.Join(target, "src", "test", "setup.js"), "backup": filepath.Join(staging, "removed", "src", "test", "setup.js")},
+		},
+	})
+	testutil.CreateTempFile(t, filepath.Join(target, ".frontforge"), "journal.json", string(journal))
+	recovered, err := vfs.Recover(target)
+	testutil.AssertNoError(t, err)
+	if !recovered {
+		t.Error("expected Recover to report the recovery")
+	}
+	testutil.AssertFileContains(t, filepath.Join(target, "src", "test", "setup.js"), "setup")
+}
+
+func TestTransactionRecoversInterruptedCommit(t *testing.T) {
+	// Simulate a crash halfway through a commit: README.md was swapped for
+	// the generated one and index.html was added
+	target := testutil.TempDir(t)
+	staging := filepath.Join(target, ".frontforge", "staging-1")
+	testutil.CreateTempFile(t, filepath.Join(staging, "backup"), "README.md", "mine")
+	testutil.CreateTempFile(t, target, "README.md", "generated")
+	testutil.CreateTempFile(t, target, "index.html", "<html>")
+
+	journal, _ := json.Marshal(map[string]interface{}{
+		"staging": staging,
+		"entries": []map[string]string{
+			{"path": filepath.Join(target, "README.md"), "backup": filepath.Join(staging, "backup", "README.md")},
+			{"path": filepath.Join(target, "index.html")},
+			{"path": filepath.Join(target, "main.ts")}, // Journaled but never moved
+		},
+	})
+	testutil.CreateTempFile(t, filepath.Join(target, ".frontforge"), "journal.json", string(journal))
+
+	tx, err := vfs.Begin(target)
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, tx.Rollback())
+
+	if !tx.Recovered {
+		t.Error("expected Begin to report the recovery")
+	}
+	testutil.AssertFileContains(t, filepath.Join(target, "README.md"), "mine")
+	testutil.AssertFileNotExists(t, filepath.Join(target, "index.html"))
+	testutil.AssertFileNotExists(t, filepath.Join(target, ".frontforge"))
+}
+
+func TestTransactionSweepsStaleStaging(t *testing.T) {
+	// Staging left by killed generations: one with a dead owner, one that
+	// never got to record its owner, and one still in use by this process
+	parent := testutil.TempDir(t)
+	target := filepath.Join(parent, "app")
+	old := time.Now().Add(-time.Hour)
+	stale := filepath.Join(parent, ".app.frontforge-1")
+	testutil.CreateTempFile(t, filepath.Join(stale, "app"), "package.json", "{}")
+	testutil.CreateTempFile(t, stale, "pid", "not a pid")
+	orphan := filepath.Join(target, ".frontforge", "staging-1")
+	testutil.CreateTempFile(t, filepath.Join(orphan, "app"), "index.html", "<html>")
+	testutil.AssertNoError(t, os.Chtimes(orphan, old, old))
+	live := filepath.Join(target, ".frontforge", "staging-2")
+	testutil.CreateTempFile(t, live, "pid", strconv.Itoa(os.Getpid()))
+	testutil.AssertNoError(t, os.Chtimes(live, old, old))
+
+	tx, err := vfs.Begin(target)
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, tx.Rollback())
+
+	testutil.AssertFileNotExists(t, stale)
+	testutil.AssertFileNotExists(t, orphan)
+	testutil.AssertFileExists(t, live)
+}
+
+func TestTransactionConflicts(t *testing.T) {
+	target := testutil.TempDir(t)
+	testutil.CreateTempFile(t, target, "README.md", "mine")
+	testutil.CreateTempFile(t, target, ".gitignore", "dist")
+	testutil.CreateTempFile(t, target, "index.html", "<html>")
+	testutil.CreateTempFile(t, target, "same.txt", "same")
+
+	tx, err := vfs.Begin(target)
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, vfs.WriteString(tx, filepath.Join(target, "README.md"), "generated"))
+	testutil.AssertNoError(t, vfs.WriteString(tx, filepath.Join(target, ".gitignore"), "node_modules"))
+	testutil.AssertNoError(t, vfs.WriteString(tx, filepath.Join(target, "index.html"), "<body>"))
+	testutil.AssertNoError(t, vfs.WriteString(tx, filepath.Join(target, "same.txt"), "same"))
+	testutil.AssertNoError(t, vfs.WriteString(tx, filepath.Join(target, "new.txt"), "new"))
+
+	conflicts, err := tx.Conflicts()
+	testutil.AssertNoError(t, err)
+	var paths []string
+	for _, c := range conflicts {
+		paths = append(paths, filepath.Base(c.Path))
+	}
+	if want := []string{".gitignore", "README.md", "index.html"}; !reflect.DeepEqual(paths, want) {
+		t.Fatalf("Conflicts() = %v, want %v", paths, want)
+	}
+	testutil.AssertEqual(t, string(conflicts[1].Existing), "mine")
+	testutil.AssertEqual(t, string(conflicts[1].Generated), "generated")
+
+	tx.Resolve = func(c vfs.Conflict) (vfs.Resolution, error) {
+		switch filepath.Base(c.Path) {
+		case "README.md":
+			return vfs.Resolution{Keep: true}, nil
+		case ".gitignore":
+			return vfs.Resolution{Data: []byte("dist\nnode_modules")}, nil
+		}
+		return vfs.Resolution{Backup: true}, nil
+	}
+	testutil.AssertNoError(t, tx.Commit())
+
+	testutil.AssertFileContains(t, filepath.Join(target, "README.md"), "mine")
+	testutil.AssertFileContains(t, filepath.Join(target, ".gitignore"), "dist\nnode_modules")
+	testutil.AssertFileContains(t, filepath.Join(target, "index.html"), "<body>")
+	testutil.AssertFileContains(t, filepath.Join(target, "index.html.bak"), "<html>")
+	testutil.AssertFileContains(t, filepath.Join(target, "new.txt"), "new")
+	testutil.AssertFileNotExists(t, filepath.Join(target, ".frontforge"))
+}
+
+func TestTransactionBackupNames(t *testing.T) {
+	// An earlier backup is never overwritten
+	target := testutil.TempDir(t)
+	testutil.CreateTempFile(t, target, "README.md", "second")
+	testutil.CreateTempFile(t, target, "README.md.bak", "first")
+
+	tx, err := vfs.Begin(target)
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, vfs.WriteString(tx, filepath.Join(target, "README.md"), "generated"))
+	tx.Resolve = func(vfs.Conflict) (vfs.Resolution, error) {
+		return vfs.Resolution{Backup: true}, nil
+	}
+	testutil.AssertNoError(t, tx.Commit())
+
+	testutil.AssertFileContains(t, filepath.Join(target, "README.md.bak"), "first")
+	testutil.AssertFileContains(t, filepath.Join(target, "README.md.bak.1"), "second")
+	testutil.AssertFileContains(t, filepath.Join(target, "README.md"), "generated")
+}
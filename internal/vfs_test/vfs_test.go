@@ -0,0 +1,203 @@
+package vfs_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"frontforge/internal/testutil"
+	"frontforge/internal/vfs"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMemory(t *testing.T) {
+	mem := vfs.NewMemory()
+	root := filepath.Join(string(filepath.Separator), "work", "app")
+
+	// Like the disk, writes need an existing parent
+	if err := vfs.WriteString(mem, filepath.Join(root, "src", "main.ts"), "x"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("write without parent: got %v, want ErrNotExist", err)
+	}
+
+	testutil.AssertNoError(t, mem.MkdirAll(filepath.Join(root, "src")))
+	testutil.AssertNoError(t, vfs.WriteString(mem, filepath.Join(root, "src", "main.ts"), "console.log(1)"))
+
+	data, err := mem.ReadFile(filepath.Join(root, "src", "main.ts"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, string(data), "console.log(1)")
+
+	info, err := mem.Stat(root)
+	if err != nil || !info.IsDir() {
+		t.Errorf("Stat(root) = %v, %v; want a directory", info, err)
+	}
+	if _, err := mem.ReadFile(filepath.Join(root, "missing")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadFile(missing) = %v, want ErrNotExist", err)
+	}
+	if files := mem.Files(); !reflect.DeepEqual(files, []string{filepath.Join(root, "src", "main.ts")}) {
+		t.Errorf("Files() = %v", files)
+	}
+}
+
+func TestDryRunReadsThroughToBase(t *testing.T) {
+	dir := testutil.TempDir(t)
+	testutil.CreateTempFile(t, dir, "package.json", `{"name":"app"}`)
+
+	dry := vfs.NewDryRun(vfs.Disk{})
+	data, err := dry.ReadFile(filepath.Join(dir, "package.json"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, string(data), `{"name":"app"}`)
+
+	// Writes are visible to later reads but never reach the disk
+	testutil.AssertNoError(t, vfs.WriteString(dry, filepath.Join(dir, "package.json"), `{"name":"changed"}`))
+	testutil.AssertNoError(t, dry.MkdirAll(filepath.Join(dir, "src")))
+	testutil.AssertNoError(t, vfs.WriteString(dry, filepath.Join(dir, "src", "main.ts"), "x"))
+	testutil.AssertNoError(t, vfs.WriteString(dry, filepath.Join(dir, "package.json"), `{"name":"final"}`))
+
+	data, _ = dry.ReadFile(filepath.Join(dir, "package.json"))
+	testutil.AssertEqual(t, string(data), `{"name":"final"}`)
+	testutil.AssertFileContains(t, filepath.Join(dir, "package.json"), `"app"`)
+	testutil.AssertFileNotExists(t, filepath.Join(dir, "src"))
+
+	var paths []string
+	for _, e := range dry.Entries() {
+		paths = append(paths, e.Path)
+	}
+	want := []string{filepath.Join(dir, "package.json"), filepath.Join(dir, "src"), filepath.Join(dir, "src", "main.ts")}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Entries() = %v, want %v", paths, want)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	mem := vfs.NewMemory()
+	rec := vfs.Record(mem)
+	testutil.AssertNoError(t, rec.MkdirAll("/app"))
+	testutil.AssertNoError(t, vfs.WriteString(rec, "/app/b", "1"))
+	testutil.AssertNoError(t, vfs.WriteString(rec, "/app/a", "2"))
+	testutil.AssertNoError(t, vfs.WriteString(rec, "/app/b", "3"))
+
+	if files := rec.Files(); !reflect.DeepEqual(files, []string{filepath.Clean("/app/b"), filepath.Clean("/app/a")}) {
+		t.Errorf("Files() = %v, want first-write order", files)
+	}
+	if !vfs.Exists(mem, "/app/a") {
+		t.Error("Recorder should write through")
+	}
+
+	// Files kept in place succeed without being recorded
+	keep := vfs.Record(vfs.Watch(mem, func(path string, dir bool) error {
+		if filepath.Base(path) == "kept" {
+			return vfs.ErrKept
+		}
+		return nil
+	}))
+	testutil.AssertNoError(t, vfs.WriteString(keep, "/app/kept", "4"))
+	testutil.AssertNoError(t, vfs.WriteString(keep, "/app/c", "5"))
+	if files := keep.Files(); !reflect.DeepEqual(files, []string{filepath.Clean("/app/c")}) {
+		t.Errorf("Files() = %v, want only the written file", files)
+	}
+
+	// Removals reach the wrapped FS and drop the path from the record
+	testutil.AssertNoError(t, vfs.Remove(rec, "/app/b"))
+	if vfs.Exists(mem, "/app/b") {
+		t.Error("Recorder should forward Remove")
+	}
+	if files := rec.Files(); !reflect.DeepEqual(files, []string{filepath.Clean("/app/a")}) {
+		t.Errorf("Files() = %v, want the removed file dropped", files)
+	}
+}
+
+// writeSample writes the same small project to an archive
+func writeSample(t *testing.T, a *vfs.Archive, root string) {
+	t.Helper()
+	testutil.AssertNoError(t, a.MkdirAll(root))
+	testutil.AssertNoError(t, a.MkdirAll(filepath.Join(root, "src", "lib")))
+	testutil.AssertNoError(t, vfs.WriteString(a, filepath.Join(root, "package.json"), "{}"))
+	testutil.AssertNoError(t, a.WriteFile(filepath.Join(root, "run.sh"), []byte("#!/bin/sh\n"), 0755))
+
+	// Entries can't be replaced once streamed, or written outside the root
+	if err := vfs.WriteString(a, filepath.Join(root, "package.json"), "{}"); err == nil {
+		t.Error("expected an error writing a file twice")
+	}
+	if err := vfs.WriteString(a, filepath.Join(filepath.Dir(root), "escape"), ""); err == nil {
+		t.Error("expected an error writing outside the root")
+	}
+	data, err := a.ReadFile(filepath.Join(root, "package.json"))
+	if err != nil || string(data) != "{}" {
+		t.Errorf("ReadFile = %q, %v", data, err)
+	}
+	testutil.AssertNoError(t, a.Close())
+}
+
+func TestArchiveZip(t *testing.T) {
+	root := filepath.Join(testutil.TempDir(t), "app")
+	var buf bytes.Buffer
+	a, err := vfs.NewArchive(&buf, vfs.FormatZip, root, "my-app")
+	testutil.AssertNoError(t, err)
+	writeSample(t, a, root)
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	testutil.AssertNoError(t, err)
+	modes := make(map[string]fs.FileMode)
+	for _, f := range r.File {
+		modes[f.Name] = f.Mode()
+	}
+	want := map[string]fs.FileMode{
+		"my-app/":             fs.ModeDir | 0755,
+		"my-app/src/":         fs.ModeDir | 0755,
+		"my-app/src/lib/":     fs.ModeDir | 0755,
+		"my-app/package.json": 0644,
+		"my-app/run.sh":       0755,
+	}
+	if !reflect.DeepEqual(modes, want) {
+		t.Errorf("zip entries = %v, want %v", modes, want)
+	}
+	testutil.AssertFileNotExists(t, root)
+}
+
+func TestArchiveTarGz(t *testing.T) {
+	root := filepath.Join(testutil.TempDir(t), "app")
+	var buf bytes.Buffer
+	a, err := vfs.NewArchive(&buf, vfs.FormatTarGz, root, "my-app")
+	testutil.AssertNoError(t, err)
+	writeSample(t, a, root)
+
+	gz, err := gzip.NewReader(&buf)
+	testutil.AssertNoError(t, err)
+	tr := tar.NewReader(gz)
+	contents := make(map[string]string)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		testutil.AssertNoError(t, err)
+		data, _ := io.ReadAll(tr)
+		contents[h.Name] = string(data)
+		if h.Name == "my-app/run.sh" && os.FileMode(h.Mode) != 0755 {
+			t.Errorf("run.sh mode = %o", h.Mode)
+		}
+	}
+	if contents["my-app/package.json"] != "{}" || len(contents) != 5 {
+		t.Errorf("unexpected tar contents: %v", contents)
+	}
+}
+
+func TestArchiveFormatFor(t *testing.T) {
+	tests := map[string]vfs.ArchiveFormat{
+		"out.zip":    vfs.FormatZip,
+		"out.tar.gz": vfs.FormatTarGz,
+		"OUT.TGZ":    vfs.FormatTarGz,
+		"out.tar":    "",
+	}
+	for name, want := range tests {
+		if got, _ := vfs.ArchiveFormatFor(name); got != want {
+			t.Errorf("ArchiveFormatFor(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
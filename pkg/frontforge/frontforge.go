@@ -0,0 +1,449 @@
+// Package frontforge generates frontend projects from Go programs: build
+// tools, internal platforms and tests can embed what the frontforge CLI does.
+//
+//	result, err := frontforge.Generate(ctx, frontforge.Config{
+//		Name:      "web",
+//		Framework: "vue",
+//		Styling:   "tailwind",
+//	}, frontforge.Options{Dir: "/srv/projects/web"})
+//
+// # Compatibility
+//
+// This package is versioned on its own, independently of the CLI, and
+// follows semantic versioning (see Version and CHANGELOG.md):
+//
+//   - a major release may remove exported identifiers or change what they do
+//   - a minor release only adds identifiers, fields and accepted values
+//   - a patch release fixes bugs without changing the API
+//
+// The JSON keys of Config match the config file format of the same CLI
+// release. The generated files themselves follow the CLI: they change as
+// templates and dependency versions are updated, in any release.
+package frontforge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"frontforge/internal/configfile"
+	"frontforge/internal/generators"
+	"frontforge/internal/generators/meta"
+	"frontforge/internal/models"
+	"frontforge/internal/vfs"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Version is the version of this package's API, not of the CLI
+const Version = "1.0.0"
+
+// Config selects the project's stack. Values are either the canonical
+// values listed by ListOptions (e.g. "Tailwind CSS") or their short IDs
+// (e.g. "tailwind"), matched case-insensitively. Empty fields take the
+// defaults of the framework, as the CLI's quick setup does.
+type Config struct {
+	Name            string `json:"name,omitempty"` // Required by Generate
+	Framework       string `json:"framework,omitempty"`
+	Language        string `json:"language,omitempty"`
+	PackageManager  string `json:"packageManager,omitempty"`
+	Styling         string `json:"styling,omitempty"`
+	UILibrary       string `json:"uiLibrary,omitempty"`
+	Routing         string `json:"routing,omitempty"`
+	Testing         string `json:"testing,omitempty"`
+	StateManagement string `json:"stateManagement,omitempty"`
+	FormManagement  string `json:"formManagement,omitempty"`
+	DataFetching    string `json:"dataFetching,omitempty"`
+	Animation       string `json:"animation,omitempty"`
+	Icons           string `json:"icons,omitempty"`
+	DataViz         string `json:"dataViz,omitempty"`
+	Utilities       string `json:"utilities,omitempty"`
+	I18n            string `json:"i18n,omitempty"`
+	Structure       string `json:"structure,omitempty"`
+}
+
+// field returns the Config field for an option group key (OptionGroup.Key)
+func (c *Config) field(key string) *string {
+	return models.KeyedField(c, key)
+}
+
+// ConflictPolicy decides what happens to existing files that differ from
+// the generated ones
+type ConflictPolicy string
+
+const (
+	ConflictOverwrite ConflictPolicy = "overwrite" // Replace the file (the default)
+	ConflictSkip      ConflictPolicy = "skip"      // Keep the existing file
+	ConflictBackup    ConflictPolicy = "backup"    // Replace it, keeping a copy as <name>.bak
+	ConflictMerge     ConflictPolicy = "merge"     // Merge package.json, tsconfig and .gitignore; keep other files
+)
+
+// FS is a filesystem Generate can write to. Paths are absolute paths under
+// Options.Dir. MemoryFS is an in-memory implementation.
+type FS interface {
+	// MkdirAll creates a directory and any missing parents
+	MkdirAll(path string) error
+
+	// WriteFile creates or replaces a file. The parent directory must exist.
+	WriteFile(path string, data []byte, perm fs.FileMode) error
+
+	// ReadFile returns a file's contents; missing files report fs.ErrNotExist
+	ReadFile(path string) ([]byte, error)
+
+	// Stat describes a file or directory; missing paths report fs.ErrNotExist
+	Stat(path string) (fs.FileInfo, error)
+}
+
+// Options configures Generate
+type Options struct {
+	// Dir is the project directory (default: Name in the working directory)
+	Dir string
+
+	// Output receives the generated files. nil writes to the disk: the
+	// project is staged first and moved into place only once it is
+	// complete, so a failed or canceled generation leaves Dir as it was.
+	Output FS
+
+	// OnConflict handles files in Dir that differ from the generated ones
+	OnConflict ConflictPolicy
+
+	// Progress, if not nil, is called as generation advances
+	Progress func(Progress)
+
+	// Logger, if not nil, receives the generator's messages and warnings
+	Logger *slog.Logger
+}
+
+// Step is a stage of generation reported to Options.Progress
+type Step string
+
+const (
+	StepScaffold Step = "scaffold" // A meta-framework's upstream CLI is running
+	StepFile     Step = "file"     // A file was generated; Path names it
+	StepCommit   Step = "commit"   // The staged project is being moved into Dir
+	StepDone     Step = "done"     // Generation finished
+)
+
+// Progress describes one step of generation
+type Progress struct {
+	Step Step
+	Path string // Relative to the project directory, for StepFile
+}
+
+// Result describes a generated project
+type Result struct {
+	Dir       string     // Absolute project directory
+	Files     []string   // Generated files, relative to Dir, with forward slashes
+	Conflicts []Conflict // Existing files that differed from the generated ones
+	Warnings  []string   // Failed post-generation checks
+}
+
+// Conflict records how an existing file that differed from the generated
+// one was handled
+type Conflict struct {
+	Path   string         // Relative to Dir
+	Action ConflictPolicy // What was done; merge falls back to skip for files it can't merge
+}
+
+// Generate generates the project config describes. Cancelling ctx stops
+// generation between files and kills a meta-framework's upstream CLI
+// (Next.js, Astro, SvelteKit). Dependencies are not installed.
+//
+// Errors are *ConfigError or *CompatibilityError for unusable configs,
+// *ScaffoldError when an upstream CLI fails, and wrap ctx.Err() when ctx
+// is done.
+func Generate(ctx context.Context, config Config, opts Options) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, canceled(err)
+	}
+	resolved, err := resolve(config)
+	if err != nil {
+		return nil, err
+	}
+	if config.Name == "" {
+		return nil, &ConfigError{Option: "name", Message: "a project name is required"}
+	}
+	switch opts.OnConflict {
+	case "", ConflictOverwrite, ConflictSkip, ConflictBackup, ConflictMerge:
+	default:
+		return nil, &ConfigError{Option: "onConflict", Value: string(opts.OnConflict),
+			Message: fmt.Sprintf("invalid conflict policy %q. Valid options: overwrite, skip, backup, merge", opts.OnConflict)}
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		dir = resolved.ProjectName
+	}
+	if dir, err = filepath.Abs(dir); err != nil {
+		return nil, err
+	}
+	resolved.ProjectPath = dir
+	resolved.OnConflict = string(opts.OnConflict)
+
+	progress := func(p Progress) {
+		if opts.Progress != nil {
+			opts.Progress(p)
+		}
+	}
+	watch := func(fsys vfs.FS) vfs.FS {
+		return vfs.Watch(fsys, func(path string, isDir bool) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if rel, err := filepath.Rel(dir, path); err == nil && !isDir {
+				progress(Progress{Step: StepFile, Path: filepath.ToSlash(rel)})
+			}
+			return nil
+		})
+	}
+	out := &logWriter{log: opts.Logger}
+	defer out.flush()
+
+	if models.IsMetaFramework(resolved.Framework) {
+		progress(Progress{Step: StepScaffold})
+	}
+	var result *generators.Result
+	if opts.Output == nil {
+		staged, err := generators.StageWith(ctx, resolved, out, watch)
+		if err != nil {
+			return nil, generateError(ctx, err)
+		}
+		if err := ctx.Err(); err != nil {
+			staged.Discard()
+			return nil, canceled(err)
+		}
+		progress(Progress{Step: StepCommit})
+		result, err = staged.Commit(nil)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		result, err = generators.GenerateInto(ctx, watch(opts.Output), resolved, out)
+		if err != nil {
+			return nil, generateError(ctx, err)
+		}
+	}
+	progress(Progress{Step: StepDone})
+
+	r := &Result{Dir: dir, Files: result.Files}
+	for _, c := range result.Conflicts {
+		r.Conflicts = append(r.Conflicts, Conflict{Path: c.Path, Action: ConflictPolicy(c.Action)})
+	}
+	for _, check := range result.Validation {
+		if !check.Passed {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("%s: %s", check.Check, check.Message))
+		}
+	}
+	return r, nil
+}
+
+// Validate normalizes config to canonical values and fills in the defaults
+// of its framework, reporting a *ConfigError for unknown values and a
+// *CompatibilityError for options that don't work together. Options left
+// empty never conflict: their defaults are chosen to fit.
+func Validate(config Config) (Config, error) {
+	resolved, err := resolve(config)
+	if err != nil {
+		return Config{}, err
+	}
+	valid := Config{Name: resolved.ProjectName}
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		*valid.field(g.Key) = *g.Field(&resolved)
+	}
+	return valid, nil
+}
+
+// resolve validates config and builds the full generator config
+func resolve(config Config) (models.Config, error) {
+	file := configfile.File{Version: configfile.CurrentVersion, Name: config.Name}
+	for i := range models.Registry {
+		g := &models.Registry[i]
+		value := *config.field(g.Key)
+		if value == "" {
+			continue
+		}
+		canonical, ok := g.Parse(value)
+		if !ok {
+			return models.Config{}, &ConfigError{Option: g.Key, Value: value,
+				Message: fmt.Sprintf("invalid %s %q. Valid options: %s", g.Key, value, strings.Join(g.Values(), ", "))}
+		}
+		*models.KeyedField(&file, g.Key) = canonical
+	}
+
+	if err := file.Validate(); err != nil {
+		var compat *models.CompatibilityError
+		if errors.As(err, &compat) {
+			return models.Config{}, newCompatibilityError(compat.Violations)
+		}
+		// Values are already canonical, so only the name can be invalid
+		return models.Config{}, &ConfigError{Option: "name", Value: config.Name, Message: err.Error()}
+	}
+	resolved := file.Resolve()
+	if violations := models.CheckCompatibility(resolved); len(violations) > 0 {
+		return models.Config{}, newCompatibilityError(violations)
+	}
+	return resolved, nil
+}
+
+// OptionGroup is one configurable part of the stack, such as styling
+type OptionGroup struct {
+	Key         string   // Config JSON key, e.g. "stateManagement"
+	Name        string   // Noun used in messages, e.g. "state management"
+	Description string   // One-line description
+	Frameworks  []string // Frameworks the group applies to (nil = all)
+	Options     []Option
+}
+
+// Option is one value of an OptionGroup
+type Option struct {
+	ID         string   // Short value, e.g. "tailwind"
+	Value      string   // Canonical value, e.g. "Tailwind CSS"
+	Label      string   // Human-readable description
+	Aliases    []string // Other accepted spellings
+	Frameworks []string // Frameworks supporting the option (nil = all the group applies to)
+	Hidden     bool     // Accepted but not offered by the CLI's interactive setup
+}
+
+// ListOptions returns every option group and the values it accepts, in the
+// order the CLI asks for them
+func ListOptions() []OptionGroup {
+	groups := make([]OptionGroup, 0, len(models.Registry))
+	for _, g := range models.Registry {
+		group := OptionGroup{Key: g.Key, Name: g.Name, Description: g.Description, Frameworks: clone(g.Frameworks)}
+		for _, o := range g.Options {
+			group.Options = append(group.Options, Option{
+				ID: o.ID, Value: o.Value, Label: o.Label,
+				Aliases: clone(o.Aliases), Frameworks: clone(o.Frameworks), Hidden: o.Hidden,
+			})
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// clone copies s so callers can't modify the registry
+func clone(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
+
+// ConfigError reports a config value that isn't accepted
+type ConfigError struct {
+	Option  string // Config JSON key, "name" or "onConflict"
+	Value   string
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	return e.Message
+}
+
+// Violation is one incompatible option
+type Violation struct {
+	Option     string // Config JSON key, e.g. "icons"
+	Value      string // Incompatible value
+	Suggestion string // Compatible replacement, if there is one
+	Message    string
+}
+
+// CompatibilityError reports every option that doesn't work with the rest
+// of the config
+type CompatibilityError struct {
+	Violations []Violation
+	err        error
+}
+
+func newCompatibilityError(violations []models.Violation) *CompatibilityError {
+	e := &CompatibilityError{err: &models.CompatibilityError{Violations: violations}}
+	for _, v := range violations {
+		e.Violations = append(e.Violations, Violation{Option: v.Key, Value: v.Value, Suggestion: v.Suggestion, Message: v.Message})
+	}
+	return e
+}
+
+func (e *CompatibilityError) Error() string {
+	return e.err.Error()
+}
+
+// ScaffoldError reports a meta-framework's upstream CLI failing
+type ScaffoldError struct {
+	Framework string
+	Command   string // Empty when the command couldn't be started
+	ExitCode  int
+	Stderr    string // Last lines of the command's error output
+}
+
+func (e *ScaffoldError) Error() string {
+	return (&meta.ScaffoldError{Framework: e.Framework, Command: e.Command, ExitCode: e.ExitCode, Stderr: e.Stderr}).Error()
+}
+
+// generateError converts errors from the generators to the package's own
+func generateError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return canceled(ctxErr)
+	}
+	var scaffold *meta.ScaffoldError
+	if errors.As(err, &scaffold) {
+		return &ScaffoldError{Framework: scaffold.Framework, Command: scaffold.Command, ExitCode: scaffold.ExitCode, Stderr: scaffold.Stderr}
+	}
+	return err
+}
+
+func canceled(err error) error {
+	return fmt.Errorf("generation canceled: %w", err)
+}
+
+// logWriter turns the generator's printed output into log records, one per
+// non-blank line
+type logWriter struct {
+	log *slog.Logger
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	if w.log == nil {
+		return len(p), nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err != nil {
+			// Keep the partial line for the next write
+			w.buf.Reset()
+			w.buf.WriteString(line)
+			return len(p), nil
+		}
+		w.emit(line)
+	}
+}
+
+// flush logs a final line that didn't end with a newline
+func (w *logWriter) flush() {
+	if w.log == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.emit(w.buf.String())
+	w.buf.Reset()
+}
+
+func (w *logWriter) emit(line string) {
+	if line = strings.TrimSpace(line); line == "" {
+		return
+	}
+	if strings.HasPrefix(line, "Warning:") {
+		w.log.Warn(strings.TrimSpace(strings.TrimPrefix(line, "Warning:")))
+		return
+	}
+	w.log.Info(line)
+}
@@ -0,0 +1,42 @@
+package frontforge
+
+import (
+	"frontforge/internal/vfs"
+	"io/fs"
+)
+
+// MemoryFS is an FS that keeps files in memory, for previews, tests and
+// callers that package the output themselves. It is safe for concurrent use.
+type MemoryFS struct {
+	mem *vfs.Memory
+}
+
+// NewMemoryFS returns an empty in-memory filesystem
+func NewMemoryFS() *MemoryFS {
+	return &MemoryFS{mem: vfs.NewMemory()}
+}
+
+// MkdirAll creates a directory and any missing parents
+func (m *MemoryFS) MkdirAll(path string) error {
+	return m.mem.MkdirAll(path)
+}
+
+// WriteFile creates or replaces a file
+func (m *MemoryFS) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	return m.mem.WriteFile(path, data, perm)
+}
+
+// ReadFile returns a file's contents
+func (m *MemoryFS) ReadFile(path string) ([]byte, error) {
+	return m.mem.ReadFile(path)
+}
+
+// Stat describes a file or directory
+func (m *MemoryFS) Stat(path string) (fs.FileInfo, error) {
+	return m.mem.Stat(path)
+}
+
+// Files returns the paths of all files, sorted
+func (m *MemoryFS) Files() []string {
+	return m.mem.Files()
+}
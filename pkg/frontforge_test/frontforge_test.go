@@ -0,0 +1,177 @@
+package frontforge_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"frontforge/internal/testutil"
+	"frontforge/pkg/frontforge"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateMemory(t *testing.T) {
+	out := frontforge.NewMemoryFS()
+	dir := filepath.Join(string(filepath.Separator), "projects", "web")
+	var files []string
+	var steps []frontforge.Step
+	var logs bytes.Buffer
+
+	result, err := frontforge.Generate(context.Background(),
+		frontforge.Config{Name: "web", Framework: "vue", Styling: "tailwind"},
+		frontforge.Options{
+			Dir:    dir,
+			Output: out,
+			Progress: func(p frontforge.Progress) {
+				steps = append(steps, p.Step)
+				if p.Step == frontforge.StepFile {
+					files = append(files, p.Path)
+				}
+			},
+			Logger: slog.New(slog.NewTextHandler(&logs, nil)),
+		})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, result.Dir, dir)
+
+	pkg, err := out.ReadFile(filepath.Join(dir, "package.json"))
+	testutil.AssertNoError(t, err)
+	if !strings.Contains(string(pkg), "tailwindcss") {
+		t.Errorf("package.json should include Tailwind:\n%s", pkg)
+	}
+	if len(files) == 0 || len(files) < len(result.Files) {
+		t.Errorf("progress reported %d files, want at least %d", len(files), len(result.Files))
+	}
+	testutil.AssertEqual(t, steps[len(steps)-1], frontforge.StepDone)
+	if _, err := os.Stat(dir); err == nil {
+		t.Error("nothing should be written to the disk")
+	}
+}
+
+func TestGenerateDisk(t *testing.T) {
+	dir := filepath.Join(testutil.TempDir(t), "web")
+	testutil.AssertNoError(t, os.MkdirAll(dir, 0755))
+	testutil.CreateTempFile(t, dir, "index.html", "mine")
+
+	result, err := frontforge.Generate(context.Background(),
+		frontforge.Config{Name: "web", Framework: "svelte"},
+		frontforge.Options{Dir: dir, OnConflict: frontforge.ConflictSkip})
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertFileExists(t, filepath.Join(dir, "package.json"))
+	testutil.AssertFileContains(t, filepath.Join(dir, "index.html"), "mine")
+	if len(result.Conflicts) != 1 || result.Conflicts[0] != (frontforge.Conflict{Path: "index.html", Action: frontforge.ConflictSkip}) {
+		t.Errorf("conflicts = %+v, want index.html skipped", result.Conflicts)
+	}
+}
+
+func TestGenerateConflictPolicyInMemory(t *testing.T) {
+	out := frontforge.NewMemoryFS()
+	dir := filepath.Join(string(filepath.Separator), "web")
+	testutil.AssertNoError(t, out.MkdirAll(dir))
+	testutil.AssertNoError(t, out.WriteFile(filepath.Join(dir, "index.html"), []byte("mine"), 0644))
+
+	result, err := frontforge.Generate(context.Background(),
+		frontforge.Config{Name: "web", Framework: "solid"},
+		frontforge.Options{Dir: dir, Output: out, OnConflict: frontforge.ConflictBackup})
+	testutil.AssertNoError(t, err)
+
+	backup, err := out.ReadFile(filepath.Join(dir, "index.html.bak"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, string(backup), "mine")
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Action != frontforge.ConflictBackup {
+		t.Errorf("conflicts = %+v, want index.html backed up", result.Conflicts)
+	}
+}
+
+func TestGenerateCanceled(t *testing.T) {
+	dir := filepath.Join(testutil.TempDir(t), "web")
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := frontforge.Generate(ctx, frontforge.Config{Name: "web"}, frontforge.Options{
+		Dir: dir,
+		Progress: func(p frontforge.Progress) {
+			if p.Step == frontforge.StepFile {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	testutil.AssertFileNotExists(t, dir)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config frontforge.Config
+		opts   frontforge.Options
+		option string // Expected ConfigError.Option; "" expects a CompatibilityError
+	}{
+		{"missing name", frontforge.Config{Framework: "vue"}, frontforge.Options{}, "name"},
+		{"invalid name", frontforge.Config{Name: "my app"}, frontforge.Options{}, "name"},
+		{"unknown value", frontforge.Config{Name: "web", Styling: "crayons"}, frontforge.Options{}, "styling"},
+		{"prompt policy", frontforge.Config{Name: "web"}, frontforge.Options{OnConflict: "prompt"}, "onConflict"},
+		{"incompatible", frontforge.Config{Name: "web", Framework: "vue", UILibrary: "shadcn"}, frontforge.Options{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Output = frontforge.NewMemoryFS()
+			_, err := frontforge.Generate(context.Background(), tt.config, tt.opts)
+			var configErr *frontforge.ConfigError
+			var compat *frontforge.CompatibilityError
+			switch {
+			case tt.option != "":
+				if !errors.As(err, &configErr) || configErr.Option != tt.option {
+					t.Errorf("err = %v, want a ConfigError for %s", err, tt.option)
+				}
+			case !errors.As(err, &compat) || len(compat.Violations) != 1 || compat.Violations[0].Option != "uiLibrary":
+				t.Errorf("err = %v, want a CompatibilityError for uiLibrary", err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	config, err := frontforge.Validate(frontforge.Config{Framework: "vue", Styling: "tailwind"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, config.Framework, "Vue")
+	testutil.AssertEqual(t, config.Styling, "Tailwind CSS")
+	testutil.AssertEqual(t, config.StateManagement, "Pinia")
+}
+
+func TestListOptions(t *testing.T) {
+	groups := frontforge.ListOptions()
+	keys := make(map[string]bool)
+	for _, g := range groups {
+		keys[g.Key] = true
+		if len(g.Options) == 0 {
+			t.Errorf("group %s has no options", g.Key)
+		}
+	}
+	for _, key := range []string{"framework", "styling", "stateManagement"} {
+		if !keys[key] {
+			t.Errorf("missing group %s", key)
+		}
+	}
+
+	// Callers get copies
+	groups[0].Options[0].Aliases = append(groups[0].Options[0].Aliases, "changed")
+	if again := frontforge.ListOptions(); len(again[0].Options[0].Aliases) == len(groups[0].Options[0].Aliases) {
+		t.Error("ListOptions should not share slices with the registry")
+	}
+}
+
+func TestVersionMatchesChangelog(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "frontforge", "CHANGELOG.md"))
+	testutil.AssertNoError(t, err)
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "## ") {
+			testutil.AssertEqual(t, strings.TrimPrefix(line, "## "), frontforge.Version)
+			return
+		}
+	}
+	t.Fatal("CHANGELOG.md has no release sections")
+}